@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/clients"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/handler"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/middleware"
@@ -14,6 +19,8 @@ import (
 	"github.com/joho/godotenv"
 
 	grpcInfra "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/infrastructure/grpc"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/infrastructure/jwks"
+	redisInfra "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/infrastructure/redis"
 )
 
 func main() {
@@ -23,6 +30,23 @@ func main() {
 
 	cfg := config.Load()
 
+	shutdownTracing, err := tracing.Init(&cfg.Tracing, cfg.ServiceName)
+	if err != nil {
+		fmt.Printf("failed to initialize tracing: %v\n", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
+
+	metrics.Init(cfg.ServiceName)
+
+	// Metrics are served on their own port so they aren't reachable through the public listener
+	metricsServer := metrics.NewServer(cfg.Metrics)
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("metrics server error: %v\n", err)
+		}
+	}()
+
 	authConn := grpcInfra.MustConnect(cfg.GRPC.AuthService)
 	defer authConn.Close()
 
@@ -35,21 +59,36 @@ func main() {
 	orderConn := grpcInfra.MustConnect(cfg.GRPC.OrderService)
 	defer orderConn.Close()
 
+	redisClient := redisInfra.MustConnect(cfg.Redis)
+	defer redisClient.Close()
+
 	authClient := clients.NewAuthClient(authConn)
 	productClient := clients.NewProductClient(productConn)
 	stockClient := clients.NewStockClient(stockConn)
 	orderClient := clients.NewOrderClient(orderConn)
 
-	jwtMiddleware := middleware.NewJWTMiddleware(authpb.NewAuthServiceClient(authConn))
+	keySet := jwks.NewKeySet(authpb.NewAuthServiceClient(authConn), cfg.JWT)
+	go keySet.Start(context.Background())
+
+	jwtMiddleware := middleware.NewJWTMiddleware(keySet, cfg.JWT, cfg.Identity)
 	productOwnershipMiddleware := middleware.NewProductOwnershipMiddleware(productClient)
+	admissionMiddleware := middleware.NewAdmissionMiddleware(stockClient, cfg.Admission)
+	rateLimiter := middleware.NewRateLimiter(redisClient, cfg.RateLimit)
 
 	authHandler := handler.NewAuthHandler(authClient)
 	productHandler := handler.NewProductHandler(productClient)
 	stockHandler := handler.NewStockHandler(stockClient)
 	orderHandler := handler.NewOrderHandler(orderClient)
+	auctionHandler := handler.NewAuctionHandler(stockClient)
+	queueHandler := handler.NewQueueHandler(stockClient)
+	jwksHandler := handler.NewJWKSHandler(keySet)
 
 	r := gin.New()
-	router.Register(r, authHandler, jwtMiddleware, productHandler, stockHandler, productOwnershipMiddleware, orderHandler)
+	if err := r.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		fmt.Printf("invalid trusted proxies: %v\n", err)
+		os.Exit(1)
+	}
+	router.Register(r, authHandler, jwtMiddleware, productHandler, stockHandler, productOwnershipMiddleware, orderHandler, auctionHandler, queueHandler, admissionMiddleware, rateLimiter, jwksHandler)
 
 	r.Run(fmt.Sprintf(":%s", cfg.HTTP.Port))
 }
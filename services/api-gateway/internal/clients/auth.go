@@ -15,10 +15,12 @@ func NewAuthClient(conn *grpc.ClientConn) *AuthClient {
 	return &AuthClient{cli: pb.NewAuthServiceClient(conn)}
 }
 
-func (c *AuthClient) Register(ctx context.Context, email, password string) (string, error) {
+func (c *AuthClient) Register(ctx context.Context, email, password, userAgent, ipAddress string) (string, error) {
 	resp, err := c.cli.Register(ctx, &pb.RegisterRequest{
-		Email:    email,
-		Password: password,
+		Email:     email,
+		Password:  password,
+		UserAgent: userAgent,
+		IpAddress: ipAddress,
 	})
 	if err != nil {
 		return "", err
@@ -26,10 +28,12 @@ func (c *AuthClient) Register(ctx context.Context, email, password string) (stri
 	return resp.UserId, nil
 }
 
-func (c *AuthClient) Login(ctx context.Context, email, password string) (string, string, string, error) {
+func (c *AuthClient) Login(ctx context.Context, email, password, userAgent, ipAddress string) (string, string, string, error) {
 	resp, err := c.cli.Login(ctx, &pb.LoginRequest{
-		Email:    email,
-		Password: password,
+		Email:     email,
+		Password:  password,
+		UserAgent: userAgent,
+		IpAddress: ipAddress,
 	})
 	if err != nil {
 		return "", "", "", err
@@ -38,12 +42,88 @@ func (c *AuthClient) Login(ctx context.Context, email, password string) (string,
 	return resp.UserId, resp.AccessToken, resp.RefreshToken, nil
 }
 
-func (c *AuthClient) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
+func (c *AuthClient) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
 	resp, err := c.cli.Refresh(ctx, &pb.RefreshRequest{
 		RefreshToken: refreshToken,
 	})
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
-	return resp.AccessToken, nil
+	return resp.AccessToken, resp.RefreshToken, nil
+}
+
+func (c *AuthClient) Logout(ctx context.Context, refreshToken string) error {
+	_, err := c.cli.Logout(ctx, &pb.LogoutRequest{
+		RefreshToken: refreshToken,
+	})
+	return err
+}
+
+func (c *AuthClient) LogoutAll(ctx context.Context, userID string) (*pb.LogoutAllResponse, error) {
+	return c.cli.LogoutAll(ctx, &pb.LogoutAllRequest{
+		UserId: userID,
+	})
+}
+
+func (c *AuthClient) ListSessions(ctx context.Context, userID string) (*pb.ListSessionsResponse, error) {
+	return c.cli.ListSessions(ctx, &pb.ListSessionsRequest{
+		UserId: userID,
+	})
+}
+
+func (c *AuthClient) GrantRole(ctx context.Context, userID, role string) (*pb.GrantRoleResponse, error) {
+	return c.cli.GrantRole(ctx, &pb.GrantRoleRequest{
+		UserId: userID,
+		Role:   role,
+	})
+}
+
+func (c *AuthClient) RevokeRole(ctx context.Context, userID, role string) (*pb.RevokeRoleResponse, error) {
+	return c.cli.RevokeRole(ctx, &pb.RevokeRoleRequest{
+		UserId: userID,
+		Role:   role,
+	})
+}
+
+func (c *AuthClient) DisableUser(ctx context.Context, userID string) error {
+	_, err := c.cli.DisableUser(ctx, &pb.DisableUserRequest{
+		UserId: userID,
+	})
+	return err
+}
+
+func (c *AuthClient) EnableUser(ctx context.Context, userID string) error {
+	_, err := c.cli.EnableUser(ctx, &pb.EnableUserRequest{
+		UserId: userID,
+	})
+	return err
+}
+
+func (c *AuthClient) RequestEmailVerification(ctx context.Context, userID string) error {
+	_, err := c.cli.RequestEmailVerification(ctx, &pb.RequestEmailVerificationRequest{
+		UserId: userID,
+	})
+	return err
+}
+
+func (c *AuthClient) VerifyEmail(ctx context.Context, token string) error {
+	_, err := c.cli.VerifyEmail(ctx, &pb.VerifyEmailRequest{
+		Token: token,
+	})
+	return err
+}
+
+func (c *AuthClient) RequestPasswordReset(ctx context.Context, email string) error {
+	_, err := c.cli.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{
+		Email: email,
+	})
+	return err
+}
+
+func (c *AuthClient) ResetPassword(ctx context.Context, token, newPassword string) error {
+	_, err := c.cli.ResetPassword(ctx, &pb.ResetPasswordRequest{
+		Token:       token,
+		NewPassword: newPassword,
+	})
+	return err
 }
@@ -35,3 +35,27 @@ func (c *OrderClient) ListUserOrders(
 ) (*pb.ListUserOrdersResponse, error) {
 	return c.client.ListUserOrders(ctx, req)
 }
+
+// PayOrder pays for a pending order on behalf of its owner
+func (c *OrderClient) PayOrder(
+	ctx context.Context,
+	req *pb.PayOrderRequest,
+) (*pb.OrderResponse, error) {
+	return c.client.PayOrder(ctx, req)
+}
+
+// HandlePaymentCallback forwards a payment provider callback to the Order Service
+func (c *OrderClient) HandlePaymentCallback(
+	ctx context.Context,
+	req *pb.PaymentCallbackRequest,
+) (*pb.OrderResponse, error) {
+	return c.client.HandlePaymentCallback(ctx, req)
+}
+
+// CancelOrder cancels a pending order on behalf of its owner
+func (c *OrderClient) CancelOrder(
+	ctx context.Context,
+	req *pb.CancelOrderRequest,
+) (*pb.OrderResponse, error) {
+	return c.client.CancelOrder(ctx, req)
+}
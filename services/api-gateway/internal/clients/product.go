@@ -44,6 +44,22 @@ func (c *ProductClient) UpdateProductPricing(
 	return c.client.UpdateProductPricing(ctx, req)
 }
 
+// UpdateProductPurchaseLimit updates the per-user purchase limit
+func (c *ProductClient) UpdateProductPurchaseLimit(
+	ctx context.Context,
+	req *productv1.UpdateProductPurchaseLimitRequest,
+) (*productv1.UpdateProductPurchaseLimitResponse, error) {
+	return c.client.UpdateProductPurchaseLimit(ctx, req)
+}
+
+// ScheduleProductSale sets the sale window of a product
+func (c *ProductClient) ScheduleProductSale(
+	ctx context.Context,
+	req *productv1.ScheduleProductSaleRequest,
+) (*productv1.ScheduleProductSaleResponse, error) {
+	return c.client.ScheduleProductSale(ctx, req)
+}
+
 // PublishProduct publishes a product
 func (c *ProductClient) PublishProduct(
 	ctx context.Context,
@@ -91,3 +107,83 @@ func (c *ProductClient) GetActiveProducts(
 ) (*productv1.GetActiveProductsResponse, error) {
 	return c.client.GetActiveProducts(ctx, req)
 }
+
+// SearchProducts searches active products
+func (c *ProductClient) SearchProducts(
+	ctx context.Context,
+	req *productv1.SearchProductsRequest,
+) (*productv1.SearchProductsResponse, error) {
+	return c.client.SearchProducts(ctx, req)
+}
+
+// UpdateProductClassification sets the category and tags of a product
+func (c *ProductClient) UpdateProductClassification(
+	ctx context.Context,
+	req *productv1.UpdateProductClassificationRequest,
+) (*productv1.UpdateProductClassificationResponse, error) {
+	return c.client.UpdateProductClassification(ctx, req)
+}
+
+// AddProductImage uploads an image to a product
+func (c *ProductClient) AddProductImage(
+	ctx context.Context,
+	req *productv1.AddProductImageRequest,
+) (*productv1.AddProductImageResponse, error) {
+	return c.client.AddProductImage(ctx, req)
+}
+
+// RemoveProductImage removes an image from a product
+func (c *ProductClient) RemoveProductImage(
+	ctx context.Context,
+	req *productv1.RemoveProductImageRequest,
+) (*productv1.RemoveProductImageResponse, error) {
+	return c.client.RemoveProductImage(ctx, req)
+}
+
+// ReorderProductImages sets the display order of product images
+func (c *ProductClient) ReorderProductImages(
+	ctx context.Context,
+	req *productv1.ReorderProductImagesRequest,
+) (*productv1.ReorderProductImagesResponse, error) {
+	return c.client.ReorderProductImages(ctx, req)
+}
+
+// AddProductVariant adds a variant to a product
+func (c *ProductClient) AddProductVariant(
+	ctx context.Context,
+	req *productv1.AddProductVariantRequest,
+) (*productv1.AddProductVariantResponse, error) {
+	return c.client.AddProductVariant(ctx, req)
+}
+
+// UpdateProductVariant updates a product variant
+func (c *ProductClient) UpdateProductVariant(
+	ctx context.Context,
+	req *productv1.UpdateProductVariantRequest,
+) (*productv1.UpdateProductVariantResponse, error) {
+	return c.client.UpdateProductVariant(ctx, req)
+}
+
+// RemoveProductVariant removes a variant from a product
+func (c *ProductClient) RemoveProductVariant(
+	ctx context.Context,
+	req *productv1.RemoveProductVariantRequest,
+) (*productv1.RemoveProductVariantResponse, error) {
+	return c.client.RemoveProductVariant(ctx, req)
+}
+
+// CreateCategory creates a product category
+func (c *ProductClient) CreateCategory(
+	ctx context.Context,
+	req *productv1.CreateCategoryRequest,
+) (*productv1.CreateCategoryResponse, error) {
+	return c.client.CreateCategory(ctx, req)
+}
+
+// ListCategories lists all product categories
+func (c *ProductClient) ListCategories(
+	ctx context.Context,
+	req *productv1.ListCategoriesRequest,
+) (*productv1.ListCategoriesResponse, error) {
+	return c.client.ListCategories(ctx, req)
+}
@@ -2,9 +2,11 @@ package clients
 
 import (
 	"context"
+	"time"
 
 	stockv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/stock/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type StockClient struct {
@@ -15,24 +17,59 @@ func NewStockClient(conn *grpc.ClientConn) *StockClient {
 	return &StockClient{cli: stockv1.NewStockServiceClient(conn)}
 }
 
-func (c *StockClient) SetStock(ctx context.Context, productID string, quantity int32) (*stockv1.SetStockResponse, error) {
+func (c *StockClient) SetStock(ctx context.Context, productID, variantID string, quantity int32) (*stockv1.SetStockResponse, error) {
 	return c.cli.SetStock(ctx, &stockv1.SetStockRequest{
 		ProductId: productID,
+		VariantId: variantID,
 		Quantity:  quantity,
 	})
 }
 
-func (c *StockClient) GetStock(ctx context.Context, productID string) (*stockv1.GetStockResponse, error) {
-	return c.cli.GetStock(ctx, &stockv1.GetStockRequest{
+func (c *StockClient) AddStock(ctx context.Context, productID, variantID, actorID string, quantity int32, reason, note string) (*stockv1.AdjustStockResponse, error) {
+	return c.cli.AddStock(ctx, &stockv1.AdjustStockRequest{
 		ProductId: productID,
+		VariantId: variantID,
+		ActorId:   actorID,
+		Quantity:  quantity,
+		Reason:    reason,
+		Note:      note,
 	})
 }
 
-func (c *StockClient) Reserve(ctx context.Context, productID, userID string, quantity int32) (*stockv1.ReserveResponse, error) {
-	return c.cli.Reserve(ctx, &stockv1.ReserveRequest{
+func (c *StockClient) RemoveStock(ctx context.Context, productID, variantID, actorID string, quantity int32, reason, note string) (*stockv1.AdjustStockResponse, error) {
+	return c.cli.RemoveStock(ctx, &stockv1.AdjustStockRequest{
 		ProductId: productID,
-		UserId:    userID,
+		VariantId: variantID,
+		ActorId:   actorID,
 		Quantity:  quantity,
+		Reason:    reason,
+		Note:      note,
+	})
+}
+
+func (c *StockClient) ListStockAdjustments(ctx context.Context, productID string, limit, offset int32) (*stockv1.ListStockAdjustmentsResponse, error) {
+	return c.cli.ListStockAdjustments(ctx, &stockv1.ListStockAdjustmentsRequest{
+		ProductId: productID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+}
+
+func (c *StockClient) GetStock(ctx context.Context, productID, variantID string) (*stockv1.GetStockResponse, error) {
+	return c.cli.GetStock(ctx, &stockv1.GetStockRequest{
+		ProductId: productID,
+		VariantId: variantID,
+	})
+}
+
+func (c *StockClient) Reserve(ctx context.Context, productID, variantID, userID string, quantity int32, admissionToken, idempotencyKey string) (*stockv1.ReserveResponse, error) {
+	return c.cli.Reserve(ctx, &stockv1.ReserveRequest{
+		ProductId:      productID,
+		VariantId:      variantID,
+		UserId:         userID,
+		Quantity:       quantity,
+		AdmissionToken: admissionToken,
+		IdempotencyKey: idempotencyKey,
 	})
 }
 
@@ -48,6 +85,73 @@ func (c *StockClient) GetReservation(ctx context.Context, reservationID string)
 	})
 }
 
+func (c *StockClient) EnableQueue(ctx context.Context, productID string, admissionRate int32) (*stockv1.EnableQueueResponse, error) {
+	return c.cli.EnableQueue(ctx, &stockv1.EnableQueueRequest{
+		ProductId:     productID,
+		AdmissionRate: admissionRate,
+	})
+}
+
+func (c *StockClient) DisableQueue(ctx context.Context, productID string) (*stockv1.DisableQueueResponse, error) {
+	return c.cli.DisableQueue(ctx, &stockv1.DisableQueueRequest{
+		ProductId: productID,
+	})
+}
+
+func (c *StockClient) GetQueueStatus(ctx context.Context, productID string) (*stockv1.GetQueueStatusResponse, error) {
+	return c.cli.GetQueueStatus(ctx, &stockv1.GetQueueStatusRequest{
+		ProductId: productID,
+	})
+}
+
+func (c *StockClient) JoinQueue(ctx context.Context, productID, userID string) (*stockv1.JoinQueueResponse, error) {
+	return c.cli.JoinQueue(ctx, &stockv1.JoinQueueRequest{
+		ProductId: productID,
+		UserId:    userID,
+	})
+}
+
+func (c *StockClient) GetQueuePosition(ctx context.Context, productID, userID string) (*stockv1.GetQueuePositionResponse, error) {
+	return c.cli.GetQueuePosition(ctx, &stockv1.GetQueuePositionRequest{
+		ProductId: productID,
+		UserId:    userID,
+	})
+}
+
+func (c *StockClient) CreateAuction(
+	ctx context.Context,
+	productID, sellerID string,
+	startPrice, minIncrement, reservePrice int64,
+	currency string,
+	quantity int32,
+	endsAt time.Time,
+) (*stockv1.CreateAuctionResponse, error) {
+	return c.cli.CreateAuction(ctx, &stockv1.CreateAuctionRequest{
+		ProductId:    productID,
+		SellerId:     sellerID,
+		StartPrice:   startPrice,
+		MinIncrement: minIncrement,
+		ReservePrice: reservePrice,
+		Currency:     currency,
+		Quantity:     quantity,
+		EndsAt:       timestamppb.New(endsAt),
+	})
+}
+
+func (c *StockClient) PlaceBid(ctx context.Context, auctionID, bidderID string, amount int64) (*stockv1.PlaceBidResponse, error) {
+	return c.cli.PlaceBid(ctx, &stockv1.PlaceBidRequest{
+		AuctionId: auctionID,
+		BidderId:  bidderID,
+		Amount:    amount,
+	})
+}
+
+func (c *StockClient) GetAuction(ctx context.Context, auctionID string) (*stockv1.GetAuctionResponse, error) {
+	return c.cli.GetAuction(ctx, &stockv1.GetAuctionRequest{
+		AuctionId: auctionID,
+	})
+}
+
 func (c *StockClient) TriggerRecovery(ctx context.Context, recoveryType string) (*stockv1.TriggerRecoveryResponse, error) {
 	return c.cli.TriggerRecovery(ctx, &stockv1.TriggerRecoveryRequest{
 		RecoveryType: recoveryType,
@@ -1,9 +1,12 @@
 package errors
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -18,10 +21,23 @@ func HandleGRPCError(c *gin.Context, err error) {
 		return
 	}
 
+	setRetryAfter(c, st)
+
 	httpStatus, errorResponse := mapGRPCCodeToHTTP(st.Code(), st.Message())
 	c.JSON(httpStatus, errorResponse)
 }
 
+// setRetryAfter sets Retry-After in whole seconds when the service said how long to wait
+func setRetryAfter(c *gin.Context, st *status.Status) {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			seconds := int(math.Ceil(info.GetRetryDelay().AsDuration().Seconds()))
+			c.Header("Retry-After", strconv.Itoa(max(seconds, 1)))
+			return
+		}
+	}
+}
+
 // mapGRPCCodeToHTTP maps gRPC status code to HTTP status code and response
 func mapGRPCCodeToHTTP(code codes.Code, message string) (int, gin.H) {
 	switch code {
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric of the system, e.g. auction_http_server_requests_total
+const namespace = "auction"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http_server",
+		Name:      "requests_total",
+		Help:      "HTTP requests handled, by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http_server",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency, by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http_server",
+		Name:      "requests_in_flight",
+		Help:      "HTTP requests currently being served.",
+	})
+)
+
+// Init registers the runtime and gateway collectors, labelled with the service name
+func Init(serviceName string) {
+	prometheus.WrapRegistererWith(prometheus.Labels{"service": serviceName}, registry).MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		httpInFlight,
+	)
+}
+
+// NewServer creates the HTTP server exposing /metrics
+func NewServer(cfg config.MetricsConfig) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	return &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+// RequestStarted tracks a request in flight until the returned func is called
+func RequestStarted() func() {
+	httpInFlight.Inc()
+	return httpInFlight.Dec
+}
+
+// ObserveHTTPRequest records a handled HTTP request
+func ObserveHTTPRequest(method, route string, status int, latency time.Duration) {
+	httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpDuration.WithLabelValues(method, route).Observe(latency.Seconds())
+}
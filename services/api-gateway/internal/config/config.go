@@ -1,11 +1,20 @@
 package config
 
+import "strings"
+
 type Config struct {
 	ServiceName string
 	Env         string
 
-	GRPC GRPCConfig
-	HTTP HTTPConfig
+	GRPC      GRPCConfig
+	HTTP      HTTPConfig
+	JWT       JWTConfig
+	Identity  IdentityConfig
+	Admission AdmissionConfig
+	Tracing   TracingConfig
+	Metrics   MetricsConfig
+	Redis     RedisConfig
+	RateLimit RateLimitConfig
 }
 
 type GRPCConfig struct {
@@ -17,6 +26,73 @@ type GRPCConfig struct {
 
 type HTTPConfig struct {
 	Port string
+	// TrustedProxies are the proxy IPs or CIDRs whose X-Forwarded-For is believed. With none,
+	// the client IP is the connection's remote address, so clients can't pick the IP the
+	// rate limits and login throttle count against.
+	TrustedProxies []string
+}
+
+// JWTConfig holds access token verification, done locally with the auth-service's published keys
+type JWTConfig struct {
+	Issuer              string // must match auth-service JWT_ISSUER
+	Audience            string // must match auth-service JWT_AUDIENCE
+	KeysRefreshInterval int    // seconds between JWKS refreshes; unknown key IDs also trigger a refresh
+}
+
+// IdentityConfig holds the secret signing the caller identity forwarded to the services
+type IdentityConfig struct {
+	Secret string // must match the services' IDENTITY_SECRET
+}
+
+type AdmissionConfig struct {
+	TokenSecret    string // must match stock-service ADMISSION_TOKEN_SECRET
+	StatusCacheTTL int    // milliseconds
+}
+
+type TracingConfig struct {
+	Exporter    string  // "none", "stdout", "file" or a registered exporter
+	FilePath    string  // output of the "file" exporter
+	SampleRatio float64 // share of new traces that are sampled
+}
+
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	Port string // HTTP port serving /metrics, kept off the public listener
+}
+
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+// RateLimitConfig holds the rate limit policies, counted in Redis so they hold across gateway replicas
+type RateLimitConfig struct {
+	Enabled bool
+	// Policies are keyed by route group. A route group without a policy is not limited.
+	Policies map[string]RateLimitPolicy
+
+	MaxConcurrentReserves int // in-flight reservations per user
+}
+
+// Route groups the router applies rate limit policies to
+const (
+	RateLimitGroupGlobal        = "global"        // every request, per client IP
+	RateLimitGroupAuthenticated = "authenticated" // every authenticated request, per user
+	RateLimitGroupLogin         = "login"         // login and password reset, per client IP
+	RateLimitGroupReserve       = "reserve"       // stock reservations, per user
+	RateLimitGroupProducts      = "products"      // product routes, per client IP
+	RateLimitGroupStock         = "stock"         // stock routes, per client IP
+	RateLimitGroupQueue         = "queue"         // waiting room routes, per client IP
+	RateLimitGroupOrders        = "orders"        // order routes, per user
+	RateLimitGroupAuctions      = "auctions"      // auction routes, per client IP
+	RateLimitGroupBids          = "bids"          // auction bids, per user
+	RateLimitGroupAdmin         = "admin"         // admin routes, per user
+)
+
+type RateLimitPolicy struct {
+	Limit  int // requests allowed per window; 0 disables the policy
+	Window int // seconds
 }
 
 type GRPCClientConfig struct {
@@ -64,7 +140,71 @@ func Load() *Config {
 		},
 
 		HTTP: HTTPConfig{
-			Port: getEnv("HTTP_PORT", "8080"),
+			Port:           getEnv("HTTP_PORT", "8080"),
+			TrustedProxies: getEnvList("HTTP_TRUSTED_PROXIES"),
+		},
+
+		JWT: JWTConfig{
+			Issuer:              getEnv("JWT_ISSUER", "auth-service"),
+			Audience:            getEnv("JWT_AUDIENCE", "auth-clients"),
+			KeysRefreshInterval: getEnvInt("JWT_KEYS_REFRESH_INTERVAL", 300),
+		},
+
+		Identity: IdentityConfig{
+			Secret: mustEnv("IDENTITY_SECRET"),
+		},
+
+		Admission: AdmissionConfig{
+			TokenSecret:    mustEnv("ADMISSION_TOKEN_SECRET"),
+			StatusCacheTTL: getEnvInt("ADMISSION_STATUS_CACHE_TTL_MS", 2000),
+		},
+
+		Tracing: TracingConfig{
+			Exporter:    getEnv("TRACING_EXPORTER", "none"),
+			FilePath:    getEnv("TRACING_FILE_PATH", "traces.jsonl"),
+			SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1.0),
 		},
+
+		Metrics: MetricsConfig{
+			Port: getEnv("METRICS_PORT", "9100"),
+		},
+
+		Redis: RedisConfig{
+			Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
+			Password: getEnv("REDIS_PASSWORD", ""),
+			DB:       getEnvInt("REDIS_DB", 0),
+		},
+
+		RateLimit: RateLimitConfig{
+			Enabled: getEnvBool("RATE_LIMIT_ENABLED", true),
+			Policies: loadRateLimitPolicies(map[string]RateLimitPolicy{
+				RateLimitGroupGlobal:        {Limit: 100, Window: 1},
+				RateLimitGroupAuthenticated: {Limit: 20, Window: 1},
+				RateLimitGroupLogin:         {Limit: 5, Window: 60},
+				RateLimitGroupReserve:       {Limit: 2, Window: 1},
+				RateLimitGroupProducts:      {Window: 1},
+				RateLimitGroupStock:         {Window: 1},
+				RateLimitGroupQueue:         {Window: 1},
+				RateLimitGroupOrders:        {Window: 1},
+				RateLimitGroupAuctions:      {Window: 1},
+				RateLimitGroupBids:          {Window: 1},
+				RateLimitGroupAdmin:         {Window: 1},
+			}),
+			MaxConcurrentReserves: getEnvInt("RATE_LIMIT_MAX_CONCURRENT_RESERVES", 1),
+		},
+	}
+}
+
+// loadRateLimitPolicies overrides the default policy of each route group with
+// RATE_LIMIT_<GROUP>_LIMIT and RATE_LIMIT_<GROUP>_WINDOW
+func loadRateLimitPolicies(defaults map[string]RateLimitPolicy) map[string]RateLimitPolicy {
+	policies := make(map[string]RateLimitPolicy, len(defaults))
+	for group, policy := range defaults {
+		prefix := "RATE_LIMIT_" + strings.ToUpper(group)
+		policies[group] = RateLimitPolicy{
+			Limit:  getEnvInt(prefix+"_LIMIT", policy.Limit),
+			Window: getEnvInt(prefix+"_WINDOW", policy.Window),
+		}
 	}
+	return policies
 }
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// Auction DTOs
+
+type CreateAuctionRequest struct {
+	StartPrice   int64     `json:"start_price" binding:"required,min=1"`
+	MinIncrement int64     `json:"min_increment" binding:"required,min=1"`
+	ReservePrice int64     `json:"reserve_price" binding:"min=0"`
+	Currency     string    `json:"currency" binding:"required,len=3"`
+	Quantity     int32     `json:"quantity" binding:"omitempty,min=1,max=10"`
+	EndsAt       time.Time `json:"ends_at" binding:"required"`
+}
+
+type PlaceBidRequest struct {
+	Amount int64 `json:"amount" binding:"required,min=1"`
+}
+
+type PlaceBidResponse struct {
+	AuctionID string `json:"auction_id"`
+	Accepted  bool   `json:"accepted"`
+	Amount    int64  `json:"amount"`
+	BidCount  int64  `json:"bid_count"`
+}
+
+type AuctionResponse struct {
+	ID             string    `json:"id"`
+	ProductID      string    `json:"product_id"`
+	SellerID       string    `json:"seller_id"`
+	StartPrice     int64     `json:"start_price"`
+	MinIncrement   int64     `json:"min_increment"`
+	ReserveMet     bool      `json:"reserve_met"`
+	Currency       string    `json:"currency"`
+	Quantity       int32     `json:"quantity"`
+	Status         string    `json:"status"`
+	EndsAt         time.Time `json:"ends_at"`
+	HighestBid     int64     `json:"highest_bid"`
+	BidCount       int32     `json:"bid_count"`
+	MinimumNextBid int64     `json:"minimum_next_bid"`
+	WinnerID       *string   `json:"winner_id,omitempty"`
+	ReservationID  *string   `json:"reservation_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// Order DTOs
+
+type PayOrderRequest struct {
+	PaymentMethod string `json:"payment_method" binding:"omitempty,oneof=MOCK CREDIT_CARD"`
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+type PaymentCallbackRequest struct {
+	OrderID       string `json:"order_id" binding:"required"`
+	PaymentID     string `json:"payment_id" binding:"required"`
+	TransactionID string `json:"transaction_id" binding:"required"`
+	Status        string `json:"status" binding:"required,oneof=COMPLETED FAILED"`
+	FailureReason string `json:"failure_reason"`
+	Signature     string `json:"signature" binding:"required"`
+}
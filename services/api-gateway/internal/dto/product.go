@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // CreateProductRequest represents HTTP request to create product
 type CreateProductRequest struct {
 	Name           string `json:"name" binding:"required,max=200"`
@@ -7,6 +9,8 @@ type CreateProductRequest struct {
 	RegularPrice   int64  `json:"regular_price" binding:"required,min=1"`
 	FlashSalePrice *int64 `json:"flash_sale_price,omitempty" binding:"omitempty,min=1"`
 	Currency       string `json:"currency" binding:"required,len=3"`
+	PriceType      string `json:"price_type,omitempty" binding:"omitempty,oneof=FIXED AUCTION"`
+	PurchaseLimit  int32  `json:"purchase_limit,omitempty" binding:"omitempty,min=0"`
 }
 
 // UpdateProductInfoRequest represents HTTP request to update product info
@@ -22,23 +26,100 @@ type UpdateProductPricingRequest struct {
 	Currency       string `json:"currency" binding:"required,len=3"`
 }
 
+// UpdatePurchaseLimitRequest represents HTTP request to update the per-user purchase limit
+type UpdatePurchaseLimitRequest struct {
+	PurchaseLimit *int32 `json:"purchase_limit" binding:"required,min=0"`
+}
+
+// ScheduleSaleRequest represents HTTP request to set the sale window.
+// Either bound may be omitted; omitting both cancels the schedule.
+type ScheduleSaleRequest struct {
+	SaleStartsAt *time.Time `json:"sale_starts_at,omitempty"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at,omitempty"`
+}
+
+// UpdateClassificationRequest represents HTTP request to set the category and tags.
+// An empty category_id removes the product from its category.
+type UpdateClassificationRequest struct {
+	CategoryID string   `json:"category_id"`
+	Tags       []string `json:"tags" binding:"max=20"`
+}
+
+// ReorderImagesRequest represents HTTP request to reorder product images
+type ReorderImagesRequest struct {
+	ImageIDs []string `json:"image_ids" binding:"required"`
+}
+
+// AddVariantRequest represents HTTP request to add a product variant.
+// The price override is in the product's currency; omit it to sell at the product price.
+type AddVariantRequest struct {
+	SKU           string            `json:"sku" binding:"required,max=64"`
+	Attributes    map[string]string `json:"attributes" binding:"max=10"`
+	PriceOverride *int64            `json:"price_override,omitempty" binding:"omitempty,min=1"`
+}
+
+// UpdateVariantRequest represents HTTP request to update a product variant.
+// Omitting price_override removes the override.
+type UpdateVariantRequest struct {
+	Attributes    map[string]string `json:"attributes" binding:"max=10"`
+	PriceOverride *int64            `json:"price_override,omitempty" binding:"omitempty,min=1"`
+}
+
+// CreateCategoryRequest represents HTTP request to create a category
+type CreateCategoryRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	ParentID string `json:"parent_id,omitempty"`
+}
+
 // ProductResponse represents product data in HTTP response
 type ProductResponse struct {
-	ID          string     `json:"id"`
-	SellerID    string     `json:"seller_id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Pricing     PricingDTO `json:"pricing"`
-	Status      string     `json:"status"`
-	StockStatus string     `json:"stock_status"`
-	CreatedAt   string     `json:"created_at"`
-	UpdatedAt   string     `json:"updated_at"`
+	ID            string       `json:"id"`
+	SellerID      string       `json:"seller_id"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description"`
+	Pricing       PricingDTO   `json:"pricing"`
+	Status        string       `json:"status"`
+	StockStatus   string       `json:"stock_status"`
+	PurchaseLimit int32        `json:"purchase_limit"` // per-user lifetime limit, 0 = unlimited
+	SaleStartsAt  *string      `json:"sale_starts_at,omitempty"`
+	SaleEndsAt    *string      `json:"sale_ends_at,omitempty"`
+	CategoryID    string       `json:"category_id,omitempty"`
+	Tags          []string     `json:"tags"`
+	Images        []ImageDTO   `json:"images"`   // in display order, the first is the cover
+	Variants      []VariantDTO `json:"variants"` // stock_status aggregates the variants' stock status
+	CreatedAt     string       `json:"created_at"`
+	UpdatedAt     string       `json:"updated_at"`
+}
+
+// ImageDTO represents a product image
+type ImageDTO struct {
+	ID          string `json:"id"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+}
+
+// VariantDTO represents a product variant; its stock is addressed by the variant ID
+type VariantDTO struct {
+	ID            string            `json:"id"`
+	SKU           string            `json:"sku"`
+	Attributes    map[string]string `json:"attributes"`
+	PriceOverride *MoneyDTO         `json:"price_override,omitempty"`
+	StockStatus   string            `json:"stock_status"`
+}
+
+// CategoryResponse represents a category in HTTP response
+type CategoryResponse struct {
+	ID        string `json:"id"`
+	ParentID  string `json:"parent_id,omitempty"` // absent for a root category
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
 }
 
 // PricingDTO represents pricing information
 type PricingDTO struct {
 	RegularPrice   MoneyDTO  `json:"regular_price"`
 	FlashSalePrice *MoneyDTO `json:"flash_sale_price,omitempty"`
+	PriceType      string    `json:"price_type"`
 }
 
 // MoneyDTO represents monetary amount
@@ -54,3 +135,14 @@ type ProductListResponse struct {
 	Page     int32             `json:"page"`
 	PageSize int32             `json:"page_size"`
 }
+
+// ProductSearchResponse represents one page of search results
+type ProductSearchResponse struct {
+	Products   []ProductResponse `json:"products"`
+	NextCursor string            `json:"next_cursor,omitempty"` // absent on the last page
+}
+
+// CategoryListResponse represents all categories; clients build the tree from parent_id
+type CategoryListResponse struct {
+	Categories []CategoryResponse `json:"categories"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// Waiting room DTOs
+
+// AdmissionTokenHeader carries the waiting room admission token on reserve requests
+const AdmissionTokenHeader = "X-Admission-Token"
+
+type EnableQueueRequest struct {
+	AdmissionRate int32 `json:"admission_rate" binding:"required,min=1,max=10000"`
+}
+
+type QueueStatusResponse struct {
+	ProductID     string `json:"product_id"`
+	Enabled       bool   `json:"enabled"`
+	AdmissionRate int32  `json:"admission_rate"`
+	Waiting       int64  `json:"waiting"`
+}
+
+type QueueTicketResponse struct {
+	ProductID            string     `json:"product_id"`
+	Position             int64      `json:"position"`
+	Admitted             bool       `json:"admitted"`
+	AdmissionToken       string     `json:"admission_token,omitempty"`
+	AdmissionExpiresAt   *time.Time `json:"admission_expires_at,omitempty"`
+	EstimatedWaitSeconds int64      `json:"estimated_wait_seconds"`
+}
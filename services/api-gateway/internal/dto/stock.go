@@ -5,20 +5,63 @@ import "time"
 // Stock DTOs
 
 type SetStockRequest struct {
-	Quantity int32 `json:"quantity" binding:"required,min=0"`
+	VariantID string `json:"variant_id"` // required for products with variants
+	Quantity  int32  `json:"quantity" binding:"required,min=0"`
 }
 
 type StockResponse struct {
 	ProductID       string    `json:"product_id"`
+	VariantID       string    `json:"variant_id,omitempty"`
 	Quantity        int32     `json:"quantity"`
 	InitialQuantity int32     `json:"initial_quantity"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// Stock adjustment DTOs
+
+type AddStockRequest struct {
+	VariantID string `json:"variant_id"`
+	Quantity  int32  `json:"quantity" binding:"required,min=1"`
+	Reason    string `json:"reason" binding:"required,oneof=RESTOCK RETURN CORRECTION"`
+	Note      string `json:"note" binding:"max=255"`
+}
+
+type RemoveStockRequest struct {
+	VariantID string `json:"variant_id"`
+	Quantity  int32  `json:"quantity" binding:"required,min=1"`
+	Reason    string `json:"reason" binding:"required,oneof=DAMAGED LOST CORRECTION"`
+	Note      string `json:"note" binding:"max=255"`
+}
+
+type StockAdjustmentResponse struct {
+	ID            string    `json:"id"`
+	ProductID     string    `json:"product_id"`
+	VariantID     string    `json:"variant_id,omitempty"`
+	ActorID       string    `json:"actor_id"`
+	Delta         int32     `json:"delta"`
+	Reason        string    `json:"reason"`
+	Note          string    `json:"note,omitempty"`
+	QuantityAfter int32     `json:"quantity_after"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type AdjustStockResponse struct {
+	Adjustment StockAdjustmentResponse `json:"adjustment"`
+	Stock      StockResponse           `json:"stock"`
+}
+
+type ListStockAdjustmentsResponse struct {
+	Adjustments []StockAdjustmentResponse `json:"adjustments"`
+}
+
 // Reservation DTOs
 
+// IdempotencyKeyHeader lets clients retry a reserve request without reserving twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 type ReserveStockRequest struct {
 	ProductID string `json:"product_id" binding:"required"`
+	VariantID string `json:"variant_id"` // required for products with variants
 	Quantity  int32  `json:"quantity" binding:"required,min=1,max=10"`
 }
 
@@ -43,6 +86,7 @@ type GetReservationRequest struct {
 type ReservationResponse struct {
 	ID         string    `json:"id"`
 	ProductID  string    `json:"product_id"`
+	VariantID  string    `json:"variant_id,omitempty"`
 	UserID     string    `json:"user_id"`
 	Quantity   int32     `json:"quantity"`
 	Status     string    `json:"status"`
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/clients"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/common/errors"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/dto"
+	stockv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/stock/v1"
+	"github.com/gin-gonic/gin"
+)
+
+type AuctionHandler struct {
+	stockClient *clients.StockClient
+}
+
+func NewAuctionHandler(stockClient *clients.StockClient) *AuctionHandler {
+	return &AuctionHandler{
+		stockClient: stockClient,
+	}
+}
+
+// CreateAuction handles POST /api/v1/auctions/products/:product_id
+func (h *AuctionHandler) CreateAuction(c *gin.Context) {
+	productID := c.Param("product_id")
+
+	sellerID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.CreateAuctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := h.stockClient.CreateAuction(
+		c.Request.Context(),
+		productID,
+		sellerID.(string),
+		req.StartPrice,
+		req.MinIncrement,
+		req.ReservePrice,
+		req.Currency,
+		req.Quantity,
+		req.EndsAt,
+	)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, protoToAuctionResponse(grpcResp.Auction))
+}
+
+// GetAuction handles GET /api/v1/auctions/:auction_id
+func (h *AuctionHandler) GetAuction(c *gin.Context) {
+	auctionID := c.Param("auction_id")
+
+	grpcResp, err := h.stockClient.GetAuction(c.Request.Context(), auctionID)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToAuctionResponse(grpcResp.Auction))
+}
+
+// PlaceBid handles POST /api/v1/auctions/:auction_id/bids
+func (h *AuctionHandler) PlaceBid(c *gin.Context) {
+	auctionID := c.Param("auction_id")
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.PlaceBidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := h.stockClient.PlaceBid(c.Request.Context(), auctionID, userID.(string), req.Amount)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PlaceBidResponse{
+		AuctionID: auctionID,
+		Accepted:  grpcResp.Accepted,
+		Amount:    grpcResp.Amount,
+		BidCount:  grpcResp.BidCount,
+	})
+}
+
+// Helper functions
+
+func protoToAuctionResponse(a *stockv1.Auction) dto.AuctionResponse {
+	return dto.AuctionResponse{
+		ID:             a.Id,
+		ProductID:      a.ProductId,
+		SellerID:       a.SellerId,
+		StartPrice:     a.StartPrice,
+		MinIncrement:   a.MinIncrement,
+		ReserveMet:     a.ReserveMet,
+		Currency:       a.Currency,
+		Quantity:       a.Quantity,
+		Status:         a.Status,
+		EndsAt:         a.EndsAt.AsTime(),
+		HighestBid:     a.HighestBid,
+		BidCount:       a.BidCount,
+		MinimumNextBid: a.MinimumNextBid,
+		WinnerID:       a.WinnerId,
+		ReservationID:  a.ReservationId,
+		CreatedAt:      a.CreatedAt.AsTime(),
+	}
+}
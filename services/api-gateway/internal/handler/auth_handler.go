@@ -1,10 +1,10 @@
 package handler
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/clients"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/common/errors"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/dto"
 	"github.com/gin-gonic/gin"
 )
@@ -25,7 +25,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	userID, err := h.authClient.Register(context.Background(), req.Email, req.Password)
+	userID, err := h.authClient.Register(c.Request.Context(), req.Email, req.Password, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -42,9 +42,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	_, accessToken, refreshToken, err := h.authClient.Login(context.Background(), req.Email, req.Password)
+	_, accessToken, refreshToken, err := h.authClient.Login(c.Request.Context(), req.Email, req.Password, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		// 401 for wrong credentials, 403 for disabled accounts, 429 while throttled
+		errors.HandleGRPCError(c, err)
 		return
 	}
 
@@ -61,13 +62,181 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	accessToken, err := h.authClient.RefreshToken(context.Background(), req.RefreshToken)
+	accessToken, refreshToken, err := h.authClient.RefreshToken(c.Request.Context(), req.RefreshToken)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.RefreshTokenResponse{
-		AccessToken: accessToken,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+// POST /logout
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authClient.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DELETE /me/sessions
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	resp, err := h.authClient.LogoutAll(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LogoutAllResponse{
+		SessionsRevoked: resp.SessionsRevoked,
 	})
 }
+
+// GET /me/sessions
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	resp, err := h.authClient.ListSessions(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	sessions := make([]dto.SessionResponse, 0, len(resp.Sessions))
+	for _, s := range resp.Sessions {
+		sessions = append(sessions, dto.SessionResponse{
+			ID:         s.Id,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IpAddress,
+			CreatedAt:  s.CreatedAt.AsTime(),
+			LastUsedAt: s.LastUsedAt.AsTime(),
+			ExpiresAt:  s.ExpiresAt.AsTime(),
+		})
+	}
+
+	c.JSON(http.StatusOK, dto.ListSessionsResponse{
+		Sessions: sessions,
+	})
+}
+
+// POST /admin/users/:user_id/roles
+func (h *AuthHandler) GrantRole(c *gin.Context) {
+	var req dto.GrantRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authClient.GrantRole(c.Request.Context(), c.Param("user_id"), req.Role)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserRolesResponse{
+		UserID: resp.UserId,
+		Roles:  resp.Roles,
+	})
+}
+
+// DELETE /admin/users/:user_id/roles/:role
+func (h *AuthHandler) RevokeRole(c *gin.Context) {
+	resp, err := h.authClient.RevokeRole(c.Request.Context(), c.Param("user_id"), c.Param("role"))
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserRolesResponse{
+		UserID: resp.UserId,
+		Roles:  resp.Roles,
+	})
+}
+
+// POST /admin/users/:user_id/disable
+func (h *AuthHandler) DisableUser(c *gin.Context) {
+	if err := h.authClient.DisableUser(c.Request.Context(), c.Param("user_id")); err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// POST /admin/users/:user_id/enable
+func (h *AuthHandler) EnableUser(c *gin.Context) {
+	if err := h.authClient.EnableUser(c.Request.Context(), c.Param("user_id")); err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// POST /me/email-verification
+func (h *AuthHandler) RequestEmailVerification(c *gin.Context) {
+	if err := h.authClient.RequestEmailVerification(c.Request.Context(), c.GetString("userID")); err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// POST /verify-email
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authClient.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// POST /password-reset/request
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req dto.RequestPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authClient.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	// Accepted whether or not the email has an account
+	c.Status(http.StatusAccepted)
+}
+
+// POST /password-reset
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authClient.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/dto"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/infrastructure/jwks"
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	keys *jwks.KeySet
+}
+
+func NewJWKSHandler(keys *jwks.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// GET /.well-known/jwks.json
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	keys := h.keys.JWKS()
+
+	resp := dto.JWKSResponse{Keys: make([]dto.JWKResponse, 0, len(keys))}
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, dto.JWKResponse{
+			Kid: key.Kid,
+			Kty: key.Kty,
+			Alg: key.Alg,
+			Use: key.Use,
+			Crv: key.Crv,
+			X:   key.X,
+			N:   key.N,
+			E:   key.E,
+		})
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, resp)
+}
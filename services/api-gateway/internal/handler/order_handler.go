@@ -6,6 +6,7 @@ import (
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/clients"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/common/errors"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/dto"
 	pb "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/order/v1"
 	"github.com/gin-gonic/gin"
 )
@@ -58,3 +59,85 @@ func (h *OrderHandler) ListUserOrders(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// PayOrder handles POST /v1/orders/:order_id/pay
+func (h *OrderHandler) PayOrder(c *gin.Context) {
+	orderID := c.Param("order_id")
+	userID := c.GetString("userID")
+
+	var req dto.PayOrderRequest
+	// The body is optional; an empty body pays with the default method
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := h.orderClient.PayOrder(c.Request.Context(), &pb.PayOrderRequest{
+		OrderId:       orderID,
+		UserId:        userID,
+		PaymentMethod: req.PaymentMethod,
+	})
+
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CancelOrder handles POST /v1/orders/:order_id/cancel
+func (h *OrderHandler) CancelOrder(c *gin.Context) {
+	orderID := c.Param("order_id")
+	userID := c.GetString("userID")
+
+	var req dto.CancelOrderRequest
+	// The body is optional; the reason defaults on the Order Service side
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := h.orderClient.CancelOrder(c.Request.Context(), &pb.CancelOrderRequest{
+		OrderId: orderID,
+		UserId:  userID,
+		Reason:  req.Reason,
+	})
+
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// HandlePaymentCallback handles POST /v1/payments/callback
+// The callback is authenticated by the provider signature, not by a user JWT.
+func (h *OrderHandler) HandlePaymentCallback(c *gin.Context) {
+	var req dto.PaymentCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.orderClient.HandlePaymentCallback(c.Request.Context(), &pb.PaymentCallbackRequest{
+		OrderId:       req.OrderID,
+		PaymentId:     req.PaymentID,
+		TransactionId: req.TransactionID,
+		Status:        req.Status,
+		FailureReason: req.FailureReason,
+		Signature:     req.Signature,
+	})
+
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
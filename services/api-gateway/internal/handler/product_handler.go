@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/common/errors"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/dto"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ProductHandler handles product-related HTTP requests
@@ -45,6 +48,10 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "flash_sale_price must be less than regular_price"})
 		return
 	}
+	if req.PriceType == "AUCTION" && req.FlashSalePrice != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "flash_sale_price is not allowed for auction products"})
+		return
+	}
 
 	// Call Product Service via gRPC
 	grpcReq := &productv1.CreateProductRequest{
@@ -54,6 +61,8 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		RegularPrice:   req.RegularPrice,
 		FlashSalePrice: req.FlashSalePrice,
 		Currency:       req.Currency,
+		PriceType:      req.PriceType,
+		PurchaseLimit:  req.PurchaseLimit,
 	}
 
 	grpcResp, err := h.productClient.CreateProduct(c.Request.Context(), grpcReq)
@@ -123,6 +132,59 @@ func (h *ProductHandler) UpdateProductPricing(c *gin.Context) {
 	c.JSON(http.StatusOK, protoToProductResponse(grpcResp.Product))
 }
 
+// UpdatePurchaseLimit handles PUT /api/v1/products/:id/purchase-limit
+func (h *ProductHandler) UpdatePurchaseLimit(c *gin.Context) {
+	productID := c.Param("id")
+
+	var req dto.UpdatePurchaseLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcReq := &productv1.UpdateProductPurchaseLimitRequest{
+		ProductId:     productID,
+		PurchaseLimit: *req.PurchaseLimit,
+	}
+
+	grpcResp, err := h.productClient.UpdateProductPurchaseLimit(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToProductResponse(grpcResp.Product))
+}
+
+// ScheduleSale handles PUT /api/v1/products/:id/sale-window
+func (h *ProductHandler) ScheduleSale(c *gin.Context) {
+	productID := c.Param("id")
+
+	var req dto.ScheduleSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcReq := &productv1.ScheduleProductSaleRequest{
+		ProductId: productID,
+	}
+	if req.SaleStartsAt != nil {
+		grpcReq.SaleStartsAt = timestamppb.New(*req.SaleStartsAt)
+	}
+	if req.SaleEndsAt != nil {
+		grpcReq.SaleEndsAt = timestamppb.New(*req.SaleEndsAt)
+	}
+
+	grpcResp, err := h.productClient.ScheduleProductSale(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToProductResponse(grpcResp.Product))
+}
+
 // PublishProduct handles POST /api/v1/products/:id/publish
 func (h *ProductHandler) PublishProduct(c *gin.Context) {
 	productID := c.Param("id")
@@ -239,6 +301,56 @@ func (h *ProductHandler) GetActiveProducts(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// SearchProducts handles GET /api/v1/products/search
+// Query: q, min_price, max_price, currency, stock_status (repeatable), category_id,
+// tag (repeatable), sort, page_size, cursor
+func (h *ProductHandler) SearchProducts(c *gin.Context) {
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	minPrice, err := optionalInt64Query(c, "min_price")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	maxPrice, err := optionalInt64Query(c, "max_price")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcReq := &productv1.SearchProductsRequest{
+		Query:         c.Query("q"),
+		MinPrice:      minPrice,
+		MaxPrice:      maxPrice,
+		Currency:      c.Query("currency"),
+		StockStatuses: c.QueryArray("stock_status"),
+		CategoryId:    c.Query("category_id"),
+		Tags:          c.QueryArray("tag"),
+		Sort:          c.Query("sort"),
+		PageSize:      int32(pageSize),
+		Cursor:        c.Query("cursor"),
+	}
+
+	grpcResp, err := h.productClient.SearchProducts(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	products := make([]dto.ProductResponse, 0, len(grpcResp.Products))
+	for _, p := range grpcResp.Products {
+		products = append(products, protoToProductResponse(p))
+	}
+
+	c.JSON(http.StatusOK, dto.ProductSearchResponse{
+		Products:   products,
+		NextCursor: grpcResp.NextCursor,
+	})
+}
+
 // GetProductsBySeller handles GET /api/v1/sellers/:id/products
 func (h *ProductHandler) GetProductsBySeller(c *gin.Context) {
 	sellerID := c.Param("id")
@@ -280,6 +392,233 @@ func (h *ProductHandler) GetProductsBySeller(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// UpdateClassification handles PUT /api/v1/products/:id/classification
+func (h *ProductHandler) UpdateClassification(c *gin.Context) {
+	productID := c.Param("id")
+
+	var req dto.UpdateClassificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcReq := &productv1.UpdateProductClassificationRequest{
+		ProductId:  productID,
+		CategoryId: req.CategoryID,
+		Tags:       req.Tags,
+	}
+
+	grpcResp, err := h.productClient.UpdateProductClassification(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToProductResponse(grpcResp.Product))
+}
+
+// maxImageUploadSize matches the product service image size limit
+const maxImageUploadSize = 5 * 1024 * 1024
+
+// AddImage handles POST /api/v1/products/:id/images (multipart form field "image")
+func (h *ProductHandler) AddImage(c *gin.Context) {
+	productID := c.Param("id")
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "image file is required"})
+		return
+	}
+	if file.Size > maxImageUploadSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "image must be at most 5 MiB"})
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read image"})
+		return
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxImageUploadSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read image"})
+		return
+	}
+
+	grpcReq := &productv1.AddProductImageRequest{
+		ProductId:   productID,
+		ContentType: http.DetectContentType(data),
+		Data:        data,
+	}
+
+	grpcResp, err := h.productClient.AddProductImage(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, protoToProductResponse(grpcResp.Product))
+}
+
+// RemoveImage handles DELETE /api/v1/products/:id/images/:image_id
+func (h *ProductHandler) RemoveImage(c *gin.Context) {
+	grpcReq := &productv1.RemoveProductImageRequest{
+		ProductId: c.Param("id"),
+		ImageId:   c.Param("image_id"),
+	}
+
+	grpcResp, err := h.productClient.RemoveProductImage(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToProductResponse(grpcResp.Product))
+}
+
+// ReorderImages handles PUT /api/v1/products/:id/images/order
+func (h *ProductHandler) ReorderImages(c *gin.Context) {
+	productID := c.Param("id")
+
+	var req dto.ReorderImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcReq := &productv1.ReorderProductImagesRequest{
+		ProductId: productID,
+		ImageIds:  req.ImageIDs,
+	}
+
+	grpcResp, err := h.productClient.ReorderProductImages(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToProductResponse(grpcResp.Product))
+}
+
+// AddVariant handles POST /api/v1/products/:id/variants
+func (h *ProductHandler) AddVariant(c *gin.Context) {
+	productID := c.Param("id")
+
+	var req dto.AddVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcReq := &productv1.AddProductVariantRequest{
+		ProductId:     productID,
+		Sku:           req.SKU,
+		Attributes:    req.Attributes,
+		PriceOverride: req.PriceOverride,
+	}
+
+	grpcResp, err := h.productClient.AddProductVariant(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, protoToProductResponse(grpcResp.Product))
+}
+
+// UpdateVariant handles PUT /api/v1/products/:id/variants/:variant_id
+func (h *ProductHandler) UpdateVariant(c *gin.Context) {
+	var req dto.UpdateVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcReq := &productv1.UpdateProductVariantRequest{
+		ProductId:     c.Param("id"),
+		VariantId:     c.Param("variant_id"),
+		Attributes:    req.Attributes,
+		PriceOverride: req.PriceOverride,
+	}
+
+	grpcResp, err := h.productClient.UpdateProductVariant(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToProductResponse(grpcResp.Product))
+}
+
+// RemoveVariant handles DELETE /api/v1/products/:id/variants/:variant_id
+func (h *ProductHandler) RemoveVariant(c *gin.Context) {
+	grpcReq := &productv1.RemoveProductVariantRequest{
+		ProductId: c.Param("id"),
+		VariantId: c.Param("variant_id"),
+	}
+
+	grpcResp, err := h.productClient.RemoveProductVariant(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToProductResponse(grpcResp.Product))
+}
+
+// CreateCategory handles POST /api/v1/categories
+func (h *ProductHandler) CreateCategory(c *gin.Context) {
+	var req dto.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcReq := &productv1.CreateCategoryRequest{
+		Name:     req.Name,
+		ParentId: req.ParentID,
+	}
+
+	grpcResp, err := h.productClient.CreateCategory(c.Request.Context(), grpcReq)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, protoToCategoryResponse(grpcResp.Category))
+}
+
+// ListCategories handles GET /api/v1/categories
+func (h *ProductHandler) ListCategories(c *gin.Context) {
+	grpcResp, err := h.productClient.ListCategories(c.Request.Context(), &productv1.ListCategoriesRequest{})
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	categories := make([]dto.CategoryResponse, 0, len(grpcResp.Categories))
+	for _, category := range grpcResp.Categories {
+		categories = append(categories, protoToCategoryResponse(category))
+	}
+
+	c.JSON(http.StatusOK, dto.CategoryListResponse{Categories: categories})
+}
+
+// optionalInt64Query parses an optional integer query parameter, nil when absent
+func optionalInt64Query(c *gin.Context, name string) (*int64, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an integer amount", name)
+	}
+	return &v, nil
+}
+
 // protoToProductResponse converts proto Product to DTO
 func protoToProductResponse(p *productv1.Product) dto.ProductResponse {
 	pricing := dto.PricingDTO{
@@ -287,6 +626,7 @@ func protoToProductResponse(p *productv1.Product) dto.ProductResponse {
 			Amount:   p.Pricing.RegularPrice.Amount,
 			Currency: p.Pricing.RegularPrice.Currency,
 		},
+		PriceType: p.Pricing.PriceType,
 	}
 
 	if p.Pricing.FlashSalePrice != nil {
@@ -296,15 +636,67 @@ func protoToProductResponse(p *productv1.Product) dto.ProductResponse {
 		}
 	}
 
-	return dto.ProductResponse{
-		ID:          p.Id,
-		SellerID:    p.SellerId,
-		Name:        p.Name,
-		Description: p.Description,
-		Pricing:     pricing,
-		Status:      p.Status,
-		StockStatus: p.StockStatus,
-		CreatedAt:   p.CreatedAt.AsTime().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:   p.UpdatedAt.AsTime().Format("2006-01-02T15:04:05Z07:00"),
+	resp := dto.ProductResponse{
+		ID:            p.Id,
+		SellerID:      p.SellerId,
+		Name:          p.Name,
+		Description:   p.Description,
+		Pricing:       pricing,
+		Status:        p.Status,
+		StockStatus:   p.StockStatus,
+		PurchaseLimit: p.PurchaseLimit,
+		CreatedAt:     p.CreatedAt.AsTime().Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:     p.UpdatedAt.AsTime().Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if p.SaleStartsAt != nil {
+		startsAt := p.SaleStartsAt.AsTime().Format("2006-01-02T15:04:05Z07:00")
+		resp.SaleStartsAt = &startsAt
+	}
+	if p.SaleEndsAt != nil {
+		endsAt := p.SaleEndsAt.AsTime().Format("2006-01-02T15:04:05Z07:00")
+		resp.SaleEndsAt = &endsAt
+	}
+
+	resp.CategoryID = p.CategoryId
+	resp.Tags = p.Tags
+	if resp.Tags == nil {
+		resp.Tags = []string{}
+	}
+	resp.Images = make([]dto.ImageDTO, 0, len(p.Images))
+	for _, image := range p.Images {
+		resp.Images = append(resp.Images, dto.ImageDTO{
+			ID:          image.Id,
+			URL:         image.Url,
+			ContentType: image.ContentType,
+		})
+	}
+	resp.Variants = make([]dto.VariantDTO, 0, len(p.Variants))
+	for _, variant := range p.Variants {
+		v := dto.VariantDTO{
+			ID:          variant.Id,
+			SKU:         variant.Sku,
+			Attributes:  variant.Attributes,
+			StockStatus: variant.StockStatus,
+		}
+		if variant.PriceOverride != nil {
+			v.PriceOverride = &dto.MoneyDTO{
+				Amount:   variant.PriceOverride.Amount,
+				Currency: variant.PriceOverride.Currency,
+			}
+		}
+		resp.Variants = append(resp.Variants, v)
+	}
+
+	return resp
+}
+
+// protoToCategoryResponse converts proto Category to DTO
+func protoToCategoryResponse(c *productv1.Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:        c.Id,
+		ParentID:  c.ParentId,
+		Name:      c.Name,
+		CreatedAt: c.CreatedAt.AsTime().Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
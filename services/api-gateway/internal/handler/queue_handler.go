@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/clients"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/common/errors"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/dto"
+	stockv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/stock/v1"
+	"github.com/gin-gonic/gin"
+)
+
+type QueueHandler struct {
+	stockClient *clients.StockClient
+}
+
+func NewQueueHandler(stockClient *clients.StockClient) *QueueHandler {
+	return &QueueHandler{
+		stockClient: stockClient,
+	}
+}
+
+// EnableQueue handles PUT /api/v1/stock/products/:product_id/queue
+func (h *QueueHandler) EnableQueue(c *gin.Context) {
+	productID := c.Param("product_id")
+
+	var req dto.EnableQueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := h.stockClient.EnableQueue(c.Request.Context(), productID, req.AdmissionRate)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToQueueStatusResponse(grpcResp.Status))
+}
+
+// DisableQueue handles DELETE /api/v1/stock/products/:product_id/queue
+func (h *QueueHandler) DisableQueue(c *gin.Context) {
+	productID := c.Param("product_id")
+
+	grpcResp, err := h.stockClient.DisableQueue(c.Request.Context(), productID)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": grpcResp.Success})
+}
+
+// GetQueueStatus handles GET /api/v1/stock/products/:product_id/queue
+func (h *QueueHandler) GetQueueStatus(c *gin.Context) {
+	productID := c.Param("product_id")
+
+	grpcResp, err := h.stockClient.GetQueueStatus(c.Request.Context(), productID)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToQueueStatusResponse(grpcResp.Status))
+}
+
+// JoinQueue handles POST /api/v1/stock/products/:product_id/queue/join
+func (h *QueueHandler) JoinQueue(c *gin.Context) {
+	productID := c.Param("product_id")
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	grpcResp, err := h.stockClient.JoinQueue(c.Request.Context(), productID, userID.(string))
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToQueueTicketResponse(grpcResp.Ticket))
+}
+
+// GetQueuePosition handles GET /api/v1/stock/products/:product_id/queue/position
+func (h *QueueHandler) GetQueuePosition(c *gin.Context) {
+	productID := c.Param("product_id")
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	grpcResp, err := h.stockClient.GetQueuePosition(c.Request.Context(), productID, userID.(string))
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToQueueTicketResponse(grpcResp.Ticket))
+}
+
+// Helper functions
+
+func protoToQueueStatusResponse(s *stockv1.QueueStatus) dto.QueueStatusResponse {
+	return dto.QueueStatusResponse{
+		ProductID:     s.ProductId,
+		Enabled:       s.Enabled,
+		AdmissionRate: s.AdmissionRate,
+		Waiting:       s.Waiting,
+	}
+}
+
+func protoToQueueTicketResponse(t *stockv1.QueueTicket) dto.QueueTicketResponse {
+	resp := dto.QueueTicketResponse{
+		ProductID:            t.ProductId,
+		Position:             t.Position,
+		Admitted:             t.Admitted,
+		AdmissionToken:       t.AdmissionToken,
+		EstimatedWaitSeconds: t.EstimatedWaitSeconds,
+	}
+
+	if t.AdmissionExpiresAt != nil {
+		expiresAt := t.AdmissionExpiresAt.AsTime()
+		resp.AdmissionExpiresAt = &expiresAt
+	}
+
+	return resp
+}
@@ -2,12 +2,14 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/clients"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/common/errors"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/dto"
 	stockv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/stock/v1"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type StockHandler struct {
@@ -30,7 +32,7 @@ func (h *StockHandler) SetStock(c *gin.Context) {
 		return
 	}
 
-	grpcResp, err := h.stockClient.SetStock(c.Request.Context(), productID, req.Quantity)
+	grpcResp, err := h.stockClient.SetStock(c.Request.Context(), productID, req.VariantID, req.Quantity)
 	if err != nil {
 		errors.HandleGRPCError(c, err)
 		return
@@ -39,11 +41,74 @@ func (h *StockHandler) SetStock(c *gin.Context) {
 	c.JSON(http.StatusOK, protoToStockResponse(grpcResp.Stock))
 }
 
-// GetStock handles GET /api/v1/stock/products/:product_id
+// AddStock handles POST /api/v1/stock/products/:product_id/stock/add
+func (h *StockHandler) AddStock(c *gin.Context) {
+	productID := c.Param("product_id")
+	sellerID := c.GetString("userID")
+
+	var req dto.AddStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := h.stockClient.AddStock(c.Request.Context(), productID, req.VariantID, sellerID, req.Quantity, req.Reason, req.Note)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToAdjustStockResponse(grpcResp))
+}
+
+// RemoveStock handles POST /api/v1/stock/products/:product_id/stock/remove
+func (h *StockHandler) RemoveStock(c *gin.Context) {
+	productID := c.Param("product_id")
+	sellerID := c.GetString("userID")
+
+	var req dto.RemoveStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := h.stockClient.RemoveStock(c.Request.Context(), productID, req.VariantID, sellerID, req.Quantity, req.Reason, req.Note)
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, protoToAdjustStockResponse(grpcResp))
+}
+
+// ListStockAdjustments handles GET /api/v1/stock/products/:product_id/adjustments
+func (h *StockHandler) ListStockAdjustments(c *gin.Context) {
+	productID := c.Param("product_id")
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	grpcResp, err := h.stockClient.ListStockAdjustments(c.Request.Context(), productID, int32(limit), int32(offset))
+	if err != nil {
+		errors.HandleGRPCError(c, err)
+		return
+	}
+
+	adjustments := make([]dto.StockAdjustmentResponse, 0, len(grpcResp.Adjustments))
+	for _, a := range grpcResp.Adjustments {
+		adjustments = append(adjustments, protoToStockAdjustmentResponse(a))
+	}
+
+	c.JSON(http.StatusOK, dto.ListStockAdjustmentsResponse{
+		Adjustments: adjustments,
+	})
+}
+
+// GetStock handles GET /api/v1/stock/products/:product_id?variant_id=
 func (h *StockHandler) GetStock(c *gin.Context) {
 	productID := c.Param("product_id")
 
-	grpcResp, err := h.stockClient.GetStock(c.Request.Context(), productID)
+	grpcResp, err := h.stockClient.GetStock(c.Request.Context(), productID, c.Query("variant_id"))
 	if err != nil {
 		errors.HandleGRPCError(c, err)
 		return
@@ -60,8 +125,9 @@ func (h *StockHandler) ReserveStock(c *gin.Context) {
 		return
 	}
 
+	// The admission middleware has already read the body
 	var req dto.ReserveStockRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -69,8 +135,11 @@ func (h *StockHandler) ReserveStock(c *gin.Context) {
 	grpcResp, err := h.stockClient.Reserve(
 		c.Request.Context(),
 		req.ProductID,
+		req.VariantID,
 		userID.(string),
 		req.Quantity,
+		c.GetHeader(dto.AdmissionTokenHeader),
+		c.GetHeader(dto.IdempotencyKeyHeader),
 	)
 	if err != nil {
 		errors.HandleGRPCError(c, err)
@@ -138,16 +207,39 @@ func (h *StockHandler) TriggerRecovery(c *gin.Context) {
 func protoToStockResponse(s *stockv1.Stock) dto.StockResponse {
 	return dto.StockResponse{
 		ProductID:       s.ProductId,
+		VariantID:       s.VariantId,
 		Quantity:        s.Quantity,
 		InitialQuantity: s.InitialQuantity,
 		UpdatedAt:       s.UpdatedAt.AsTime(),
 	}
 }
 
+func protoToStockAdjustmentResponse(a *stockv1.StockAdjustment) dto.StockAdjustmentResponse {
+	return dto.StockAdjustmentResponse{
+		ID:            a.Id,
+		ProductID:     a.ProductId,
+		VariantID:     a.VariantId,
+		ActorID:       a.ActorId,
+		Delta:         a.Delta,
+		Reason:        a.Reason,
+		Note:          a.Note,
+		QuantityAfter: a.QuantityAfter,
+		CreatedAt:     a.CreatedAt.AsTime(),
+	}
+}
+
+func protoToAdjustStockResponse(r *stockv1.AdjustStockResponse) dto.AdjustStockResponse {
+	return dto.AdjustStockResponse{
+		Adjustment: protoToStockAdjustmentResponse(r.Adjustment),
+		Stock:      protoToStockResponse(r.Stock),
+	}
+}
+
 func protoToReservationResponse(r *stockv1.Reservation) dto.ReservationResponse {
 	return dto.ReservationResponse{
 		ID:         r.Id,
 		ProductID:  r.ProductId,
+		VariantID:  r.VariantId,
 		UserID:     r.UserId,
 		Quantity:   r.Quantity,
 		Status:     r.Status,
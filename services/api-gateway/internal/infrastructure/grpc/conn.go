@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
@@ -17,6 +18,7 @@ func MustConnect(cfg config.GRPCClientConfig) *grpc.ClientConn {
 	conn, err := grpc.NewClient(
 		addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()), // propagates the request's trace to the service
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                time.Duration(cfg.KeepaliveTime) * time.Second,
 			Timeout:             time.Duration(cfg.KeepaliveTimeout) * time.Second,
@@ -0,0 +1,179 @@
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
+	authpb "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/auth/v1"
+	"go.uber.org/zap"
+)
+
+// minRefetchInterval bounds how often tokens with unknown key IDs can make the key set refetch
+const minRefetchInterval = 10 * time.Second
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// PublicKey verifies access tokens signed by the auth-service key of the same key ID
+type PublicKey struct {
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// KeySet caches the auth-service's JWKS so access tokens are verified without a call per request.
+// It refreshes periodically, and on an unknown key ID since a newly rotated key may not be
+// cached yet.
+type KeySet struct {
+	client          authpb.AuthServiceClient
+	refreshInterval time.Duration
+
+	fetchMu   sync.Mutex
+	fetchedAt time.Time
+
+	mu   sync.RWMutex
+	keys map[string]PublicKey
+	jwks []*authpb.JWK
+}
+
+func NewKeySet(client authpb.AuthServiceClient, cfg config.JWTConfig) *KeySet {
+	return &KeySet{
+		client:          client,
+		refreshInterval: time.Duration(cfg.KeysRefreshInterval) * time.Second,
+		keys:            make(map[string]PublicKey),
+	}
+}
+
+// Start refreshes the keys every refresh interval until ctx is done
+func (s *KeySet) Start(ctx context.Context) {
+	if err := s.Refresh(ctx); err != nil {
+		zap.L().Warn("failed to fetch JWKS, retrying on the next refresh", zap.Error(err))
+	}
+
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				zap.L().Warn("failed to refresh JWKS", zap.Error(err))
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Key returns the public key of a key ID
+func (s *KeySet) Key(ctx context.Context, kid string) (PublicKey, error) {
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+
+	// Requests missing the same new key wait for a single fetch
+	s.fetchMu.Lock()
+	var err error
+	if time.Since(s.fetchedAt) >= minRefetchInterval {
+		err = s.fetch(ctx)
+	}
+	s.fetchMu.Unlock()
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return PublicKey{}, ErrUnknownKey
+}
+
+// JWKS returns the cached keys as published by the auth-service
+func (s *KeySet) JWKS() []*authpb.JWK {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.jwks
+}
+
+// Refresh fetches the keys from the auth-service
+func (s *KeySet) Refresh(ctx context.Context) error {
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+	return s.fetch(ctx)
+}
+
+func (s *KeySet) fetch(ctx context.Context) error {
+	// Failed fetches count too, so an auth-service outage isn't hit once per request
+	s.fetchedAt = time.Now()
+
+	resp, err := s.client.GetJWKS(ctx, &authpb.GetJWKSRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]PublicKey, len(resp.Keys))
+	for _, jwk := range resp.Keys {
+		key, err := parseJWK(jwk)
+		if err != nil {
+			zap.L().Warn("skipping invalid JWK",
+				zap.String("kid", jwk.Kid),
+				zap.Error(err),
+			)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.jwks = resp.Keys
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *KeySet) lookup(kid string) (PublicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func parseJWK(jwk *authpb.JWK) (PublicKey, error) {
+	switch jwk.Kty {
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return PublicKey{}, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return PublicKey{}, errors.New("invalid Ed25519 public key")
+		}
+		return PublicKey{Algorithm: jwk.Alg, Key: ed25519.PublicKey(x)}, nil
+
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return PublicKey{}, errors.New("invalid RSA modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return PublicKey{}, errors.New("invalid RSA exponent")
+		}
+		return PublicKey{Algorithm: jwk.Alg, Key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}, nil
+
+	default:
+		return PublicKey{}, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
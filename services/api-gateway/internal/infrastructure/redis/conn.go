@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+func MustConnect(cfg config.RedisConfig) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		fmt.Printf("failed to connect to redis [%s]: %v\n", cfg.Addr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("[REDIS] Connected to %s\n", cfg.Addr)
+
+	return client
+}
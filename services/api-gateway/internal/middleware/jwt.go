@@ -1,15 +1,51 @@
 package middleware
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
-	authpb "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/auth/v1"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/infrastructure/jwks"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 )
 
-func NewJWTMiddleware(authClient authpb.AuthServiceClient) gin.HandlerFunc {
+// Metadata carrying the caller's identity to the services, which use the roles to authorize
+// admin-only methods once they have checked the signature
+const (
+	userIDMetadataKey            = "x-user-id"
+	userRolesMetadataKey         = "x-user-roles"
+	identitySignatureMetadataKey = "x-identity-signature"
+)
+
+// identityTTL bounds how long a forwarded identity signature is accepted
+const identityTTL = time.Minute
+
+// accessClaims are the claims of an auth-service access token
+type accessClaims struct {
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// NewJWTMiddleware verifies access tokens locally against the auth-service's published keys
+func NewJWTMiddleware(keys *jwks.KeySet, cfg config.JWTConfig, identity config.IdentityConfig) gin.HandlerFunc {
+	identitySecret := []byte(identity.Secret)
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(cfg.Audience),
+		jwt.WithExpirationRequired(),
+	)
+
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -23,20 +59,45 @@ func NewJWTMiddleware(authClient authpb.AuthServiceClient) gin.HandlerFunc {
 			return
 		}
 
-		accessToken := parts[1]
-		resp, err := authClient.VerifyToken(c.Request.Context(), &authpb.VerifyRequest{
-			Token: accessToken,
+		var claims accessClaims
+		_, err := parser.ParseWithClaims(parts[1], &claims, func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			key, err := keys.Key(c.Request.Context(), kid)
+			if err != nil {
+				return nil, err
+			}
+			if t.Method.Alg() != key.Algorithm {
+				return nil, errors.New("unexpected signing method")
+			}
+			return key.Key, nil
 		})
-		if err != nil || !resp.Valid {
-			fmt.Println(err)
-			fmt.Println(resp.Valid)
+		if err != nil || claims.Subject == "" {
+			zap.L().Debug("access token rejected", zap.Error(err))
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			return
 		}
 
 		// Store user ID in context for downstream handlers
-		c.Set("userID", resp.UserId)
-		c.Set("role", resp.Role)
-		c.Next()
+		c.Set("userID", claims.Subject)
+		c.Set("roles", claims.Roles)
+
+		// Forward the caller's identity on every gRPC call made with the request context
+		ctx := metadata.AppendToOutgoingContext(c.Request.Context(), userIDMetadataKey, claims.Subject)
+		for _, role := range claims.Roles {
+			ctx = metadata.AppendToOutgoingContext(ctx, userRolesMetadataKey, role)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, identitySignatureMetadataKey,
+			signIdentity(identitySecret, claims.Subject, claims.Roles, time.Now().Add(identityTTL)))
+		c.Request = c.Request.WithContext(ctx)
 	}
 }
+
+// signIdentity returns a "<expires_unix>.<hex hmac-sha256(user_id|roles|expires_unix)>"
+// signature, roles comma-joined, so the services can tell the identity came from the gateway
+func signIdentity(secret []byte, userID string, roles []string, expiresAt time.Time) string {
+	exp := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s|%s|%s", userID, strings.Join(roles, ","), exp)
+	return exp + "." + hex.EncodeToString(mac.Sum(nil))
+}
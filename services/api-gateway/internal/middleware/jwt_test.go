@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignIdentity(t *testing.T) {
+	expiresAt := time.Unix(1790000000, 0)
+
+	// The services verify these exact signatures, see stock-service authorization_test.go
+	tests := []struct {
+		name   string
+		secret string
+		userID string
+		roles  []string
+		want   string
+	}{
+		{
+			name:   "admin and seller",
+			secret: "test-secret",
+			userID: "user-1",
+			roles:  []string{"admin", "seller"},
+			want:   "1790000000.03f8ee87c140c0f88a7ac4157d84539669ff7d979257127aec67d3ec8b176028",
+		},
+		{
+			name:   "buyer",
+			secret: "test-secret",
+			userID: "user-1",
+			roles:  []string{"buyer"},
+			want:   "1790000000.c7dc6c2ca8528b5e08af3b98acb161ab9c3d0ea0a738f55d6e6f361edcb6d0a1",
+		},
+		{
+			name:   "no roles",
+			secret: "test-secret",
+			userID: "user-1",
+			want:   "1790000000.31408f6b7da68f34172d03103c1d258f88a48401407d987b0f48bcbd588885c7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signIdentity([]byte(tt.secret), tt.userID, tt.roles, expiresAt); got != tt.want {
+				t.Fatalf("signIdentity() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/common/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics records request count and latency by route template, so /products/:product_id
+// is one series rather than one per product.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		start := time.Now()
+		done := metrics.RequestStarted()
+		defer done()
+
+		c.Next()
+
+		metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,208 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// fixedWindowScript counts a request in the window key KEYS[1], expiring it after ARGV[1] ms.
+// Returns {count, ttl_ms}
+var fixedWindowScript = redis.NewScript(`
+	local count = redis.call('INCR', KEYS[1])
+	if count == 1 then
+		redis.call('PEXPIRE', KEYS[1], ARGV[1])
+	end
+	return {count, redis.call('PTTL', KEYS[1])}
+`)
+
+// acquireSlotScript takes one of ARGV[1] in-flight slots in KEYS[1].
+// The key expires after ARGV[2] ms so slots leaked by a crashed gateway are freed.
+// Returns 1 when a slot was taken, 0 when all slots are in use
+var acquireSlotScript = redis.NewScript(`
+	local inflight = tonumber(redis.call('GET', KEYS[1]) or '0')
+	if inflight >= tonumber(ARGV[1]) then
+		return 0
+	end
+	redis.call('INCR', KEYS[1])
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+`)
+
+// releaseSlotScript frees an in-flight slot taken by acquireSlotScript
+var releaseSlotScript = redis.NewScript(`
+	if redis.call('DECR', KEYS[1]) <= 0 then
+		redis.call('DEL', KEYS[1])
+	end
+	return 1
+`)
+
+// slotTTL bounds how long an in-flight slot outlives a gateway that never released it
+const slotTTL = 30 * time.Second
+
+// RateLimiter enforces the configured rate limit policies with fixed-window counters in Redis,
+// shared by every gateway replica. Redis errors fail open, since the services behind the
+// gateway enforce their own limits (stock, purchase limits, waiting room).
+type RateLimiter struct {
+	client  *redis.Client
+	cfg     config.RateLimitConfig
+	enabled bool
+}
+
+func NewRateLimiter(client *redis.Client, cfg config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		client:  client,
+		cfg:     cfg,
+		enabled: cfg.Enabled,
+	}
+}
+
+// Group applies the policy configured for the route group. Requests count per user once the
+// JWT middleware has run, and per client IP before it or on public routes.
+func (l *RateLimiter) Group(group string) gin.HandlerFunc {
+	policy, ok := l.cfg.Policies[group]
+	if !ok {
+		return func(*gin.Context) {}
+	}
+
+	return l.limit(group, policy, func(c *gin.Context) string {
+		if userID := c.GetString("userID"); userID != "" {
+			return "user:" + userID
+		}
+		// X-Forwarded-For is only believed behind a trusted proxy
+		return "ip:" + c.ClientIP()
+	})
+}
+
+// Reserve applies the reservation policy and allows one reservation in flight per user
+// (by default), so a flooding client waits for its previous attempt
+func (l *RateLimiter) Reserve() gin.HandlerFunc {
+	perUser := l.Group(config.RateLimitGroupReserve)
+	concurrency := l.LimitConcurrency("reserve", l.cfg.MaxConcurrentReserves)
+
+	return func(c *gin.Context) {
+		if perUser(c); c.IsAborted() {
+			return
+		}
+		concurrency(c)
+	}
+}
+
+func (l *RateLimiter) limit(group string, policy config.RateLimitPolicy, subject func(c *gin.Context) string) gin.HandlerFunc {
+	window := time.Duration(policy.Window) * time.Second
+
+	return func(c *gin.Context) {
+		if !l.enabled || policy.Limit <= 0 || window <= 0 {
+			return
+		}
+
+		now := time.Now()
+		key := fmt.Sprintf("ratelimit:%s:%s:%d", group, subject(c), now.UnixNano()/int64(window))
+
+		count, ttl, err := l.hit(c.Request.Context(), key, window)
+		if err != nil {
+			zap.L().Warn("rate limiter unavailable, allowing request",
+				zap.String("policy", group),
+				zap.Error(err),
+			)
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(policy.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(max(policy.Limit-count, 0)))
+
+		if count > policy.Limit {
+			tooManyRequests(c, ttl, "RATE_LIMITED", "too many requests, please retry later")
+		}
+	}
+}
+
+// hit counts a request in the window key and returns the count and the time left in the window
+func (l *RateLimiter) hit(ctx context.Context, key string, window time.Duration) (int, time.Duration, error) {
+	values, err := fixedWindowScript.Run(ctx, l.client, []string{key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(values) != 2 {
+		return 0, 0, fmt.Errorf("invalid rate limit script result")
+	}
+
+	ttl := time.Duration(values[1]) * time.Millisecond
+	if ttl < 0 {
+		ttl = window
+	}
+	return int(values[0]), ttl, nil
+}
+
+// LimitConcurrency allows at most maxInFlight concurrent requests per user on a route.
+// It must run after the JWT middleware.
+func (l *RateLimiter) LimitConcurrency(name string, maxInFlight int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("userID")
+		if !l.enabled || maxInFlight <= 0 || userID == "" {
+			c.Next()
+			return
+		}
+
+		key := fmt.Sprintf("concurrency:%s:user:%s", name, userID)
+
+		acquired, err := acquireSlotScript.Run(c.Request.Context(), l.client, []string{key}, maxInFlight, slotTTL.Milliseconds()).Int()
+		if err != nil {
+			zap.L().Warn("concurrency guard unavailable, allowing request",
+				zap.String("guard", name),
+				zap.Error(err),
+			)
+			c.Next()
+			return
+		}
+		if acquired == 0 {
+			tooManyRequests(c, time.Second, "TOO_MANY_CONCURRENT_REQUESTS", "a previous request is still in progress")
+			return
+		}
+
+		defer func() {
+			// The request context may already be cancelled
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if err := releaseSlotScript.Run(ctx, l.client, []string{key}).Err(); err != nil {
+				zap.L().Warn("failed to release concurrency slot",
+					zap.String("guard", name),
+					zap.Error(err),
+				)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+// tooManyRequests aborts with 429 and a Retry-After in whole seconds
+func tooManyRequests(c *gin.Context, retryAfter time.Duration, code, message string) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(max(seconds, 1)))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+		"error": message,
+		"code":  code,
+	})
+}
+
+// Chain runs handlers in order as a single middleware, stopping at the first one that aborts.
+// The handlers must not call c.Next.
+func Chain(handlers ...gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, h := range handlers {
+			h(c)
+			if c.IsAborted() {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	RoleBuyer  = "buyer"
+	RoleSeller = "seller"
+	RoleAdmin  = "admin"
+)
+
+// RequireRole allows the request when the user has any of the given roles.
+// It must run after the JWT middleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRoles := c.GetStringSlice("roles")
+
+		for _, role := range roles {
+			if slices.Contains(userRoles, role) {
+				return
+			}
+		}
+
+		zap.L().Warn("user lacks required role",
+			zap.String("user_id", c.GetString("userID")),
+			zap.Strings("required", roles),
+			zap.Strings("roles", userRoles),
+		)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "insufficient role",
+			"code":  "PERMISSION_DENIED",
+		})
+	}
+}
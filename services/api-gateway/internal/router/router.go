@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/handler"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/middleware"
 	v1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/router/v1"
@@ -15,18 +16,34 @@ func Register(
 	stockHandler *handler.StockHandler,
 	productOwnershipMiddleware *middleware.ProductOwnershipMiddleware,
 	orderHandler *handler.OrderHandler,
+	auctionHandler *handler.AuctionHandler,
+	queueHandler *handler.QueueHandler,
+	admissionMiddleware *middleware.AdmissionMiddleware,
+	rateLimiter *middleware.RateLimiter,
+	jwksHandler *handler.JWKSHandler,
 ) {
 	r.Use(gin.Recovery())
+	r.Use(middleware.Tracing())
+	r.Use(middleware.Metrics())
 	r.Use(gin.Logger())
+	r.Use(rateLimiter.Group(config.RateLimitGroupGlobal))
+
+	// Every authenticated request also counts against the per-user limit
+	jwtMiddleware = middleware.Chain(jwtMiddleware, rateLimiter.Group(config.RateLimitGroupAuthenticated))
+
+	r.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
 
 	api := r.Group("/api")
 	{
 		v1Router := api.Group("v1")
 		{
-			v1.RegisterAuth(v1Router, authHandler, jwtMiddleware)
-			v1.RegisterProduct(v1Router, productHandler, jwtMiddleware)
-			v1.RegisterStock(v1Router, stockHandler, jwtMiddleware, productOwnershipMiddleware)
-			v1.RegisterOrder(v1Router, orderHandler, jwtMiddleware)
+			v1.RegisterAuth(v1Router, authHandler, jwtMiddleware, rateLimiter)
+			v1.RegisterProduct(v1Router, productHandler, jwtMiddleware, rateLimiter)
+			v1.RegisterStock(v1Router, stockHandler, jwtMiddleware, productOwnershipMiddleware, admissionMiddleware, rateLimiter)
+			v1.RegisterQueue(v1Router, queueHandler, jwtMiddleware, productOwnershipMiddleware, rateLimiter)
+			v1.RegisterOrder(v1Router, orderHandler, jwtMiddleware, rateLimiter)
+			v1.RegisterAuction(v1Router, auctionHandler, jwtMiddleware, productOwnershipMiddleware, rateLimiter)
+			v1.RegisterAdmin(v1Router, authHandler, jwtMiddleware, rateLimiter)
 		}
 
 	}
@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/handler"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdmin(
+	r *gin.RouterGroup,
+	authHandler *handler.AuthHandler,
+	jwtMiddleware gin.HandlerFunc,
+	rateLimiter *middleware.RateLimiter,
+) {
+	// Admin routes (require the admin role)
+	admin := r.Group("/admin")
+	admin.Use(jwtMiddleware, middleware.RequireRole(middleware.RoleAdmin), rateLimiter.Group(config.RateLimitGroupAdmin))
+	{
+		admin.POST("/users/:user_id/roles", authHandler.GrantRole)
+		admin.DELETE("/users/:user_id/roles/:role", authHandler.RevokeRole)
+		admin.POST("/users/:user_id/disable", authHandler.DisableUser)
+		admin.POST("/users/:user_id/enable", authHandler.EnableUser)
+	}
+}
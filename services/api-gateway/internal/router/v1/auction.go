@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/handler"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAuction(
+	r *gin.RouterGroup,
+	auctionHandler *handler.AuctionHandler,
+	jwtMiddleware gin.HandlerFunc,
+	productOwnershipMiddleware *middleware.ProductOwnershipMiddleware,
+	rateLimiter *middleware.RateLimiter,
+) {
+	auction := r.Group("/auctions", rateLimiter.Group(config.RateLimitGroupAuctions))
+	{
+		// Public routes
+		auction.GET("/:auction_id", auctionHandler.GetAuction)
+
+		// Protected routes (require authentication)
+		authenticated := auction.Group("")
+		authenticated.Use(jwtMiddleware)
+		{
+			authenticated.POST("/:auction_id/bids", rateLimiter.Group(config.RateLimitGroupBids), auctionHandler.PlaceBid)
+		}
+
+		seller := auction.Group("")
+		seller.Use(jwtMiddleware, middleware.RequireRole(middleware.RoleSeller), productOwnershipMiddleware.VerifySeller())
+		{
+			seller.POST("/products/:product_id", auctionHandler.CreateAuction)
+		}
+	}
+}
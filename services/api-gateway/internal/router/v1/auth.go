@@ -1,25 +1,34 @@
 package v1
 
 import (
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/handler"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterAuth(r *gin.RouterGroup, auth *handler.AuthHandler, jwtMiddleware gin.HandlerFunc) {
+func RegisterAuth(r *gin.RouterGroup, auth *handler.AuthHandler, jwtMiddleware gin.HandlerFunc, rateLimiter *middleware.RateLimiter) {
 	r.POST("/register", auth.Register)
-	r.POST("/login", auth.Login)
+	r.POST("/login", rateLimiter.Group(config.RateLimitGroupLogin), auth.Login)
 	r.POST("/refresh", auth.RefreshToken)
+	r.POST("/logout", auth.Logout)
+	r.POST("/verify-email", auth.VerifyEmail)
+	r.POST("/password-reset/request", rateLimiter.Group(config.RateLimitGroupLogin), auth.RequestPasswordReset)
+	r.POST("/password-reset", rateLimiter.Group(config.RateLimitGroupLogin), auth.ResetPassword)
 
 	secured := r.Group("/me")
 	secured.Use(jwtMiddleware)
 	{
 		secured.GET("/", gin.HandlerFunc(func(c *gin.Context) {
 			userID, _ := c.Get("userID")
-			role, _ := c.Get("Role")
+			roles, _ := c.Get("roles")
 			c.JSON(200, gin.H{
 				"userID": userID,
-				"role":   role,
+				"roles":  roles,
 			})
 		}))
+		secured.GET("/sessions", auth.ListSessions)
+		secured.DELETE("/sessions", auth.LogoutAll)
+		secured.POST("/email-verification", auth.RequestEmailVerification)
 	}
 }
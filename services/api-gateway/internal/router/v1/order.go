@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/handler"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -9,16 +11,30 @@ func RegisterOrder(
 	r *gin.RouterGroup,
 	orderHandler *handler.OrderHandler,
 	jwtMiddleware gin.HandlerFunc,
+	rateLimiter *middleware.RateLimiter,
 ) {
 	order := r.Group("/orders")
 
 	// Apply JWT middleware - all order queries must be authenticated
-	order.Use(jwtMiddleware)
+	order.Use(jwtMiddleware, rateLimiter.Group(config.RateLimitGroupOrders))
 	{
 		// GET /v1/orders/:order_id - Get specific order details
 		order.GET("/:order_id", orderHandler.GetOrder)
 
 		// GET /v1/orders - List all orders for the authenticated user
 		order.GET("", orderHandler.ListUserOrders)
+
+		// POST /v1/orders/:order_id/pay - Pay for a pending order
+		order.POST("/:order_id/pay", orderHandler.PayOrder)
+
+		// POST /v1/orders/:order_id/cancel - Cancel a pending order
+		order.POST("/:order_id/cancel", orderHandler.CancelOrder)
+	}
+
+	// Payment provider callbacks are verified by signature in the Order Service
+	payments := r.Group("/payments")
+	{
+		// POST /v1/payments/callback - Asynchronous settlement notification
+		payments.POST("/callback", orderHandler.HandlePaymentCallback)
 	}
 }
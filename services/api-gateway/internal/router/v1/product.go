@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/handler"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -9,27 +11,45 @@ func RegisterProduct(
 	r *gin.RouterGroup,
 	productHandler *handler.ProductHandler,
 	jwtMiddleware gin.HandlerFunc,
+	rateLimiter *middleware.RateLimiter,
 ) {
 	// Product routes
-	products := r.Group("/products")
+	products := r.Group("/products", rateLimiter.Group(config.RateLimitGroupProducts))
 	{
 		// Public routes (no auth required)
+		products.GET("/search", productHandler.SearchProducts)
 		products.GET("/:id", productHandler.GetProduct)
 		products.GET("", productHandler.GetActiveProducts)
 
-		// Protected routes (require authentication)
-		authenticated := products.Group("")
-		authenticated.Use(jwtMiddleware)
+		// Seller routes (require the seller role)
+		seller := products.Group("")
+		seller.Use(jwtMiddleware, middleware.RequireRole(middleware.RoleSeller))
 		{
-			authenticated.POST("", productHandler.CreateProduct)
-			authenticated.PUT("/:id", productHandler.UpdateProductInfo)
-			authenticated.PUT("/:id/pricing", productHandler.UpdateProductPricing)
-			authenticated.POST("/:id/publish", productHandler.PublishProduct)
-			authenticated.POST("/:id/deactivate", productHandler.DeactivateProduct)
-			authenticated.DELETE("/:id", productHandler.DeleteProduct)
+			seller.POST("", productHandler.CreateProduct)
+			seller.PUT("/:id", productHandler.UpdateProductInfo)
+			seller.PUT("/:id/pricing", productHandler.UpdateProductPricing)
+			seller.PUT("/:id/purchase-limit", productHandler.UpdatePurchaseLimit)
+			seller.PUT("/:id/sale-window", productHandler.ScheduleSale)
+			seller.POST("/:id/publish", productHandler.PublishProduct)
+			seller.POST("/:id/deactivate", productHandler.DeactivateProduct)
+			seller.DELETE("/:id", productHandler.DeleteProduct)
+			seller.PUT("/:id/classification", productHandler.UpdateClassification)
+			seller.POST("/:id/images", productHandler.AddImage)
+			seller.DELETE("/:id/images/:image_id", productHandler.RemoveImage)
+			seller.PUT("/:id/images/order", productHandler.ReorderImages)
+			seller.POST("/:id/variants", productHandler.AddVariant)
+			seller.PUT("/:id/variants/:variant_id", productHandler.UpdateVariant)
+			seller.DELETE("/:id/variants/:variant_id", productHandler.RemoveVariant)
 		}
 	}
 
+	// Category routes
+	categories := r.Group("/categories")
+	{
+		categories.GET("", productHandler.ListCategories)
+		categories.POST("", jwtMiddleware, middleware.RequireRole(middleware.RoleAdmin), productHandler.CreateCategory)
+	}
+
 	// Seller routes
 	sellers := r.Group("/sellers")
 	{
@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/handler"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterQueue(
+	r *gin.RouterGroup,
+	queueHandler *handler.QueueHandler,
+	jwtMiddleware gin.HandlerFunc,
+	productOwnershipMiddleware *middleware.ProductOwnershipMiddleware,
+	rateLimiter *middleware.RateLimiter,
+) {
+	// Waiting room routes
+	queue := r.Group("/stock/products/:product_id/queue", rateLimiter.Group(config.RateLimitGroupQueue))
+	{
+		// Public routes
+		queue.GET("", queueHandler.GetQueueStatus)
+
+		// Protected routes (require authentication)
+		authenticated := queue.Group("")
+		authenticated.Use(jwtMiddleware)
+		{
+			authenticated.POST("/join", queueHandler.JoinQueue)
+			authenticated.GET("/position", queueHandler.GetQueuePosition)
+		}
+
+		seller := queue.Group("")
+		seller.Use(jwtMiddleware, middleware.RequireRole(middleware.RoleSeller), productOwnershipMiddleware.VerifySeller())
+		{
+			seller.PUT("", queueHandler.EnableQueue)
+			seller.DELETE("", queueHandler.DisableQueue)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/handler"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/api-gateway/internal/middleware"
 	"github.com/gin-gonic/gin"
@@ -11,9 +12,11 @@ func RegisterStock(
 	stockHandler *handler.StockHandler,
 	jwtMiddleware gin.HandlerFunc,
 	productOwnershipMiddleware *middleware.ProductOwnershipMiddleware,
+	admissionMiddleware *middleware.AdmissionMiddleware,
+	rateLimiter *middleware.RateLimiter,
 ) {
 	// Stock routes
-	stock := r.Group("/stock")
+	stock := r.Group("/stock", rateLimiter.Group(config.RateLimitGroupStock))
 	{
 		// Public routes
 		stock.GET("/products/:product_id", stockHandler.GetStock)
@@ -22,15 +25,24 @@ func RegisterStock(
 		authenticated := stock.Group("")
 		authenticated.Use(jwtMiddleware)
 		{
-			authenticated.POST("/reserve", stockHandler.ReserveStock)
+			authenticated.POST("/reserve", rateLimiter.Reserve(), admissionMiddleware.RequireAdmission(), stockHandler.ReserveStock)
 			authenticated.DELETE("/reservations/:reservation_id", stockHandler.ReleaseReservation)
 			authenticated.GET("/reservations/:reservation_id", stockHandler.GetReservation)
 		}
 
 		seller := stock.Group("")
-		seller.Use(jwtMiddleware, productOwnershipMiddleware.VerifySeller())
+		seller.Use(jwtMiddleware, middleware.RequireRole(middleware.RoleSeller), productOwnershipMiddleware.VerifySeller())
 		{
 			seller.POST("/products/:product_id/stock", stockHandler.SetStock)
+			seller.POST("/products/:product_id/stock/add", stockHandler.AddStock)
+			seller.POST("/products/:product_id/stock/remove", stockHandler.RemoveStock)
+			seller.GET("/products/:product_id/adjustments", stockHandler.ListStockAdjustments)
+		}
+
+		admin := stock.Group("/admin")
+		admin.Use(jwtMiddleware, middleware.RequireRole(middleware.RoleAdmin))
+		{
+			admin.POST("/recovery", stockHandler.TriggerRecovery)
 		}
 	}
 }
@@ -2,25 +2,34 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/application/service"
+	authDomainSvc "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/service"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/port"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/infrastructure/crypto"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/infrastructure/identity"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/infrastructure/jwt"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/infrastructure/mail"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/infrastructure/messaging/kafka"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/infrastructure/persistence/postgres"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/infrastructure/persistence/redis"
 	grpcHandler "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/interface/grpc"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/config"
 	pb "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/auth/v1"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -44,6 +53,16 @@ func main() {
 		zap.String("environment", cfg.Logger.Environment),
 	)
 
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(&cfg.Tracing, cfg.ServiceName)
+	if err != nil {
+		log.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	// Initialize metrics
+	metrics.Init(cfg.ServiceName)
+	metricsServer := metrics.NewServer(cfg.Metrics)
+
 	// Initialize infrastructure
 	log.Info("connecting to database")
 	db := postgres.MustConnect(cfg.Database.DSN)
@@ -56,11 +75,33 @@ func main() {
 	// Initialize repositories
 	userRepo := postgres.NewUserRepository(db)
 	refreshRepo := redis.NewRedisRefreshRepo(redisClient)
+	sessionRepo := redis.NewRedisSessionRepo(redisClient)
+	oneTimeTokenRepo := redis.NewRedisOneTimeTokenRepo(redisClient)
+	loginAttemptRepo := redis.NewRedisLoginAttemptRepo(redisClient)
 
 	// Initialize domain services
-	jwtProvider := jwt.NewJWTProvider(cfg.JWT)
+	signingKeyRepo := redis.NewRedisSigningKeyRepo(redisClient, cfg.JWT.KeyEncryptionKey)
+	keyring := jwt.NewKeyring(signingKeyRepo, cfg.JWT)
+	if err := keyring.Init(context.Background()); err != nil {
+		log.Fatal("failed to initialize signing keys", zap.Error(err))
+	}
+	jwtProvider := jwt.NewJWTProvider(cfg.JWT, keyring)
 	bcryptVerifier := crypto.NewBcryptVerifier(cfg.Bcrypt.Cost)
 	idGenerator := identity.NewUUIDGenerator()
+	mailer := newMailer(cfg.Mail)
+	securityEvents := kafka.NewSecurityEventPublisher(&cfg.Kafka)
+	sessionPolicy := authDomainSvc.SessionPolicy{
+		MaxSessions: cfg.Session.MaxPerUser,
+		TTL:         time.Duration(cfg.JWT.RefreshTTL) * time.Second,
+	}
+	loginThrottle := authDomainSvc.LoginThrottlePolicy{
+		MaxAccountFailures: cfg.Login.MaxAccountFailures,
+		MaxIPFailures:      cfg.Login.MaxIPFailures,
+		FailureWindow:      time.Duration(cfg.Login.FailureWindow) * time.Second,
+		LockoutDuration:    time.Duration(cfg.Login.LockoutDuration) * time.Second,
+		BaseDelay:          time.Duration(cfg.Login.BaseDelay) * time.Millisecond,
+		MaxDelay:           time.Duration(cfg.Login.MaxDelay) * time.Millisecond,
+	}
 
 	// Initialize application services
 	authService := service.NewAuthService(
@@ -68,14 +109,26 @@ func main() {
 		bcryptVerifier,
 		jwtProvider,
 		jwtProvider,
+		jwtProvider,
 		refreshRepo,
+		sessionRepo,
+		sessionPolicy,
+		loginAttemptRepo,
+		loginThrottle,
 		idGenerator,
+		oneTimeTokenRepo,
+		mailer,
+		securityEvents,
+		cfg.Roles.BootstrapAdminEmails,
+		cfg.Mail.AppBaseURL,
 	)
 
 	// Initialize gRPC server
 	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
 			grpcHandler.UnaryServerInterceptor(),
+			grpcHandler.AuthorizationInterceptor(cfg.GRPC.IdentitySecret),
 		),
 	)
 	authHandler := grpcHandler.NewAuthHandler(authService)
@@ -100,12 +153,27 @@ func main() {
 		}
 	}()
 
+	// Start signing key rotation in goroutine
+	keysCtx, stopKeys := context.WithCancel(context.Background())
+	go keyring.Start(keysCtx)
+
+	// Start metrics server in goroutine
+	go func() {
+		log.Info("metrics server listening",
+			zap.String("port", cfg.Metrics.Port),
+		)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("metrics server error", zap.Error(err))
+		}
+	}()
+
 	// Wait for interrupt signal for graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Info("shutting down gracefully")
+	stopKeys()
 
 	// Create shutdown context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -127,5 +195,28 @@ func main() {
 		grpcServer.Stop() // Force stop
 	}
 
+	// Stop metrics server
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		log.Warn("failed to stop metrics server", zap.Error(err))
+	}
+
+	// Flush queued security events
+	if err := securityEvents.Close(); err != nil {
+		log.Warn("failed to flush security events", zap.Error(err))
+	}
+
+	// Flush pending spans
+	if err := shutdownTracing(ctx); err != nil {
+		log.Warn("failed to flush traces", zap.Error(err))
+	}
+
 	log.Info("auth service stopped")
 }
+
+// newMailer picks the mailer for the configured driver
+func newMailer(cfg config.MailConfig) port.Mailer {
+	if cfg.Driver == "file" {
+		return mail.NewFileMailer(cfg.From, cfg.FilePath)
+	}
+	return mail.NewLogMailer(cfg.From)
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/user"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+	"go.uber.org/zap"
+)
+
+// DisableUser blocks a user from logging in and ends their sessions on behalf of an admin.
+// Access tokens already issued are accepted by the gateway until they expire.
+func (s *AuthService) DisableUser(ctx context.Context, actorID kernel.UserID, userID kernel.UserID) error {
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.Disable(actorID); err != nil {
+		return err
+	}
+
+	if err := s.users.Update(ctx, u); err != nil {
+		return err
+	}
+
+	revoked, err := s.sessions.RevokeAll(ctx, u.ID())
+	if err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "user disabled",
+		zap.String("user_id", string(u.ID())),
+		zap.String("actor_id", string(actorID)),
+		zap.Int("revoked_sessions", revoked),
+	)
+
+	return nil
+}
+
+// EnableUser lets a disabled user log in again on behalf of an admin
+func (s *AuthService) EnableUser(ctx context.Context, actorID kernel.UserID, userID kernel.UserID) error {
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	u.Enable()
+
+	if err := s.users.Update(ctx, u); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "user enabled",
+		zap.String("user_id", string(u.ID())),
+		zap.String("actor_id", string(actorID)),
+	)
+
+	return nil
+}
+
+// RequestEmailVerification mails the user a new verification link, invalidating earlier ones
+func (s *AuthService) RequestEmailVerification(ctx context.Context, userID kernel.UserID) error {
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if u.EmailVerified() {
+		return user.ErrEmailVerified
+	}
+
+	return s.sendEmailVerification(ctx, u)
+}
+
+// VerifyEmail redeems a verification link
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	userID, err := s.oneTimeTokens.Consume(ctx, model.TokenPurposeVerifyEmail, token)
+	if err != nil {
+		return err
+	}
+
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.saveVerifiedEmail(ctx, u)
+}
+
+// saveVerifiedEmail records that the user proved they own their email, together with any
+// pending changes of u, and grants the roles that wait for a verified email
+func (s *AuthService) saveVerifiedEmail(ctx context.Context, u *user.User) error {
+	u.VerifyEmail(time.Now())
+
+	if err := s.users.Update(ctx, u); err != nil {
+		return err
+	}
+
+	return s.grantBootstrapAdmin(ctx, u)
+}
+
+// grantBootstrapAdmin grants the admin role to a bootstrap admin email once its owner has
+// proven they own the mailbox, so registering the address first is not enough
+func (s *AuthService) grantBootstrapAdmin(ctx context.Context, u *user.User) error {
+	if !slices.Contains(s.bootstrapAdminEmails, strings.ToLower(u.Email())) {
+		return nil
+	}
+
+	granted, err := u.GrantRole(kernel.RoleAdmin)
+	if err != nil || !granted {
+		return err
+	}
+
+	if err := s.users.AddRole(ctx, u.ID(), kernel.RoleAdmin, ""); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "bootstrap admin role granted",
+		zap.String("user_id", string(u.ID())),
+	)
+
+	return nil
+}
+
+// RequestPasswordReset mails a password reset link to the account's email. It succeeds for
+// unknown and disabled accounts too, so it can't be used to find out which emails have accounts.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	u, err := s.users.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			logger.InfoContext(ctx, "password reset requested for unknown email")
+			return nil
+		}
+		return err
+	}
+
+	if !u.IsActive() {
+		logger.InfoContext(ctx, "password reset requested for disabled user",
+			zap.String("user_id", string(u.ID())),
+		)
+		return nil
+	}
+
+	token, err := s.oneTimeTokens.Issue(ctx, model.TokenPurposeResetPassword, u.ID())
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, model.Email{
+		To:      u.Email(),
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Someone asked to reset the password of your account. To choose a new password, open:\n\n%s\n\nThe link expires in %s. If you didn't ask for it, ignore this email.",
+			s.link("/reset-password", token),
+			formatTTL(model.TokenPurposeResetPassword.TTL()),
+		),
+	})
+}
+
+// ResetPassword redeems a password reset link and sets the new password. Every session of the
+// user ends, so whoever knew the old password is logged out.
+func (s *AuthService) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	userID, err := s.oneTimeTokens.Consume(ctx, model.TokenPurposeResetPassword, token)
+	if err != nil {
+		return err
+	}
+
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !u.IsActive() {
+		return user.ErrUserDisabled
+	}
+
+	hash, err := s.verifier.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	u.SetPasswordHash(hash)
+
+	// Redeeming the link proves the user owns the email as well
+	if err := s.saveVerifiedEmail(ctx, u); err != nil {
+		return err
+	}
+
+	if _, err := s.sessions.RevokeAll(ctx, u.ID()); err != nil {
+		return err
+	}
+
+	if err := s.unlockAccount(ctx, u, "password_reset"); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "password reset",
+		zap.String("user_id", string(u.ID())),
+	)
+
+	return nil
+}
+
+func (s *AuthService) sendEmailVerification(ctx context.Context, u *user.User) error {
+	token, err := s.oneTimeTokens.Issue(ctx, model.TokenPurposeVerifyEmail, u.ID())
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, model.Email{
+		To:      u.Email(),
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"To verify the email of your account, open:\n\n%s\n\nThe link expires in %s.",
+			s.link("/verify-email", token),
+			formatTTL(model.TokenPurposeVerifyEmail.TTL()),
+		),
+	})
+}
+
+// link builds a frontend URL carrying a one-time token
+func (s *AuthService) link(path string, token string) string {
+	return s.appBaseURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// formatTTL renders a link lifetime for an email, such as "24 hours" or "30 minutes"
+func formatTTL(ttl time.Duration) string {
+	if ttl%time.Hour == 0 {
+		return fmt.Sprintf("%d hours", int(ttl.Hours()))
+	}
+	return fmt.Sprintf("%d minutes", int(ttl.Minutes()))
+}
@@ -3,13 +3,18 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
 	repository "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/repositroy"
+	authSvc "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/port"
 	domainSvc "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/user"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+	"go.uber.org/zap"
 )
 
 type AuthService struct {
@@ -17,22 +22,43 @@ type AuthService struct {
 	verifier      domainSvc.PasswordVerifier
 	tokenIssuer   port.TokenIssuer
 	tokenVerifier port.TokenVerifier
+	keySet        port.KeySetPublisher
 	refreshRepo   repository.RefreshTokenRepository
+	sessions      repository.SessionRepository
+	sessionPolicy authSvc.SessionPolicy
+	loginAttempts repository.LoginAttemptRepository
+	loginThrottle authSvc.LoginThrottlePolicy
 	idGenerator   kernel.IDGenerator
+	oneTimeTokens repository.OneTimeTokenRepository
+	mailer        port.Mailer
+	events        port.SecurityEventPublisher
+
+	bootstrapAdminEmails []string
+	appBaseURL           string
 }
 
-func NewAuthService(users user.UserRepository, verifier domainSvc.PasswordVerifier, tokenIssuer port.TokenIssuer, tokenVerifier port.TokenVerifier, refreshRepo repository.RefreshTokenRepository, idGenerator kernel.IDGenerator) *AuthService {
+func NewAuthService(users user.UserRepository, verifier domainSvc.PasswordVerifier, tokenIssuer port.TokenIssuer, tokenVerifier port.TokenVerifier, keySet port.KeySetPublisher, refreshRepo repository.RefreshTokenRepository, sessions repository.SessionRepository, sessionPolicy authSvc.SessionPolicy, loginAttempts repository.LoginAttemptRepository, loginThrottle authSvc.LoginThrottlePolicy, idGenerator kernel.IDGenerator, oneTimeTokens repository.OneTimeTokenRepository, mailer port.Mailer, events port.SecurityEventPublisher, bootstrapAdminEmails []string, appBaseURL string) *AuthService {
 	return &AuthService{
-		users:         users,
-		tokenIssuer:   tokenIssuer,
-		tokenVerifier: tokenVerifier,
-		refreshRepo:   refreshRepo,
-		verifier:      verifier,
-		idGenerator:   idGenerator,
+		users:                users,
+		tokenIssuer:          tokenIssuer,
+		tokenVerifier:        tokenVerifier,
+		keySet:               keySet,
+		refreshRepo:          refreshRepo,
+		sessions:             sessions,
+		sessionPolicy:        sessionPolicy,
+		loginAttempts:        loginAttempts,
+		loginThrottle:        loginThrottle,
+		verifier:             verifier,
+		idGenerator:          idGenerator,
+		oneTimeTokens:        oneTimeTokens,
+		mailer:               mailer,
+		events:               events,
+		bootstrapAdminEmails: bootstrapAdminEmails,
+		appBaseURL:           appBaseURL,
 	}
 }
 
-func (s *AuthService) Register(ctx context.Context, email string, password string) (kernel.UserID, string, string, error) {
+func (s *AuthService) Register(ctx context.Context, email string, password string, client model.ClientInfo) (kernel.UserID, string, string, error) {
 	existing, _ := s.users.FindByEmail(ctx, email)
 	if existing != nil {
 		return "", "", "", errors.New("email already in use")
@@ -50,81 +76,314 @@ func (s *AuthService) Register(ctx context.Context, email string, password strin
 		return "", "", "", err
 	}
 
-	access, err := s.tokenIssuer.IssueAccess(user.ID(), kernel.RoleUser)
+	// The account is usable before the email is verified, so a mail outage doesn't block sign ups
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		logger.WarnContext(ctx, "failed to send verification email",
+			zap.String("user_id", string(user.ID())),
+			zap.Error(err),
+		)
+	}
+
+	access, refresh, err := s.startSession(ctx, user, client)
 	if err != nil {
 		return "", "", "", err
 	}
 
-	tokenID := s.idGenerator.NewTokenID()
-	refresh, expiredAt, err := s.tokenIssuer.IssueRefresh(tokenID, user.ID())
+	return user.ID(), access, refresh, nil
+}
+
+// Login checks the failed login throttle of the account and the client IP before the password,
+// so throttled attempts cost no password hashing
+func (s *AuthService) Login(ctx context.Context, email string, password string, client model.ClientInfo) (kernel.UserID, string, string, error) {
+	subjects := loginSubjects(email, client)
+	if err := s.checkLoginAllowed(ctx, subjects, client); err != nil {
+		return "", "", "", err
+	}
+
+	u, err := s.users.FindByEmail(ctx, email)
 	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			// Unknown emails fail like wrong passwords, so they can't be told apart
+			return "", "", "", s.loginFailed(ctx, subjects, "", client, "unknown_email")
+		}
 		return "", "", "", err
 	}
 
-	rt := model.RefreshToken{
-		ID:       tokenID,
-		UserID:   userID,
-		ExpireAt: expiredAt,
-		Revoked:  false,
+	if err := u.Login(password, s.verifier); err != nil {
+		if errors.Is(err, user.ErrInvalidCredentials) {
+			return "", "", "", s.loginFailed(ctx, subjects, u.ID(), client, "invalid_password")
+		}
+		return "", "", "", err
 	}
 
-	if err := s.refreshRepo.Save(ctx, rt); err != nil {
+	if err := s.resetLoginAttempts(ctx, subjects); err != nil {
 		return "", "", "", err
 	}
 
-	return user.ID(), access, refresh, nil
+	access, refresh, err := s.startSession(ctx, u, client)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return u.ID(), access, refresh, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, email string, password string) (kernel.UserID, string, string, error) {
-	user, err := s.users.FindByEmail(ctx, email)
+// startSession opens a session for the user, ending their least recently used sessions when
+// they are at the session limit. Returns the access and refresh tokens.
+func (s *AuthService) startSession(ctx context.Context, u *user.User, client model.ClientInfo) (string, string, error) {
+	sessions, err := s.sessions.ListByUser(ctx, u.ID())
 	if err != nil {
-		return "", "", "", err
+		return "", "", err
 	}
 
-	if err := user.Login(password, s.verifier); err != nil {
-		return "", "", "", err
+	slices.SortFunc(sessions, func(a, b model.Session) int {
+		return a.LastUsedAt.Compare(b.LastUsedAt)
+	})
+	for len(sessions) > 0 && !s.sessionPolicy.CanCreate(len(sessions)) {
+		if err := s.sessions.Revoke(ctx, u.ID(), sessions[0].ID); err != nil {
+			return "", "", err
+		}
+		sessions = sessions[1:]
 	}
 
-	access, err := s.tokenIssuer.IssueAccess(user.ID(), "user")
+	access, err := s.tokenIssuer.IssueAccess(u.ID(), u.Roles())
 	if err != nil {
-		return "", "", "", err
+		return "", "", err
 	}
 
+	sessionID := s.idGenerator.NewSessionID()
 	tokenID := s.idGenerator.NewTokenID()
-	refresh, expiredAt, err := s.tokenIssuer.IssueRefresh(tokenID, user.ID())
+	refresh, expiredAt, err := s.tokenIssuer.IssueRefresh(tokenID, u.ID())
 	if err != nil {
-		return "", "", "", err
+		return "", "", err
 	}
 
 	rt := model.RefreshToken{
-		ID:       tokenID,
-		UserID:   user.ID(),
-		ExpireAt: expiredAt,
-		Revoked:  false,
+		ID:        tokenID,
+		UserID:    u.ID(),
+		SessionID: sessionID,
+		ExpireAt:  expiredAt,
+		Revoked:   false,
 	}
 
 	if err := s.refreshRepo.Save(ctx, rt); err != nil {
-		return "", "", "", err
+		return "", "", err
 	}
 
-	return user.ID(), access, refresh, nil
+	now := time.Now()
+	session := model.Session{
+		ID:             sessionID,
+		UserID:         u.ID(),
+		CurrentTokenID: tokenID,
+		Client:         client,
+		CreatedAt:      now,
+		LastUsedAt:     now,
+		ExpireAt:       expiredAt,
+	}
+
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return "", "", err
+	}
+
+	return access, refresh, nil
 }
 
-func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (string, error) {
+// Refresh rotates the refresh token: it returns a new access token and a new refresh token,
+// and the presented refresh token can't be used again. Presenting an already rotated token
+// revokes its session, since either the client or an attacker holds a stolen copy.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (string, string, error) {
 	tokenID, err := s.tokenVerifier.VerifyRefresh(refreshToken)
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+
+	rt, err := s.refreshRepo.Find(ctx, tokenID)
+	if err != nil {
+		return "", "", err
+	}
+	if rt == nil {
+		return "", "", model.ErrRefreshTokenRevoked
+	}
+
+	session, err := s.sessions.Find(ctx, rt.SessionID)
+	if err != nil {
+		return "", "", err
+	}
+	if session == nil {
+		return "", "", model.ErrRefreshTokenRevoked
+	}
+
+	if session.CurrentTokenID != rt.ID {
+		if err := s.sessions.Revoke(ctx, rt.UserID, rt.SessionID); err != nil {
+			return "", "", err
+		}
+		return "", "", model.ErrRefreshTokenReused
+	}
+
+	// Roles are read again so granted and revoked roles apply from the next refresh
+	u, err := s.users.FindByID(ctx, rt.UserID)
+	if err != nil {
+		return "", "", err
+	}
+	if !u.IsActive() {
+		// Disabling revokes the user's sessions; this catches one that raced with it
+		if err := s.sessions.Revoke(ctx, rt.UserID, rt.SessionID); err != nil {
+			return "", "", err
+		}
+		return "", "", user.ErrUserDisabled
+	}
+
+	nextID := s.idGenerator.NewTokenID()
+	refresh, expiredAt, err := s.tokenIssuer.IssueRefresh(nextID, u.ID())
+	if err != nil {
+		return "", "", err
+	}
+
+	next := model.RefreshToken{
+		ID:        nextID,
+		UserID:    u.ID(),
+		SessionID: session.ID,
+		ExpireAt:  expiredAt,
+		Revoked:   false,
+	}
+
+	if err := s.refreshRepo.Save(ctx, next); err != nil {
+		return "", "", err
+	}
+
+	rotated, err := s.sessions.Rotate(ctx, session.ID, rt.ID, nextID, expiredAt)
+	if err != nil {
+		return "", "", err
+	}
+	if !rotated {
+		// Another refresh with the same token won the race: the token was used twice
+		if err := s.sessions.Revoke(ctx, rt.UserID, rt.SessionID); err != nil {
+			return "", "", err
+		}
+		return "", "", model.ErrRefreshTokenReused
 	}
 
-	var rt *model.RefreshToken
-	rt, err = s.refreshRepo.Find(ctx, tokenID)
+	access, err := s.tokenIssuer.IssueAccess(u.ID(), u.Roles())
 	if err != nil {
-		return "", errors.New("refresh token revoked")
+		return "", "", err
 	}
 
-	return s.tokenIssuer.IssueAccess(rt.UserID, kernel.RoleUser)
+	return access, refresh, nil
 }
 
-func (s *AuthService) VerifyAccessToken(accessToken string) (*model.Access, error) {
-	return s.tokenVerifier.VerifyAccess(accessToken)
+// Logout ends the session of the refresh token. Access tokens already issued stay valid until
+// they expire.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	tokenID, err := s.tokenVerifier.VerifyRefresh(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	rt, err := s.refreshRepo.Find(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if rt == nil {
+		// Already expired or logged out
+		return nil
+	}
+
+	return s.sessions.Revoke(ctx, rt.UserID, rt.SessionID)
+}
+
+// LogoutAll ends every session of the user and returns how many were ended
+func (s *AuthService) LogoutAll(ctx context.Context, userID kernel.UserID) (int, error) {
+	return s.sessions.RevokeAll(ctx, userID)
+}
+
+// ListSessions returns the user's active sessions, most recently used first
+func (s *AuthService) ListSessions(ctx context.Context, userID kernel.UserID) ([]model.Session, error) {
+	sessions, err := s.sessions.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(sessions, func(a, b model.Session) int {
+		return b.LastUsedAt.Compare(a.LastUsedAt)
+	})
+	return sessions, nil
+}
+
+// VerifyAccessToken verifies the token and that its user is still active. The gateway verifies
+// tokens locally against PublicKeys instead, so there a disabled user's access token is accepted
+// until it expires.
+func (s *AuthService) VerifyAccessToken(ctx context.Context, accessToken string) (*model.Access, error) {
+	access, err := s.tokenVerifier.VerifyAccess(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.users.FindByID(ctx, access.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsActive() {
+		return nil, user.ErrUserDisabled
+	}
+
+	return access, nil
+}
+
+// PublicKeys returns the JWKS that access tokens can be verified with, without calling this service
+func (s *AuthService) PublicKeys() []model.JWK {
+	return s.keySet.JWKS()
+}
+
+// GrantRole grants a role to a user on behalf of an admin and returns the user's roles.
+// The new role is in the user's access tokens from their next login or refresh.
+func (s *AuthService) GrantRole(ctx context.Context, actorID kernel.UserID, userID kernel.UserID, rawRole string) ([]kernel.Role, error) {
+	role, err := kernel.NewRole(rawRole)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := u.GrantRole(role)
+	if err != nil {
+		return nil, err
+	}
+
+	if granted {
+		if err := s.users.AddRole(ctx, u.ID(), role, actorID); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.Roles(), nil
+}
+
+// RevokeRole revokes a role from a user on behalf of an admin and returns the user's roles.
+// Access tokens already issued keep the role until they expire.
+func (s *AuthService) RevokeRole(ctx context.Context, actorID kernel.UserID, userID kernel.UserID, rawRole string) ([]kernel.Role, error) {
+	role, err := kernel.NewRole(rawRole)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := u.RevokeRole(role, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked {
+		if err := s.users.RemoveRole(ctx, u.ID(), role); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.Roles(), nil
 }
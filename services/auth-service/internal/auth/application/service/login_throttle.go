@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/user"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+	"go.uber.org/zap"
+)
+
+// loginSubjects are the account and, for calls from the gateway, the client IP of a login
+func loginSubjects(email string, client model.ClientInfo) []model.LoginSubject {
+	subjects := []model.LoginSubject{
+		{Kind: model.LoginSubjectAccount, Value: strings.ToLower(strings.TrimSpace(email))},
+	}
+	if client.IPAddress != "" {
+		subjects = append(subjects, model.LoginSubject{Kind: model.LoginSubjectIP, Value: client.IPAddress})
+	}
+	return subjects
+}
+
+// checkLoginAllowed rejects the attempt while any subject waits out its delay or lockout.
+// A lockout that is over is cleared along with the failures that caused it.
+func (s *AuthService) checkLoginAllowed(ctx context.Context, subjects []model.LoginSubject, client model.ClientInfo) error {
+	now := time.Now()
+
+	for _, subject := range subjects {
+		attempts, err := s.loginAttempts.Find(ctx, subject)
+		if err != nil {
+			return err
+		}
+
+		if attempts.LockExpired(now) {
+			if err := s.loginAttempts.Reset(ctx, subject); err != nil {
+				return err
+			}
+			s.publishSecurityEvent(ctx, model.SecurityEvent{
+				Type:       model.SecurityEventLoginUnlocked,
+				Subject:    subject,
+				Email:      subjects[0].Value,
+				IPAddress:  client.IPAddress,
+				Reason:     "lockout_expired",
+				OccurredAt: now,
+			})
+			continue
+		}
+
+		if blocked, until := attempts.Blocked(now); blocked {
+			return &model.LoginBlockedError{
+				Subject:    subject.Kind,
+				Locked:     now.Before(attempts.LockedUntil),
+				RetryAfter: until.Sub(now),
+			}
+		}
+	}
+
+	return nil
+}
+
+// loginFailed counts a failed login against every subject, delaying or locking out their
+// next attempts, and returns the error for the caller
+func (s *AuthService) loginFailed(ctx context.Context, subjects []model.LoginSubject, userID kernel.UserID, client model.ClientInfo, reason string) error {
+	now := time.Now()
+	accountFailures := 0
+
+	for _, subject := range subjects {
+		failures, err := s.loginAttempts.RecordFailure(ctx, subject, s.loginThrottle.FailureWindow)
+		if err != nil {
+			return err
+		}
+		if subject.Kind == model.LoginSubjectAccount {
+			accountFailures = failures
+		}
+
+		var lockedUntil time.Time
+		if s.loginThrottle.Locks(subject.Kind, failures) {
+			lockedUntil = now.Add(s.loginThrottle.LockoutDuration)
+		}
+
+		if err := s.loginAttempts.Block(ctx, subject, now.Add(s.loginThrottle.Delay(failures)), lockedUntil); err != nil {
+			return err
+		}
+
+		if !lockedUntil.IsZero() {
+			logger.WarnContext(ctx, "login locked out",
+				zap.String("subject", string(subject.Kind)),
+				zap.String("user_id", string(userID)),
+				zap.Int("failures", failures),
+				zap.Time("locked_until", lockedUntil),
+			)
+			s.publishSecurityEvent(ctx, model.SecurityEvent{
+				Type:        model.SecurityEventLoginLocked,
+				Subject:     subject,
+				UserID:      userID,
+				Email:       subjects[0].Value,
+				IPAddress:   client.IPAddress,
+				Failures:    failures,
+				LockedUntil: lockedUntil,
+				OccurredAt:  now,
+			})
+		}
+	}
+
+	s.publishSecurityEvent(ctx, model.SecurityEvent{
+		Type:       model.SecurityEventLoginFailed,
+		Subject:    subjects[0],
+		UserID:     userID,
+		Email:      subjects[0].Value,
+		IPAddress:  client.IPAddress,
+		Reason:     reason,
+		Failures:   accountFailures,
+		OccurredAt: now,
+	})
+
+	return user.ErrInvalidCredentials
+}
+
+// resetLoginAttempts clears the account's failures after a successful login. The IP keeps
+// its failures, or an attacker could log in to an account of their own between guesses to
+// wipe the IP's count while spraying passwords at other accounts.
+func (s *AuthService) resetLoginAttempts(ctx context.Context, subjects []model.LoginSubject) error {
+	for _, subject := range subjects {
+		if subject.Kind != model.LoginSubjectAccount {
+			continue
+		}
+		if err := s.loginAttempts.Reset(ctx, subject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unlockAccount clears the failures of an account whose owner proved they own its email,
+// lifting a lockout early
+func (s *AuthService) unlockAccount(ctx context.Context, u *user.User, reason string) error {
+	subject := loginSubjects(u.Email(), model.ClientInfo{})[0]
+	now := time.Now()
+
+	attempts, err := s.loginAttempts.Find(ctx, subject)
+	if err != nil {
+		return err
+	}
+
+	if err := s.loginAttempts.Reset(ctx, subject); err != nil {
+		return err
+	}
+
+	if now.Before(attempts.LockedUntil) {
+		s.publishSecurityEvent(ctx, model.SecurityEvent{
+			Type:       model.SecurityEventLoginUnlocked,
+			Subject:    subject,
+			UserID:     u.ID(),
+			Email:      subject.Value,
+			Reason:     reason,
+			OccurredAt: now,
+		})
+	}
+
+	return nil
+}
+
+// publishSecurityEvent publishes to the audit log; a failure is logged and doesn't fail the
+// request
+func (s *AuthService) publishSecurityEvent(ctx context.Context, event model.SecurityEvent) {
+	if err := s.events.Publish(ctx, event); err != nil {
+		logger.WarnContext(ctx, "failed to publish security event",
+			zap.String("event_type", string(event.Type)),
+			zap.Error(err),
+		)
+	}
+}
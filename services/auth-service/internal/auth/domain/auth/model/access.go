@@ -8,7 +8,7 @@ import (
 
 type Access struct {
 	UserID   kernel.UserID
-	Role     kernel.Role
+	Roles    []kernel.Role
 	IssuedAt time.Time
 	ExpireAt time.Time
 }
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// LoginSubjectKind is what failed logins are counted against
+type LoginSubjectKind string
+
+const (
+	LoginSubjectAccount LoginSubjectKind = "account"
+	LoginSubjectIP      LoginSubjectKind = "ip"
+)
+
+// LoginSubject is an account, identified by its email so unknown emails are throttled
+// the same way, or a source IP
+type LoginSubject struct {
+	Kind  LoginSubjectKind
+	Value string
+}
+
+// LoginAttempts is the failed login record of a subject
+type LoginAttempts struct {
+	Failures    int
+	RetryAt     time.Time // no attempt before this, set after every failure
+	LockedUntil time.Time // set once the failures reach the lockout threshold
+}
+
+// Blocked reports whether an attempt at now must be rejected, and until when
+func (a LoginAttempts) Blocked(now time.Time) (bool, time.Time) {
+	until := a.RetryAt
+	if a.LockedUntil.After(until) {
+		until = a.LockedUntil
+	}
+	return now.Before(until), until
+}
+
+// LockExpired reports whether the subject was locked out and the lockout is over
+func (a LoginAttempts) LockExpired(now time.Time) bool {
+	return !a.LockedUntil.IsZero() && !now.Before(a.LockedUntil)
+}
+
+// LoginBlockedError rejects a login attempt without checking the password
+type LoginBlockedError struct {
+	Subject    LoginSubjectKind
+	Locked     bool
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	seconds := int(math.Ceil(e.RetryAfter.Seconds()))
+	if e.Locked {
+		return fmt.Sprintf("too many failed logins, %s locked for %d seconds", e.Subject, seconds)
+	}
+	return fmt.Sprintf("too many failed logins, retry in %d seconds", seconds)
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidOneTimeToken = errors.New("invalid or expired link")
+
+// TokenPurpose scopes a one-time token to the flow it was issued for, so a token mailed for
+// one flow can't be redeemed in another
+type TokenPurpose string
+
+const (
+	TokenPurposeVerifyEmail   TokenPurpose = "verify_email"
+	TokenPurposeResetPassword TokenPurpose = "reset_password"
+)
+
+// TTL is how long a token of the purpose can be redeemed
+func (p TokenPurpose) TTL() time.Duration {
+	switch p {
+	case TokenPurposeResetPassword:
+		return 30 * time.Minute
+	default:
+		return 24 * time.Hour
+	}
+}
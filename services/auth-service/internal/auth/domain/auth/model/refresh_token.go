@@ -1,14 +1,23 @@
 package model
 
 import (
+	"errors"
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
 )
 
+var (
+	ErrRefreshTokenRevoked = errors.New("refresh token revoked")
+	ErrRefreshTokenReused  = errors.New("refresh token reused, session revoked")
+)
+
+// RefreshToken is one token of a session's rotation chain. Rotated tokens are kept until they
+// expire so that a reused one can be traced back to its session.
 type RefreshToken struct {
-	ID       kernel.TokenID
-	UserID   kernel.UserID
-	ExpireAt time.Time
-	Revoked  bool
+	ID        kernel.TokenID
+	UserID    kernel.UserID
+	SessionID kernel.SessionID
+	ExpireAt  time.Time
+	Revoked   bool
 }
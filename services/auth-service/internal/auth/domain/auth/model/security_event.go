@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+)
+
+type SecurityEventType string
+
+const (
+	SecurityEventLoginFailed   SecurityEventType = "auth.login_failed"
+	SecurityEventLoginLocked   SecurityEventType = "auth.login_locked"
+	SecurityEventLoginUnlocked SecurityEventType = "auth.login_unlocked"
+)
+
+// SecurityEvent is published to the audit log. Subject is the account or IP the event is
+// about; UserID is empty when the email has no account.
+type SecurityEvent struct {
+	Type        SecurityEventType
+	Subject     LoginSubject
+	UserID      kernel.UserID
+	Email       string
+	IPAddress   string
+	Reason      string
+	Failures    int
+	LockedUntil time.Time
+	OccurredAt  time.Time
+}
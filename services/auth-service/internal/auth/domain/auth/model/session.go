@@ -1,6 +1,26 @@
 package model
 
+import (
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+)
+
+// Session is a login on one device: the family of refresh tokens rotated from the token
+// issued at login. Only CurrentTokenID can be refreshed; presenting an older token of the
+// family means it leaked, and the whole session is revoked.
 type Session struct {
-	UserID string
-	Token  RefreshToken
+	ID             kernel.SessionID
+	UserID         kernel.UserID
+	CurrentTokenID kernel.TokenID
+	Client         ClientInfo
+	CreatedAt      time.Time
+	LastUsedAt     time.Time
+	ExpireAt       time.Time
+}
+
+// ClientInfo describes the client a session was created from
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
 }
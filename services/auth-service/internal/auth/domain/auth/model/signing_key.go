@@ -0,0 +1,43 @@
+package model
+
+import (
+	"crypto"
+	"time"
+)
+
+// Access token signing algorithms
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmRS256 = "RS256"
+)
+
+// SigningKey is a key pair signing access tokens, identified in token headers and the JWKS by
+// its key ID (kid). The newest key signs; a key replaced by a newer one is retired but stays
+// published until the tokens it signed have expired.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	CreatedAt  time.Time
+	ExpireAt   time.Time // zero until the key is retired
+}
+
+func (k SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+func (k SigningKey) Retired() bool {
+	return !k.ExpireAt.IsZero()
+}
+
+// JWK is the public half of a signing key as a JSON Web Key (RFC 7517)
+type JWK struct {
+	KeyID     string
+	KeyType   string // OKP or RSA
+	Algorithm string
+	Use       string
+	Curve     string // OKP
+	X         string // OKP public key
+	N         string // RSA modulus
+	E         string // RSA exponent
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+)
+
+type LoginAttemptRepository interface {
+	Find(ctx context.Context, subject model.LoginSubject) (model.LoginAttempts, error)
+	// RecordFailure counts a failed login and returns the failures in the current window.
+	// The window starts at the first failure.
+	RecordFailure(ctx context.Context, subject model.LoginSubject, window time.Duration) (int, error)
+	// Block rejects the subject's attempts until retryAt, and locks it until lockedUntil
+	// when that is not zero
+	Block(ctx context.Context, subject model.LoginSubject, retryAt time.Time, lockedUntil time.Time) error
+	Reset(ctx context.Context, subject model.LoginSubject) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+)
+
+// OneTimeTokenRepository issues the secrets mailed to users to prove they own their email.
+// A user has at most one live token per purpose: issuing another invalidates the previous one.
+type OneTimeTokenRepository interface {
+	// Issue returns a new token for the user, valid for the purpose's TTL
+	Issue(ctx context.Context, purpose model.TokenPurpose, userID kernel.UserID) (string, error)
+	// Consume redeems a token and returns its user. It returns model.ErrInvalidOneTimeToken
+	// for an unknown, expired or already used token.
+	Consume(ctx context.Context, purpose model.TokenPurpose, token string) (kernel.UserID, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session model.Session) error
+	// Find returns nil when the session doesn't exist, expired or was revoked
+	Find(ctx context.Context, id kernel.SessionID) (*model.Session, error)
+	ListByUser(ctx context.Context, userID kernel.UserID) ([]model.Session, error)
+	// Rotate replaces the session's current refresh token with next, extending the session to
+	// expireAt. It reports false, changing nothing, when current is no longer the session's token.
+	Rotate(ctx context.Context, id kernel.SessionID, current, next kernel.TokenID, expireAt time.Time) (bool, error)
+	Revoke(ctx context.Context, userID kernel.UserID, id kernel.SessionID) error
+	// RevokeAll revokes every session of the user and returns how many were revoked
+	RevokeAll(ctx context.Context, userID kernel.UserID) (int, error)
+}
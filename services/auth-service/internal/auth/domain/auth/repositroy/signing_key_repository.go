@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+)
+
+type SigningKeyRepository interface {
+	List(ctx context.Context) ([]model.SigningKey, error)
+	Save(ctx context.Context, key model.SigningKey) error
+	Delete(ctx context.Context, id string) error
+	// TryLockRotation takes the rotation lock for ttl, so one replica rotates keys at a time
+	TryLockRotation(ctx context.Context, ttl time.Duration) (bool, error)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+)
+
+// LoginThrottlePolicy slows down and then locks out repeated failed logins. Each failure
+// doubles the wait before the next attempt, from BaseDelay up to MaxDelay.
+type LoginThrottlePolicy struct {
+	MaxAccountFailures int // failures of one account that lock it; zero or less never locks
+	MaxIPFailures      int // failures from one IP that lock it; zero or less never locks
+	FailureWindow      time.Duration
+	LockoutDuration    time.Duration
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+}
+
+// Delay is the wait after the given number of consecutive failures
+func (p LoginThrottlePolicy) Delay(failures int) time.Duration {
+	if failures <= 0 || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// Locks reports whether the subject is locked out after the given number of failures
+func (p LoginThrottlePolicy) Locks(kind model.LoginSubjectKind, failures int) bool {
+	limit := p.MaxAccountFailures
+	if kind == model.LoginSubjectIP {
+		limit = p.MaxIPFailures
+	}
+	return limit > 0 && failures >= limit
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+)
+
+func testThrottlePolicy() LoginThrottlePolicy {
+	return LoginThrottlePolicy{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      20,
+		FailureWindow:      15 * time.Minute,
+		LockoutDuration:    15 * time.Minute,
+		BaseDelay:          time.Second,
+		MaxDelay:           30 * time.Second,
+	}
+}
+
+func TestLoginThrottlePolicyDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   func(p *LoginThrottlePolicy)
+		failures int
+		want     time.Duration
+	}{
+		{name: "no failures", failures: 0, want: 0},
+		{name: "negative failures", failures: -1, want: 0},
+		{name: "first failure", failures: 1, want: time.Second},
+		{name: "second failure doubles", failures: 2, want: 2 * time.Second},
+		{name: "fifth failure", failures: 5, want: 16 * time.Second},
+		{name: "capped at max delay", failures: 6, want: 30 * time.Second},
+		{name: "many failures stay capped", failures: 1000, want: 30 * time.Second},
+		{
+			name:     "delays disabled",
+			policy:   func(p *LoginThrottlePolicy) { p.BaseDelay = 0 },
+			failures: 3,
+			want:     0,
+		},
+		{
+			name:     "base delay above max delay",
+			policy:   func(p *LoginThrottlePolicy) { p.BaseDelay = time.Minute },
+			failures: 1,
+			want:     30 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testThrottlePolicy()
+			if tt.policy != nil {
+				tt.policy(&p)
+			}
+			if got := p.Delay(tt.failures); got != tt.want {
+				t.Fatalf("Delay(%d) = %s, want %s", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginThrottlePolicyLocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   func(p *LoginThrottlePolicy)
+		kind     model.LoginSubjectKind
+		failures int
+		want     bool
+	}{
+		{name: "account below limit", kind: model.LoginSubjectAccount, failures: 4, want: false},
+		{name: "account at limit", kind: model.LoginSubjectAccount, failures: 5, want: true},
+		{name: "account above limit", kind: model.LoginSubjectAccount, failures: 6, want: true},
+		{name: "ip below its own limit", kind: model.LoginSubjectIP, failures: 5, want: false},
+		{name: "ip at limit", kind: model.LoginSubjectIP, failures: 20, want: true},
+		{
+			name:     "account lockout disabled",
+			policy:   func(p *LoginThrottlePolicy) { p.MaxAccountFailures = 0 },
+			kind:     model.LoginSubjectAccount,
+			failures: 100,
+			want:     false,
+		},
+		{
+			name:     "ip lockout disabled",
+			policy:   func(p *LoginThrottlePolicy) { p.MaxIPFailures = 0 },
+			kind:     model.LoginSubjectIP,
+			failures: 100,
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testThrottlePolicy()
+			if tt.policy != nil {
+				tt.policy(&p)
+			}
+			if got := p.Locks(tt.kind, tt.failures); got != tt.want {
+				t.Fatalf("Locks(%s, %d) = %v, want %v", tt.kind, tt.failures, got, tt.want)
+			}
+		})
+	}
+}
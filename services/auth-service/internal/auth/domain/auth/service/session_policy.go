@@ -3,10 +3,10 @@ package service
 import "time"
 
 type SessionPolicy struct {
-	MaxSessions int
+	MaxSessions int // zero or less allows any number of sessions
 	TTL         time.Duration
 }
 
 func (p SessionPolicy) CanCreate(current int) bool {
-	return current < p.MaxSessions
+	return p.MaxSessions <= 0 || current < p.MaxSessions
 }
@@ -0,0 +1,8 @@
+package port
+
+import "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+
+type KeySetPublisher interface {
+	// Return the public keys verifying access tokens
+	JWKS() []model.JWK
+}
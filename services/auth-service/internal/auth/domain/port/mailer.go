@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+)
+
+type Mailer interface {
+	Send(ctx context.Context, email model.Email) error
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+)
+
+type SecurityEventPublisher interface {
+	Publish(ctx context.Context, event model.SecurityEvent) error
+}
@@ -8,7 +8,7 @@ import (
 
 type TokenIssuer interface {
 	// Return AccessToken, Error
-	IssueAccess(userID kernel.UserID, role kernel.Role) (string, error)
+	IssueAccess(userID kernel.UserID, roles []kernel.Role) (string, error)
 	// Return RefreshToken, Error
 	IssueRefresh(tokenID kernel.TokenID, userID kernel.UserID) (string, time.Time, error)
 }
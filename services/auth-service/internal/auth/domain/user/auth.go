@@ -2,13 +2,14 @@ package user
 
 import "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/service"
 
+// Login checks the password, then the status, so only the account owner learns that it is disabled
 func (u *User) Login(password string, verifier service.PasswordVerifier) error {
-	if u.status != UserStatusActive {
+	if !verifier.Verify(u.passwordHash, password) {
 		return ErrInvalidCredentials
 	}
 
-	if !verifier.Verify(u.passwordHash, password) {
-		return ErrInvalidCredentials
+	if !u.IsActive() {
+		return ErrUserDisabled
 	}
 
 	return nil
@@ -1,8 +1,18 @@
 package user
 
-import "context"
+import (
+	"context"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+)
 
 type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*User, error)
+	FindByID(ctx context.Context, id kernel.UserID) (*User, error)
 	Save(ctx context.Context, user *User) error
+	// Update persists the password hash, status and email verification of an existing user
+	Update(ctx context.Context, user *User) error
+	// AddRole records a granted role; an empty grantedBy records a grant made by the system
+	AddRole(ctx context.Context, userID kernel.UserID, role kernel.Role, grantedBy kernel.UserID) error
+	RemoveRole(ctx context.Context, userID kernel.UserID, role kernel.Role) error
 }
@@ -0,0 +1,59 @@
+package user
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+)
+
+var (
+	ErrRoleNotGrantable     = errors.New("role cannot be granted or revoked")
+	ErrCannotRevokeOwnAdmin = errors.New("admins cannot revoke their own admin role")
+)
+
+// Roles returns every role of the user, starting with the buyer role all users have
+func (u *User) Roles() []kernel.Role {
+	return append([]kernel.Role{kernel.RoleBuyer}, u.roles...)
+}
+
+// GrantedRoles returns the roles granted to the user on top of the buyer role
+func (u *User) GrantedRoles() []kernel.Role {
+	return slices.Clone(u.roles)
+}
+
+func (u *User) HasRole(role kernel.Role) bool {
+	return role == kernel.RoleBuyer || slices.Contains(u.roles, role)
+}
+
+// GrantRole grants a seller or admin role. Granting a role the user already has is a no-op
+// and reports false.
+func (u *User) GrantRole(role kernel.Role) (bool, error) {
+	if role == kernel.RoleBuyer {
+		return false, ErrRoleNotGrantable
+	}
+	if u.HasRole(role) {
+		return false, nil
+	}
+
+	u.roles = append(u.roles, role)
+	return true, nil
+}
+
+// RevokeRole revokes a seller or admin role on behalf of actorID. Revoking a role the user
+// doesn't have is a no-op and reports false.
+func (u *User) RevokeRole(role kernel.Role, actorID kernel.UserID) (bool, error) {
+	if role == kernel.RoleBuyer {
+		return false, ErrRoleNotGrantable
+	}
+	// An admin dropping their own role could leave no admin to undo it
+	if role == kernel.RoleAdmin && actorID == u.id {
+		return false, ErrCannotRevokeOwnAdmin
+	}
+	if !u.HasRole(role) {
+		return false, nil
+	}
+
+	u.roles = slices.DeleteFunc(u.roles, func(r kernel.Role) bool { return r == role })
+	return true, nil
+}
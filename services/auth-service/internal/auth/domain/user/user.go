@@ -2,19 +2,26 @@ package user
 
 import (
 	"errors"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
 )
 
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrUserDisabled       = errors.New("account disabled")
+	ErrCannotDisableSelf  = errors.New("admins cannot disable their own account")
+	ErrEmailVerified      = errors.New("email already verified")
 )
 
 type User struct {
-	id           kernel.UserID
-	email        string
-	passwordHash string
-	status       UserStatus
+	id              kernel.UserID
+	email           string
+	passwordHash    string
+	status          UserStatus
+	roles           []kernel.Role // granted roles, besides the buyer role every user has
+	emailVerifiedAt *time.Time
 }
 
 type UserStatus string
@@ -24,12 +31,14 @@ const (
 	UserStatusDisabled UserStatus = "DISABLED"
 )
 
-func NewUser(id kernel.UserID, email, passwordHash string, status UserStatus) *User {
+func NewUser(id kernel.UserID, email, passwordHash string, status UserStatus, roles []kernel.Role, emailVerifiedAt *time.Time) *User {
 	return &User{
-		id:           id,
-		email:        email,
-		passwordHash: passwordHash,
-		status:       status,
+		id:              id,
+		email:           email,
+		passwordHash:    passwordHash,
+		status:          status,
+		roles:           roles,
+		emailVerifiedAt: emailVerifiedAt,
 	}
 }
 
@@ -61,3 +70,37 @@ func (u *User) Status() string {
 func (u *User) SetPasswordHash(hash string) {
 	u.passwordHash = hash
 }
+
+func (u *User) EmailVerifiedAt() *time.Time {
+	return u.emailVerifiedAt
+}
+
+func (u *User) EmailVerified() bool {
+	return u.emailVerifiedAt != nil
+}
+
+// VerifyEmail records that the user proved they own their email. Verifying again keeps the
+// first verification time.
+func (u *User) VerifyEmail(at time.Time) {
+	if u.emailVerifiedAt == nil {
+		u.emailVerifiedAt = &at
+	}
+}
+
+func (u *User) IsActive() bool {
+	return u.status == UserStatusActive
+}
+
+// Disable blocks the user from logging in. actorID is the admin disabling the user, who can't
+// lock themselves out.
+func (u *User) Disable(actorID kernel.UserID) error {
+	if u.id == actorID {
+		return ErrCannotDisableSelf
+	}
+	u.status = UserStatusDisabled
+	return nil
+}
+
+func (u *User) Enable() {
+	u.status = UserStatusActive
+}
@@ -19,3 +19,7 @@ func (g *UUIDGenerator) NewUserID() kernel.UserID {
 func (g *UUIDGenerator) NewTokenID() kernel.TokenID {
 	return kernel.TokenID(uuidv7.New().String())
 }
+
+func (g *UUIDGenerator) NewSessionID() kernel.SessionID {
+	return kernel.SessionID(uuidv7.New().String())
+}
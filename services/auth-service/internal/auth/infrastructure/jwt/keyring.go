@@ -0,0 +1,270 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	repository "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/repositroy"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/config"
+	"github.com/samborkent/uuidv7"
+	"go.uber.org/zap"
+)
+
+const (
+	rsaKeyBits   = 2048
+	initAttempts = 10
+)
+
+// Keyring holds the access token signing keys in memory. Keys live in the shared repository:
+// on every check the keyring rotates the signing key when it is due (one replica at a time),
+// drops retired keys whose tokens have all expired, and reloads.
+type Keyring struct {
+	repo             repository.SigningKeyRepository
+	algorithm        string
+	rotationInterval time.Duration
+	checkInterval    time.Duration
+	// retention keeps a retired key published until every token it signed has expired,
+	// including tokens signed by replicas that haven't reloaded yet
+	retention time.Duration
+
+	mu   sync.RWMutex
+	keys []model.SigningKey // newest first
+}
+
+func NewKeyring(repo repository.SigningKeyRepository, cfg config.JWTConfig) *Keyring {
+	checkInterval := time.Duration(cfg.KeyCheckInterval) * time.Second
+
+	return &Keyring{
+		repo:             repo,
+		algorithm:        cfg.SigningAlgorithm,
+		rotationInterval: time.Duration(cfg.KeyRotationInterval) * time.Second,
+		checkInterval:    checkInterval,
+		retention:        time.Duration(cfg.AccessTTL)*time.Second + checkInterval,
+	}
+}
+
+// Init loads the keys, creating the first signing key of a new deployment
+func (k *Keyring) Init(ctx context.Context) error {
+	if k.algorithm != model.AlgorithmEdDSA && k.algorithm != model.AlgorithmRS256 {
+		return fmt.Errorf("unsupported signing algorithm %q", k.algorithm)
+	}
+
+	// Replicas starting together wait for the one holding the rotation lock
+	for attempt := 0; attempt < initAttempts; attempt++ {
+		if err := k.sync(ctx); err != nil {
+			return err
+		}
+		if _, ok := k.SigningKey(); ok {
+			return nil
+		}
+		time.Sleep(time.Second)
+	}
+	return fmt.Errorf("no signing key after %d attempts", initAttempts)
+}
+
+// Start rotates and reloads the keys every check interval until ctx is done
+func (k *Keyring) Start(ctx context.Context) error {
+	logger.InfoContext(ctx, "starting signing key rotation",
+		zap.Duration("rotation_interval", k.rotationInterval),
+		zap.Duration("check_interval", k.checkInterval),
+	)
+
+	ticker := time.NewTicker(k.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := k.sync(ctx); err != nil {
+				logger.ErrorContext(ctx, "failed to sync signing keys", zap.Error(err))
+			}
+
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "signing key rotation stopping")
+			return nil
+		}
+	}
+}
+
+// SigningKey returns the key new tokens are signed with
+func (k *Keyring) SigningKey() (model.SigningKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for _, key := range k.keys {
+		if !key.Retired() {
+			return key, true
+		}
+	}
+	return model.SigningKey{}, false
+}
+
+// Key returns a published key by key ID
+func (k *Keyring) Key(id string) (model.SigningKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for _, key := range k.keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return model.SigningKey{}, false
+}
+
+// JWKS returns the public keys of every published key, newest first
+func (k *Keyring) JWKS() []model.JWK {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	jwks := make([]model.JWK, 0, len(k.keys))
+	for _, key := range k.keys {
+		jwks = append(jwks, publicJWK(key))
+	}
+	return jwks
+}
+
+func (k *Keyring) sync(ctx context.Context) error {
+	keys, err := k.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if k.rotationDue(keys) {
+		locked, err := k.repo.TryLockRotation(ctx, k.checkInterval)
+		if err != nil {
+			return err
+		}
+		if locked {
+			if keys, err = k.rotate(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	now := time.Now()
+	published := make([]model.SigningKey, 0, len(keys))
+	for _, key := range keys {
+		if key.Retired() && now.After(key.ExpireAt) {
+			if err := k.repo.Delete(ctx, key.ID); err != nil {
+				return err
+			}
+			logger.InfoContext(ctx, "expired signing key removed", zap.String("kid", key.ID))
+			continue
+		}
+		published = append(published, key)
+	}
+
+	slices.SortFunc(published, func(a, b model.SigningKey) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	k.mu.Lock()
+	k.keys = published
+	k.mu.Unlock()
+
+	return nil
+}
+
+// rotationDue reports whether there is no signing key, or it is older than the rotation
+// interval or uses another algorithm than configured
+func (k *Keyring) rotationDue(keys []model.SigningKey) bool {
+	for _, key := range keys {
+		if !key.Retired() {
+			return key.Algorithm != k.algorithm || time.Since(key.CreatedAt) >= k.rotationInterval
+		}
+	}
+	return true
+}
+
+// rotate creates a new signing key and retires the previous ones
+func (k *Keyring) rotate(ctx context.Context) ([]model.SigningKey, error) {
+	// Another replica may have rotated between listing and locking
+	keys, err := k.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !k.rotationDue(keys) {
+		return keys, nil
+	}
+
+	privateKey, err := generateKey(k.algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	next := model.SigningKey{
+		ID:         uuidv7.New().String(),
+		Algorithm:  k.algorithm,
+		PrivateKey: privateKey,
+		CreatedAt:  now,
+	}
+
+	// Publish the new key before retiring the old one, so a failure leaves a signing key
+	if err := k.repo.Save(ctx, next); err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		if key.Retired() {
+			continue
+		}
+		key.ExpireAt = now.Add(k.retention)
+		if err := k.repo.Save(ctx, key); err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+
+	logger.InfoContext(ctx, "signing key rotated",
+		zap.String("kid", next.ID),
+		zap.String("algorithm", next.Algorithm),
+	)
+
+	return append(keys, next), nil
+}
+
+func generateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case model.AlgorithmEdDSA:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		return privateKey, err
+	case model.AlgorithmRS256:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+}
+
+func publicJWK(key model.SigningKey) model.JWK {
+	jwk := model.JWK{
+		KeyID:     key.ID,
+		Algorithm: key.Algorithm,
+		Use:       "sig",
+	}
+
+	switch publicKey := key.PublicKey().(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	}
+
+	return jwk
+}
@@ -11,8 +11,10 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// JWTProvider signs access tokens with the keyring's asymmetric keys, so they can be verified
+// from the published JWKS. Refresh tokens are only verified here and keep an HMAC secret.
 type JWTProvider struct {
-	accessSecret  []byte
+	keyring       *Keyring
 	refreshSecret []byte
 	issuer        string
 	audience      string
@@ -22,10 +24,11 @@ type JWTProvider struct {
 
 var _ port.TokenIssuer = (*JWTProvider)(nil)
 var _ port.TokenVerifier = (*JWTProvider)(nil)
+var _ port.KeySetPublisher = (*JWTProvider)(nil)
 
-func NewJWTProvider(cfg config.JWTConfig) *JWTProvider {
+func NewJWTProvider(cfg config.JWTConfig, keyring *Keyring) *JWTProvider {
 	return &JWTProvider{
-		accessSecret:  []byte(cfg.AccessSecretKey),
+		keyring:       keyring,
 		refreshSecret: []byte(cfg.RefreshSecretKey),
 		issuer:        cfg.Issuer,
 		audience:      cfg.Audience,
@@ -34,19 +37,25 @@ func NewJWTProvider(cfg config.JWTConfig) *JWTProvider {
 	}
 }
 
-func (p *JWTProvider) IssueAccess(userID kernel.UserID, role kernel.Role) (string, error) {
+func (p *JWTProvider) IssueAccess(userID kernel.UserID, roles []kernel.Role) (string, error) {
 	now := time.Now()
 	claims := jwt.MapClaims{
-		"sub":  userID,
-		"role": role,
-		"iss":  p.issuer,
-		"aud":  p.audience,
-		"iat":  now.Unix(),
-		"exp":  now.Add(p.accessTTL).Unix(),
+		"sub":   userID,
+		"roles": roles,
+		"iss":   p.issuer,
+		"aud":   p.audience,
+		"iat":   now.Unix(),
+		"exp":   now.Add(p.accessTTL).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(p.accessSecret)
+	key, ok := p.keyring.SigningKey()
+	if !ok {
+		return "", errors.New("no signing key available")
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
 }
 
 func (p *JWTProvider) IssueRefresh(tokenID kernel.TokenID, userID kernel.UserID) (string, time.Time, error) {
@@ -67,11 +76,20 @@ func (p *JWTProvider) IssueRefresh(tokenID kernel.TokenID, userID kernel.UserID)
 
 func (p *JWTProvider) VerifyAccess(tokenStr string) (*model.Access, error) {
 	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := p.keyring.Key(kid)
+		if !ok {
+			return nil, errors.New("unknown signing key")
+		}
+		if t.Method.Alg() != key.Algorithm {
 			return nil, errors.New("unexpected signing method")
 		}
-		return p.accessSecret, nil
-	})
+		return key.PublicKey(), nil
+	},
+		jwt.WithValidMethods([]string{model.AlgorithmEdDSA, model.AlgorithmRS256}),
+		jwt.WithIssuer(p.issuer),
+		jwt.WithAudience(p.audience),
+	)
 	if err != nil || !token.Valid {
 		return nil, errors.New("invalid access token")
 	}
@@ -91,19 +109,26 @@ func (p *JWTProvider) VerifyAccess(tokenStr string) (*model.Access, error) {
 		return nil, errors.New("Invalid user_id")
 	}
 
-	roleClaim, ok := claims["role"].(string)
+	rolesClaim, ok := claims["roles"].([]interface{})
 	if !ok {
-		return nil, errors.New("Invalid role")
+		return nil, errors.New("Invalid roles")
 	}
-	var role kernel.Role
-	role, err = kernel.NewRole(roleClaim)
-	if err != nil {
-		return nil, errors.New("Invalid role")
+	roles := make([]kernel.Role, 0, len(rolesClaim))
+	for _, claim := range rolesClaim {
+		raw, ok := claim.(string)
+		if !ok {
+			return nil, errors.New("Invalid roles")
+		}
+		role, err := kernel.NewRole(raw)
+		if err != nil {
+			return nil, errors.New("Invalid roles")
+		}
+		roles = append(roles, role)
 	}
 
 	return &model.Access{
 		UserID:   userID,
-		Role:     role,
+		Roles:    roles,
 		IssuedAt: time.Unix(int64(claims["iat"].(float64)), 0),
 		ExpireAt: time.Unix(int64(claims["exp"].(float64)), 0),
 	}, nil
@@ -132,3 +157,7 @@ func (j *JWTProvider) VerifyRefresh(tokenStr string) (kernel.TokenID, error) {
 
 	return kernel.NewTokenID(jtiClaim)
 }
+
+func (p *JWTProvider) JWKS() []model.JWK {
+	return p.keyring.JWKS()
+}
@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/port"
+)
+
+// FileMailer appends emails to a local mbox-style file instead of sending them, for local
+// development and manual testing of the email flows
+type FileMailer struct {
+	from string
+	path string
+	mu   sync.Mutex
+}
+
+var _ port.Mailer = (*FileMailer)(nil)
+
+func NewFileMailer(from, path string) *FileMailer {
+	return &FileMailer{
+		from: from,
+		path: path,
+	}
+}
+
+func (m *FileMailer) Send(ctx context.Context, email model.Email) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "From %s %s\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		m.from,
+		time.Now().UTC().Format(time.ANSIC),
+		m.from,
+		email.To,
+		email.Subject,
+		email.Body,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+
+	return nil
+}
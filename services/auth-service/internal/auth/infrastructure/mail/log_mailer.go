@@ -0,0 +1,32 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/port"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"go.uber.org/zap"
+)
+
+// LogMailer writes emails to the service log instead of sending them. The body holds
+// redeemable links, so it is for local development only.
+type LogMailer struct {
+	from string
+}
+
+var _ port.Mailer = (*LogMailer)(nil)
+
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+func (m *LogMailer) Send(ctx context.Context, email model.Email) error {
+	logger.InfoContext(ctx, "email sent to log",
+		zap.String("from", m.from),
+		zap.String("to", email.To),
+		zap.String("subject", email.Subject),
+		zap.String("body", email.Body),
+	)
+	return nil
+}
@@ -0,0 +1,130 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/port"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/config"
+	"github.com/samborkent/uuidv7"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+// EventMessage represents a Kafka event message
+type EventMessage struct {
+	EventID     string                 `json:"event_id"`
+	EventType   string                 `json:"event_type"`
+	AggregateID string                 `json:"aggregate_id"`
+	OccurredAt  time.Time              `json:"occurred_at"`
+	Data        map[string]interface{} `json:"data"`
+}
+
+// SecurityEventPublisher publishes security events to the audit topic. Writes are
+// asynchronous so a slow or unavailable broker doesn't hold up logins; failed writes are
+// logged and the event is lost.
+type SecurityEventPublisher struct {
+	writer *kafka.Writer
+	topic  string
+}
+
+var _ port.SecurityEventPublisher = (*SecurityEventPublisher)(nil)
+
+func NewSecurityEventPublisher(cfg *config.KafkaConfig) *SecurityEventPublisher {
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Topic:        cfg.AuditTopic,
+		Balancer:     &kafka.Hash{}, // Partition by subject
+		MaxAttempts:  cfg.ProducerMaxAttempts,
+		BatchTimeout: cfg.ProducerBatchTimeout,
+		RequiredAcks: kafka.RequireAll,
+		Async:        true,
+		Completion: func(messages []kafka.Message, err error) {
+			if err != nil {
+				zap.L().Error("failed to publish security events",
+					zap.String("topic", cfg.AuditTopic),
+					zap.Int("count", len(messages)),
+					zap.Error(err),
+				)
+			}
+		},
+	}
+
+	return &SecurityEventPublisher{
+		writer: writer,
+		topic:  cfg.AuditTopic,
+	}
+}
+
+// Publish queues the event for the audit topic. The trace context of ctx is carried in the
+// message headers.
+func (p *SecurityEventPublisher) Publish(ctx context.Context, event model.SecurityEvent) error {
+	data := map[string]interface{}{
+		"subject_type": string(event.Subject.Kind),
+		"subject":      event.Subject.Value,
+		"email":        event.Email,
+		"ip_address":   event.IPAddress,
+	}
+	if event.UserID != "" {
+		data["user_id"] = string(event.UserID)
+	}
+	if event.Reason != "" {
+		data["reason"] = event.Reason
+	}
+	if event.Failures > 0 {
+		data["failures"] = event.Failures
+	}
+	if !event.LockedUntil.IsZero() {
+		data["locked_until"] = event.LockedUntil
+	}
+
+	msg := EventMessage{
+		EventID:     uuidv7.New().String(),
+		EventType:   string(event.Type),
+		AggregateID: event.Subject.Value,
+		OccurredAt:  event.OccurredAt,
+		Data:        data,
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal security event: %w", err)
+	}
+
+	kafkaMsg := kafka.Message{
+		Key:   []byte(msg.AggregateID),
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "event_type", Value: []byte(msg.EventType)},
+			{Key: "event_id", Value: []byte(msg.EventID)},
+		},
+		Time: msg.OccurredAt,
+	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{headers: &kafkaMsg.Headers})
+
+	// The async writer only fails here when it is closed
+	if err := p.writer.WriteMessages(ctx, kafkaMsg); err != nil {
+		return fmt.Errorf("failed to write security event: %w", err)
+	}
+
+	logger.DebugContext(ctx, "security event queued",
+		zap.String("topic", p.topic),
+		zap.String("event_type", msg.EventType),
+		zap.String("event_id", msg.EventID),
+	)
+
+	return nil
+}
+
+// Close flushes queued events and closes the writer
+func (p *SecurityEventPublisher) Close() error {
+	if err := p.writer.Close(); err != nil {
+		return fmt.Errorf("failed to close security event publisher: %w", err)
+	}
+	return nil
+}
@@ -4,13 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/user"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
+// selectUser loads a user with the roles granted in user_roles
+const selectUser = `
+	SELECT u.id, u.email, u.password_hash, u.status, u.email_verified_at,
+		COALESCE(array_agg(r.role) FILTER (WHERE r.role IS NOT NULL), '{}')
+	FROM users u
+	LEFT JOIN user_roles r ON r.user_id = u.id
+`
+
 type UserRepository struct {
 	db *sql.DB
 }
@@ -24,15 +35,14 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.U
 		zap.String("email", email),
 	)
 
-	var id, passwordHash, status string
-
-	row := r.db.QueryRowContext(ctx, "SELECT id, password_hash, status FROM users WHERE email = $1", email)
-	if err := row.Scan(&id, &passwordHash, &status); err != nil {
+	row := r.db.QueryRowContext(ctx, selectUser+" WHERE u.email = $1 GROUP BY u.id", email)
+	u, err := scanUser(row)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			logger.DebugContext(ctx, "user not found",
 				zap.String("email", email),
 			)
-			return nil, errors.New("user not found")
+			return nil, user.ErrUserNotFound
 		}
 
 		logger.ErrorContext(ctx, "database query failed",
@@ -43,24 +53,71 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.U
 		return nil, err
 	}
 
-	userID, err := kernel.NewUserID(id)
+	return u, nil
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id kernel.UserID) (*user.User, error) {
+	logger.DebugContext(ctx, "querying user by id",
+		zap.String("user_id", string(id)),
+	)
+
+	row := r.db.QueryRowContext(ctx, selectUser+" WHERE u.id = $1 GROUP BY u.id", id)
+	u, err := scanUser(row)
 	if err != nil {
-		logger.ErrorContext(ctx, "invalid user id from database",
-			zap.String("id", id),
-			zap.String("email", email),
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.DebugContext(ctx, "user not found",
+				zap.String("user_id", string(id)),
+			)
+			return nil, user.ErrUserNotFound
+		}
+
+		logger.ErrorContext(ctx, "database query failed",
+			zap.String("operation", "FindByID"),
+			zap.String("user_id", string(id)),
 			zap.Error(err),
 		)
 		return nil, err
 	}
 
-	u := user.NewUser(
+	return u, nil
+}
+
+func scanUser(row *sql.Row) (*user.User, error) {
+	var id, email, passwordHash, status string
+	var emailVerifiedAt sql.NullTime
+	var rawRoles []string
+
+	if err := row.Scan(&id, &email, &passwordHash, &status, &emailVerifiedAt, pq.Array(&rawRoles)); err != nil {
+		return nil, err
+	}
+
+	userID, err := kernel.NewUserID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id from database: %w", err)
+	}
+
+	roles := make([]kernel.Role, 0, len(rawRoles))
+	for _, raw := range rawRoles {
+		role, err := kernel.NewRole(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role %q from database: %w", raw, err)
+		}
+		roles = append(roles, role)
+	}
+
+	var verifiedAt *time.Time
+	if emailVerifiedAt.Valid {
+		verifiedAt = &emailVerifiedAt.Time
+	}
+
+	return user.NewUser(
 		userID,
 		email,
 		passwordHash,
 		user.UserStatus(status),
-	)
-
-	return u, nil
+		roles,
+		verifiedAt,
+	), nil
 }
 
 func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
@@ -69,13 +126,20 @@ func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
 		zap.String("email", u.Email()),
 	)
 
-	_, err := r.db.ExecContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
 		ctx,
-		"INSERT INTO users (id, email, password_hash, status) VALUES ($1, $2, $3, $4)",
+		"INSERT INTO users (id, email, password_hash, status, email_verified_at) VALUES ($1, $2, $3, $4, $5)",
 		u.ID(),
 		u.Email(),
 		u.PasswordHash(),
 		u.Status(),
+		u.EmailVerifiedAt(),
 	)
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to save user",
@@ -86,6 +150,27 @@ func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
 		return err
 	}
 
+	// Roles granted at registration have no granting admin
+	for _, role := range u.GrantedRoles() {
+		if _, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO user_roles (user_id, role, granted_at) VALUES ($1, $2, NOW())",
+			u.ID(),
+			role,
+		); err != nil {
+			logger.ErrorContext(ctx, "failed to save user role",
+				zap.String("user_id", string(u.ID())),
+				zap.String("role", role.String()),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	logger.DebugContext(ctx, "user saved successfully",
 		zap.String("user_id", string(u.ID())),
 	)
@@ -93,4 +178,76 @@ func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
 	return nil
 }
 
+func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
+	logger.DebugContext(ctx, "updating user",
+		zap.String("user_id", string(u.ID())),
+	)
+
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE users SET password_hash = $2, status = $3, email_verified_at = $4 WHERE id = $1",
+		u.ID(),
+		u.PasswordHash(),
+		u.Status(),
+		u.EmailVerifiedAt(),
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to update user",
+			zap.String("user_id", string(u.ID())),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) AddRole(ctx context.Context, userID kernel.UserID, role kernel.Role, grantedBy kernel.UserID) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO user_roles (user_id, role, granted_by, granted_at) VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id, role) DO NOTHING`,
+		userID,
+		role,
+		sql.NullString{String: string(grantedBy), Valid: grantedBy != ""},
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to add user role",
+			zap.String("user_id", string(userID)),
+			zap.String("role", role.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepository) RemoveRole(ctx context.Context, userID kernel.UserID, role kernel.Role) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"DELETE FROM user_roles WHERE user_id = $1 AND role = $2",
+		userID,
+		role,
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to remove user role",
+			zap.String("user_id", string(userID)),
+			zap.String("role", role.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
 var _ user.UserRepository = (*UserRepository)(nil)
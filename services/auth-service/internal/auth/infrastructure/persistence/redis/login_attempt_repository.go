@@ -0,0 +1,151 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	repository "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/repositroy"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// recordLoginFailureScript counts a failure, starting a window of ARGV[1] ms at the first one.
+// KEYS[1] = login attempts hash
+// Returns the failures in the window
+var recordLoginFailureScript = redis.NewScript(`
+	local failures = redis.call('HINCRBY', KEYS[1], 'failures', 1)
+	if failures == 1 then
+		redis.call('PEXPIRE', KEYS[1], ARGV[1])
+	end
+	return failures
+`)
+
+// blockLoginScript sets when the next attempt is allowed and keeps the hash until then.
+// KEYS[1] = login attempts hash
+// ARGV: retry at (unix ms), locked until (unix ms, 0 when not locked)
+var blockLoginScript = redis.NewScript(`
+	redis.call('HSET', KEYS[1], 'retry_at', ARGV[1])
+	if ARGV[2] ~= '0' then
+		redis.call('HSET', KEYS[1], 'locked_until', ARGV[2])
+	end
+	local expireAt = math.max(tonumber(ARGV[1]), tonumber(ARGV[2]))
+	if redis.call('PEXPIRETIME', KEYS[1]) < expireAt then
+		redis.call('PEXPIREAT', KEYS[1], expireAt)
+	end
+	return 1
+`)
+
+// RedisLoginAttemptRepo keeps the failed logins of each subject in a hash that expires with
+// the failure window, or with the block if that ends later
+type RedisLoginAttemptRepo struct {
+	rdb *redis.Client
+}
+
+var _ repository.LoginAttemptRepository = (*RedisLoginAttemptRepo)(nil)
+
+func NewRedisLoginAttemptRepo(rdb *redis.Client) *RedisLoginAttemptRepo {
+	return &RedisLoginAttemptRepo{rdb: rdb}
+}
+
+func loginAttemptsKey(subject model.LoginSubject) string {
+	return "login_attempts:" + string(subject.Kind) + ":" + strings.ToLower(subject.Value)
+}
+
+func (r *RedisLoginAttemptRepo) Find(ctx context.Context, subject model.LoginSubject) (model.LoginAttempts, error) {
+	fields, err := r.rdb.HGetAll(ctx, loginAttemptsKey(subject)).Result()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get login attempts from redis",
+			zap.String("subject", string(subject.Kind)),
+			zap.Error(err),
+		)
+		return model.LoginAttempts{}, err
+	}
+
+	return parseLoginAttempts(fields)
+}
+
+func (r *RedisLoginAttemptRepo) RecordFailure(ctx context.Context, subject model.LoginSubject, window time.Duration) (int, error) {
+	failures, err := recordLoginFailureScript.Run(ctx, r.rdb,
+		[]string{loginAttemptsKey(subject)},
+		window.Milliseconds(),
+	).Int()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to record login failure",
+			zap.String("subject", string(subject.Kind)),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+func (r *RedisLoginAttemptRepo) Block(ctx context.Context, subject model.LoginSubject, retryAt time.Time, lockedUntil time.Time) error {
+	var locked int64
+	if !lockedUntil.IsZero() {
+		locked = lockedUntil.UnixMilli()
+	}
+
+	err := blockLoginScript.Run(ctx, r.rdb,
+		[]string{loginAttemptsKey(subject)},
+		retryAt.UnixMilli(),
+		locked,
+	).Err()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to block login attempts",
+			zap.String("subject", string(subject.Kind)),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RedisLoginAttemptRepo) Reset(ctx context.Context, subject model.LoginSubject) error {
+	if err := r.rdb.Del(ctx, loginAttemptsKey(subject)).Err(); err != nil {
+		logger.ErrorContext(ctx, "failed to reset login attempts",
+			zap.String("subject", string(subject.Kind)),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func parseLoginAttempts(fields map[string]string) (model.LoginAttempts, error) {
+	var attempts model.LoginAttempts
+	if len(fields) == 0 {
+		return attempts, nil
+	}
+
+	failures, err1 := parseOptionalInt(fields["failures"])
+	retryAt, err2 := parseOptionalInt(fields["retry_at"])
+	lockedUntil, err3 := parseOptionalInt(fields["locked_until"])
+	if err := errors.Join(err1, err2, err3); err != nil {
+		return attempts, fmt.Errorf("invalid login attempts: %w", err)
+	}
+
+	attempts.Failures = int(failures)
+	if retryAt > 0 {
+		attempts.RetryAt = time.UnixMilli(retryAt)
+	}
+	if lockedUntil > 0 {
+		attempts.LockedUntil = time.UnixMilli(lockedUntil)
+	}
+	return attempts, nil
+}
+
+func parseOptionalInt(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,124 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	repository "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/repositroy"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// issueOneTimeTokenScript stores a token and replaces the user's previous token of the purpose.
+// KEYS[1] = token key, KEYS[2] = user's token pointer
+// ARGV: user id, token hash, ttl (ms), token key prefix
+var issueOneTimeTokenScript = redis.NewScript(`
+	local previous = redis.call('GET', KEYS[2])
+	if previous then
+		redis.call('DEL', ARGV[4] .. previous)
+	end
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+	redis.call('SET', KEYS[2], ARGV[2], 'PX', ARGV[3])
+	return 1
+`)
+
+// consumeOneTimeTokenScript deletes a token and returns its user id, or nil for an unknown token.
+// KEYS[1] = token key
+// ARGV: user pointer prefix, token hash
+var consumeOneTimeTokenScript = redis.NewScript(`
+	local userID = redis.call('GET', KEYS[1])
+	if not userID then
+		return false
+	end
+	redis.call('DEL', KEYS[1])
+	local pointer = ARGV[1] .. userID
+	if redis.call('GET', pointer) == ARGV[2] then
+		redis.call('DEL', pointer)
+	end
+	return userID
+`)
+
+// oneTimeTokenBytes is the entropy of a token
+const oneTimeTokenBytes = 32
+
+// RedisOneTimeTokenRepo keeps only the SHA-256 of each token, so tokens can't be read back
+// from Redis. Every user also has a pointer to their live token per purpose.
+type RedisOneTimeTokenRepo struct {
+	rdb *redis.Client
+}
+
+var _ repository.OneTimeTokenRepository = (*RedisOneTimeTokenRepo)(nil)
+
+func NewRedisOneTimeTokenRepo(rdb *redis.Client) *RedisOneTimeTokenRepo {
+	return &RedisOneTimeTokenRepo{rdb: rdb}
+}
+
+func oneTimeTokenPrefix(purpose model.TokenPurpose) string {
+	return "one_time_token:" + string(purpose) + ":"
+}
+
+func userOneTimeTokenPrefix(purpose model.TokenPurpose) string {
+	return "user_one_time_token:" + string(purpose) + ":"
+}
+
+func hashOneTimeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *RedisOneTimeTokenRepo) Issue(ctx context.Context, purpose model.TokenPurpose, userID kernel.UserID) (string, error) {
+	raw := make([]byte, oneTimeTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	hash := hashOneTimeToken(token)
+
+	err := issueOneTimeTokenScript.Run(ctx, r.rdb,
+		[]string{oneTimeTokenPrefix(purpose) + hash, userOneTimeTokenPrefix(purpose) + string(userID)},
+		string(userID),
+		hash,
+		purpose.TTL().Milliseconds(),
+		oneTimeTokenPrefix(purpose),
+	).Err()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to issue one-time token",
+			zap.String("purpose", string(purpose)),
+			zap.String("user_id", string(userID)),
+			zap.Error(err),
+		)
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (r *RedisOneTimeTokenRepo) Consume(ctx context.Context, purpose model.TokenPurpose, token string) (kernel.UserID, error) {
+	hash := hashOneTimeToken(token)
+
+	userID, err := consumeOneTimeTokenScript.Run(ctx, r.rdb,
+		[]string{oneTimeTokenPrefix(purpose) + hash},
+		userOneTimeTokenPrefix(purpose),
+		hash,
+	).Text()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", model.ErrInvalidOneTimeToken
+		}
+		logger.ErrorContext(ctx, "failed to consume one-time token",
+			zap.String("purpose", string(purpose)),
+			zap.Error(err),
+		)
+		return "", err
+	}
+
+	return kernel.UserID(userID), nil
+}
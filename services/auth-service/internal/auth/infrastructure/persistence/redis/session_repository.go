@@ -0,0 +1,254 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	repository "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/repositroy"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// rotateSessionScript swaps the session's current refresh token if it is still ARGV[1].
+// KEYS[1] = session hash, KEYS[2] = user's session index
+// ARGV: current token, next token, last used (unix), expire at (unix), session id
+// Returns 1 when rotated, 0 when the session is gone or ARGV[1] was already rotated
+var rotateSessionScript = redis.NewScript(`
+	if redis.call('HGET', KEYS[1], 'current_token_id') ~= ARGV[1] then
+		return 0
+	end
+	redis.call('HSET', KEYS[1], 'current_token_id', ARGV[2], 'last_used_at', ARGV[3], 'expire_at', ARGV[4])
+	redis.call('EXPIREAT', KEYS[1], ARGV[4])
+	redis.call('ZADD', KEYS[2], ARGV[4], ARGV[5])
+	redis.call('EXPIREAT', KEYS[2], ARGV[4], 'GT')
+	return 1
+`)
+
+// RedisSessionRepo stores each session in a hash, indexed per user in a sorted set scored by
+// the session's expiry
+type RedisSessionRepo struct {
+	rdb *redis.Client
+}
+
+var _ repository.SessionRepository = (*RedisSessionRepo)(nil)
+
+func NewRedisSessionRepo(rdb *redis.Client) *RedisSessionRepo {
+	return &RedisSessionRepo{rdb: rdb}
+}
+
+func sessionKey(id kernel.SessionID) string {
+	return "session:" + string(id)
+}
+
+func userSessionsKey(userID kernel.UserID) string {
+	return "user_sessions:" + string(userID)
+}
+
+func (r *RedisSessionRepo) Create(ctx context.Context, session model.Session) error {
+	key := sessionKey(session.ID)
+	indexKey := userSessionsKey(session.UserID)
+
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, map[string]interface{}{
+			"user_id":          string(session.UserID),
+			"current_token_id": string(session.CurrentTokenID),
+			"user_agent":       session.Client.UserAgent,
+			"ip_address":       session.Client.IPAddress,
+			"created_at":       session.CreatedAt.Unix(),
+			"last_used_at":     session.LastUsedAt.Unix(),
+			"expire_at":        session.ExpireAt.Unix(),
+		})
+		pipe.ExpireAt(ctx, key, session.ExpireAt)
+		pipe.ZAdd(ctx, indexKey, redis.Z{Score: float64(session.ExpireAt.Unix()), Member: string(session.ID)})
+		// The index lives as long as the user's longest session
+		pipe.ExpireNX(ctx, indexKey, time.Until(session.ExpireAt))
+		pipe.ExpireGT(ctx, indexKey, time.Until(session.ExpireAt))
+		return nil
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to create session",
+			zap.String("session_id", string(session.ID)),
+			zap.String("user_id", string(session.UserID)),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RedisSessionRepo) Find(ctx context.Context, id kernel.SessionID) (*model.Session, error) {
+	fields, err := r.rdb.HGetAll(ctx, sessionKey(id)).Result()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get session from redis",
+			zap.String("session_id", string(id)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return parseSession(id, fields)
+}
+
+func (r *RedisSessionRepo) ListByUser(ctx context.Context, userID kernel.UserID) ([]model.Session, error) {
+	indexKey := userSessionsKey(userID)
+
+	// Drop sessions that expired since they were indexed
+	if err := r.rdb.ZRemRangeByScore(ctx, indexKey, "-inf", strconv.FormatInt(time.Now().Unix(), 10)).Err(); err != nil {
+		return nil, fmt.Errorf("failed to prune expired sessions: %w", err)
+	}
+
+	ids, err := r.rdb.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	pipe := r.rdb.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, sessionKey(kernel.SessionID(id)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load sessions: %w", err)
+	}
+
+	sessions := make([]model.Session, 0, len(ids))
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			continue
+		}
+
+		session, err := parseSession(kernel.SessionID(ids[i]), fields)
+		if err != nil {
+			logger.WarnContext(ctx, "skipping unreadable session",
+				zap.String("session_id", ids[i]),
+				zap.Error(err),
+			)
+			continue
+		}
+		sessions = append(sessions, *session)
+	}
+
+	return sessions, nil
+}
+
+func (r *RedisSessionRepo) Rotate(ctx context.Context, id kernel.SessionID, current, next kernel.TokenID, expireAt time.Time) (bool, error) {
+	session, err := r.Find(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if session == nil {
+		return false, nil
+	}
+
+	rotated, err := rotateSessionScript.Run(ctx, r.rdb,
+		[]string{sessionKey(id), userSessionsKey(session.UserID)},
+		string(current),
+		string(next),
+		time.Now().Unix(),
+		expireAt.Unix(),
+		string(id),
+	).Int()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to rotate session",
+			zap.String("session_id", string(id)),
+			zap.Error(err),
+		)
+		return false, err
+	}
+
+	return rotated == 1, nil
+}
+
+func (r *RedisSessionRepo) Revoke(ctx context.Context, userID kernel.UserID, id kernel.SessionID) error {
+	logger.InfoContext(ctx, "revoking session",
+		zap.String("session_id", string(id)),
+		zap.String("user_id", string(userID)),
+	)
+
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(id))
+		pipe.ZRem(ctx, userSessionsKey(userID), string(id))
+		return nil
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to revoke session",
+			zap.String("session_id", string(id)),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RedisSessionRepo) RevokeAll(ctx context.Context, userID kernel.UserID) (int, error) {
+	indexKey := userSessionsKey(userID)
+
+	ids, err := r.rdb.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, sessionKey(kernel.SessionID(id)))
+	}
+	keys = append(keys, indexKey)
+
+	revoked, err := r.rdb.Del(ctx, keys...).Result()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to revoke sessions",
+			zap.String("user_id", string(userID)),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	// The index key itself is counted when it existed
+	if len(ids) > 0 {
+		revoked--
+	}
+
+	logger.InfoContext(ctx, "all sessions revoked",
+		zap.String("user_id", string(userID)),
+		zap.Int64("revoked", revoked),
+	)
+
+	return int(revoked), nil
+}
+
+func parseSession(id kernel.SessionID, fields map[string]string) (*model.Session, error) {
+	createdAt, err1 := strconv.ParseInt(fields["created_at"], 10, 64)
+	lastUsedAt, err2 := strconv.ParseInt(fields["last_used_at"], 10, 64)
+	expireAt, err3 := strconv.ParseInt(fields["expire_at"], 10, 64)
+	if err := errors.Join(err1, err2, err3); err != nil {
+		return nil, fmt.Errorf("invalid session timestamps: %w", err)
+	}
+
+	return &model.Session{
+		ID:             id,
+		UserID:         kernel.UserID(fields["user_id"]),
+		CurrentTokenID: kernel.TokenID(fields["current_token_id"]),
+		Client: model.ClientInfo{
+			UserAgent: fields["user_agent"],
+			IPAddress: fields["ip_address"],
+		},
+		CreatedAt:  time.Unix(createdAt, 0),
+		LastUsedAt: time.Unix(lastUsedAt, 0),
+		ExpireAt:   time.Unix(expireAt, 0),
+	}, nil
+}
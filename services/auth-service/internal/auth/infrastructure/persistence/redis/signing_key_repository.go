@@ -0,0 +1,214 @@
+package redis
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	repository "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/repositroy"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	signingKeysKey         = "jwt:signing_keys"
+	signingKeysRotationKey = "jwt:signing_keys:rotation_lock"
+)
+
+// storedSigningKey is a signing key as stored in the signing keys hash, by key ID
+type storedSigningKey struct {
+	ID                  string    `json:"id"`
+	Algorithm           string    `json:"algorithm"`
+	EncryptedPrivateKey string    `json:"encrypted_private_key,omitempty"` // base64 AES-GCM nonce and sealed PKCS #8 PEM
+	PrivateKey          string    `json:"private_key,omitempty"`           // PKCS #8 PEM, only in keys saved before encryption
+	CreatedAt           time.Time `json:"created_at"`
+	ExpireAt            time.Time `json:"expire_at"`
+}
+
+// RedisSigningKeyRepo shares the signing keys between auth-service replicas.
+// Private keys are encrypted with the key encryption key, so a Redis dump or replica
+// does not expose them.
+type RedisSigningKeyRepo struct {
+	rdb  *redis.Client
+	aead cipher.AEAD
+}
+
+var _ repository.SigningKeyRepository = (*RedisSigningKeyRepo)(nil)
+
+// NewRedisSigningKeyRepo encrypts private keys with AES-256-GCM under the SHA-256 of encryptionKey
+func NewRedisSigningKeyRepo(rdb *redis.Client, encryptionKey string) *RedisSigningKeyRepo {
+	key := sha256.Sum256([]byte(encryptionKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		panic(fmt.Sprintf("failed to create signing key cipher: %v", err))
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create signing key cipher: %v", err))
+	}
+
+	return &RedisSigningKeyRepo{rdb: rdb, aead: aead}
+}
+
+func (r *RedisSigningKeyRepo) List(ctx context.Context) ([]model.SigningKey, error) {
+	values, err := r.rdb.HGetAll(ctx, signingKeysKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	keys := make([]model.SigningKey, 0, len(values))
+	for id, value := range values {
+		var stored storedSigningKey
+		if err := json.Unmarshal([]byte(value), &stored); err != nil {
+			logger.ErrorContext(ctx, "failed to unmarshal signing key",
+				zap.String("kid", id),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		encoded := stored.PrivateKey
+		if stored.EncryptedPrivateKey != "" {
+			encoded, err = r.decrypt(stored.ID, stored.EncryptedPrivateKey)
+			if err != nil {
+				logger.ErrorContext(ctx, "failed to decrypt signing key",
+					zap.String("kid", id),
+					zap.Error(err),
+				)
+				continue
+			}
+		}
+
+		privateKey, err := parsePrivateKey(encoded)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to parse signing key",
+				zap.String("kid", id),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		keys = append(keys, model.SigningKey{
+			ID:         stored.ID,
+			Algorithm:  stored.Algorithm,
+			PrivateKey: privateKey,
+			CreatedAt:  stored.CreatedAt,
+			ExpireAt:   stored.ExpireAt,
+		})
+	}
+
+	return keys, nil
+}
+
+func (r *RedisSigningKeyRepo) Save(ctx context.Context, key model.SigningKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal signing key: %w", err)
+	}
+
+	encrypted, err := r.encrypt(key.ID, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	data, err := json.Marshal(storedSigningKey{
+		ID:                  key.ID,
+		Algorithm:           key.Algorithm,
+		EncryptedPrivateKey: encrypted,
+		CreatedAt:           key.CreatedAt,
+		ExpireAt:            key.ExpireAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal signing key: %w", err)
+	}
+
+	if err := r.rdb.HSet(ctx, signingKeysKey, key.ID, data).Err(); err != nil {
+		logger.ErrorContext(ctx, "failed to save signing key",
+			zap.String("kid", key.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RedisSigningKeyRepo) Delete(ctx context.Context, id string) error {
+	if err := r.rdb.HDel(ctx, signingKeysKey, id).Err(); err != nil {
+		logger.ErrorContext(ctx, "failed to delete signing key",
+			zap.String("kid", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *RedisSigningKeyRepo) TryLockRotation(ctx context.Context, ttl time.Duration) (bool, error) {
+	locked, err := r.rdb.SetNX(ctx, signingKeysRotationKey, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to take key rotation lock: %w", err)
+	}
+	return locked, nil
+}
+
+// encrypt seals a private key, prefixing the random nonce. The key ID is authenticated
+// too, so a sealed key cannot be moved to another key ID.
+func (r *RedisSigningKeyRepo) encrypt(id string, plaintext string) (string, error) {
+	nonce := make([]byte, r.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := r.aead.Seal(nonce, nonce, []byte(plaintext), []byte(id))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt opens a private key sealed by encrypt
+func (r *RedisSigningKeyRepo) decrypt(id string, encoded string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < r.aead.NonceSize() {
+		return "", errors.New("sealed key too short")
+	}
+
+	nonce, ciphertext := sealed[:r.aead.NonceSize()], sealed[r.aead.NonceSize():]
+	plaintext, err := r.aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+func parsePrivateKey(encoded string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(encoded))
+	if block == nil {
+		return nil, errors.New("invalid PEM")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+	return signer, nil
+}
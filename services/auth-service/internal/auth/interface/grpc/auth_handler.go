@@ -2,13 +2,18 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/application/service"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
 	pb "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/auth/v1"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type AuthHandler struct {
@@ -27,7 +32,10 @@ func (h *AuthHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		zap.String("email", req.GetEmail()),
 	)
 
-	userID, accessToken, refreshToken, err := h.authService.Register(ctx, req.GetEmail(), req.GetPassword())
+	userID, accessToken, refreshToken, err := h.authService.Register(ctx, req.GetEmail(), req.GetPassword(), model.ClientInfo{
+		UserAgent: req.GetUserAgent(),
+		IPAddress: req.GetIpAddress(),
+	})
 	if err != nil {
 		grpcErr := mapDomainErrorToGRPC(err)
 		code := status.Code(grpcErr)
@@ -71,7 +79,10 @@ func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 		zap.String("email", req.GetEmail()),
 	)
 
-	userID, accessToken, refreshToken, err := h.authService.Login(ctx, req.GetEmail(), req.GetPassword())
+	userID, accessToken, refreshToken, err := h.authService.Login(ctx, req.GetEmail(), req.GetPassword(), model.ClientInfo{
+		UserAgent: req.GetUserAgent(),
+		IPAddress: req.GetIpAddress(),
+	})
 	if err != nil {
 		grpcErr := mapDomainErrorToGRPC(err)
 		code := status.Code(grpcErr)
@@ -113,7 +124,7 @@ func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 func (h *AuthHandler) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResponse, error) {
 	logger.DebugContext(ctx, "handling Refresh request")
 
-	accessToken, err := h.authService.Refresh(ctx, req.GetRefreshToken())
+	accessToken, refreshToken, err := h.authService.Refresh(ctx, req.GetRefreshToken())
 	if err != nil {
 		grpcErr := mapDomainErrorToGRPC(err)
 		code := status.Code(grpcErr)
@@ -122,6 +133,10 @@ func (h *AuthHandler) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.
 			logger.ErrorContext(ctx, "refresh token failed",
 				zap.String("error", err.Error()),
 			)
+		} else if errors.Is(err, model.ErrRefreshTokenReused) {
+			logger.WarnContext(ctx, "refresh token reuse detected",
+				zap.String("error", err.Error()),
+			)
 		} else {
 			logger.DebugContext(ctx, "refresh token failed",
 				zap.String("error", err.Error()),
@@ -134,14 +149,103 @@ func (h *AuthHandler) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.
 	logger.DebugContext(ctx, "token refreshed successfully")
 
 	return &pb.RefreshResponse{
-		AccessToken: accessToken,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (h *AuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	logger.DebugContext(ctx, "handling Logout request")
+
+	if err := h.authService.Logout(ctx, req.GetRefreshToken()); err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "logout failed",
+				zap.String("error", err.Error()),
+			)
+		} else {
+			logger.DebugContext(ctx, "logout failed",
+				zap.String("error", err.Error()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	return &pb.LogoutResponse{}, nil
+}
+
+func (h *AuthHandler) LogoutAll(ctx context.Context, req *pb.LogoutAllRequest) (*pb.LogoutAllResponse, error) {
+	logger.InfoContext(ctx, "handling LogoutAll request",
+		zap.String("user_id", req.GetUserId()),
+	)
+
+	userID, err := kernel.NewUserID(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	revoked, err := h.authService.LogoutAll(ctx, userID)
+	if err != nil {
+		logger.ErrorContext(ctx, "logout all failed",
+			zap.String("user_id", req.GetUserId()),
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	logger.InfoContext(ctx, "user logged out of all sessions",
+		zap.String("user_id", req.GetUserId()),
+		zap.Int("sessions_revoked", revoked),
+	)
+
+	return &pb.LogoutAllResponse{
+		SessionsRevoked: int32(revoked),
+	}, nil
+}
+
+func (h *AuthHandler) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	logger.DebugContext(ctx, "handling ListSessions request",
+		zap.String("user_id", req.GetUserId()),
+	)
+
+	userID, err := kernel.NewUserID(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sessions, err := h.authService.ListSessions(ctx, userID)
+	if err != nil {
+		logger.ErrorContext(ctx, "list sessions failed",
+			zap.String("user_id", req.GetUserId()),
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	protoSessions := make([]*pb.Session, 0, len(sessions))
+	for _, session := range sessions {
+		protoSessions = append(protoSessions, &pb.Session{
+			Id:         string(session.ID),
+			UserAgent:  session.Client.UserAgent,
+			IpAddress:  session.Client.IPAddress,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastUsedAt: timestamppb.New(session.LastUsedAt),
+			ExpiresAt:  timestamppb.New(session.ExpireAt),
+		})
+	}
+
+	return &pb.ListSessionsResponse{
+		Sessions: protoSessions,
 	}, nil
 }
 
 func (h *AuthHandler) VerifyToken(ctx context.Context, req *pb.VerifyRequest) (*pb.VerifyResponse, error) {
 	logger.DebugContext(ctx, "handling VerifyToken request")
 
-	access, err := h.authService.VerifyAccessToken(req.GetToken())
+	access, err := h.authService.VerifyAccessToken(ctx, req.GetToken())
 	if err != nil {
 		logger.DebugContext(ctx, "token verification failed",
 			zap.String("error", err.Error()),
@@ -156,6 +260,267 @@ func (h *AuthHandler) VerifyToken(ctx context.Context, req *pb.VerifyRequest) (*
 	return &pb.VerifyResponse{
 		Valid:  true,
 		UserId: string(access.UserID),
-		Role:   string(access.Role),
+		Roles:  rolesToStrings(access.Roles),
 	}, nil
 }
+
+func (h *AuthHandler) GetJWKS(ctx context.Context, req *pb.GetJWKSRequest) (*pb.GetJWKSResponse, error) {
+	logger.DebugContext(ctx, "handling GetJWKS request")
+
+	keys := h.authService.PublicKeys()
+
+	protoKeys := make([]*pb.JWK, 0, len(keys))
+	for _, key := range keys {
+		protoKeys = append(protoKeys, &pb.JWK{
+			Kid: key.KeyID,
+			Kty: key.KeyType,
+			Alg: key.Algorithm,
+			Use: key.Use,
+			Crv: key.Curve,
+			X:   key.X,
+			N:   key.N,
+			E:   key.E,
+		})
+	}
+
+	return &pb.GetJWKSResponse{
+		Keys: protoKeys,
+	}, nil
+}
+
+func (h *AuthHandler) GrantRole(ctx context.Context, req *pb.GrantRoleRequest) (*pb.GrantRoleResponse, error) {
+	logger.InfoContext(ctx, "handling GrantRole request",
+		zap.String("user_id", req.GetUserId()),
+		zap.String("role", req.GetRole()),
+		zap.String("actor_id", callerID(ctx)),
+	)
+
+	roles, err := h.authService.GrantRole(ctx, kernel.UserID(callerID(ctx)), kernel.UserID(req.GetUserId()), req.GetRole())
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "grant role failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.String("role", req.GetRole()),
+				zap.Error(err),
+			)
+		} else {
+			logger.WarnContext(ctx, "grant role failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.String("role", req.GetRole()),
+				zap.String("error", err.Error()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	logger.InfoContext(ctx, "role granted",
+		zap.String("user_id", req.GetUserId()),
+		zap.String("role", req.GetRole()),
+		zap.String("actor_id", callerID(ctx)),
+	)
+
+	return &pb.GrantRoleResponse{
+		UserId: req.GetUserId(),
+		Roles:  rolesToStrings(roles),
+	}, nil
+}
+
+func (h *AuthHandler) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.RevokeRoleResponse, error) {
+	logger.InfoContext(ctx, "handling RevokeRole request",
+		zap.String("user_id", req.GetUserId()),
+		zap.String("role", req.GetRole()),
+		zap.String("actor_id", callerID(ctx)),
+	)
+
+	roles, err := h.authService.RevokeRole(ctx, kernel.UserID(callerID(ctx)), kernel.UserID(req.GetUserId()), req.GetRole())
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "revoke role failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.String("role", req.GetRole()),
+				zap.Error(err),
+			)
+		} else {
+			logger.WarnContext(ctx, "revoke role failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.String("role", req.GetRole()),
+				zap.String("error", err.Error()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	logger.InfoContext(ctx, "role revoked",
+		zap.String("user_id", req.GetUserId()),
+		zap.String("role", req.GetRole()),
+		zap.String("actor_id", callerID(ctx)),
+	)
+
+	return &pb.RevokeRoleResponse{
+		UserId: req.GetUserId(),
+		Roles:  rolesToStrings(roles),
+	}, nil
+}
+
+func (h *AuthHandler) DisableUser(ctx context.Context, req *pb.DisableUserRequest) (*pb.DisableUserResponse, error) {
+	logger.InfoContext(ctx, "handling DisableUser request",
+		zap.String("user_id", req.GetUserId()),
+		zap.String("actor_id", callerID(ctx)),
+	)
+
+	if err := h.authService.DisableUser(ctx, kernel.UserID(callerID(ctx)), kernel.UserID(req.GetUserId())); err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "disable user failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.Error(err),
+			)
+		} else {
+			logger.WarnContext(ctx, "disable user failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.String("error", err.Error()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	return &pb.DisableUserResponse{}, nil
+}
+
+func (h *AuthHandler) EnableUser(ctx context.Context, req *pb.EnableUserRequest) (*pb.EnableUserResponse, error) {
+	logger.InfoContext(ctx, "handling EnableUser request",
+		zap.String("user_id", req.GetUserId()),
+		zap.String("actor_id", callerID(ctx)),
+	)
+
+	if err := h.authService.EnableUser(ctx, kernel.UserID(callerID(ctx)), kernel.UserID(req.GetUserId())); err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "enable user failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.Error(err),
+			)
+		} else {
+			logger.WarnContext(ctx, "enable user failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.String("error", err.Error()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	return &pb.EnableUserResponse{}, nil
+}
+
+func (h *AuthHandler) RequestEmailVerification(ctx context.Context, req *pb.RequestEmailVerificationRequest) (*pb.RequestEmailVerificationResponse, error) {
+	logger.InfoContext(ctx, "handling RequestEmailVerification request",
+		zap.String("user_id", req.GetUserId()),
+	)
+
+	userID, err := kernel.NewUserID(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.authService.RequestEmailVerification(ctx, userID); err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "request email verification failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.Error(err),
+			)
+		} else {
+			logger.DebugContext(ctx, "request email verification failed",
+				zap.String("user_id", req.GetUserId()),
+				zap.String("error", err.Error()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	return &pb.RequestEmailVerificationResponse{}, nil
+}
+
+func (h *AuthHandler) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	logger.DebugContext(ctx, "handling VerifyEmail request")
+
+	if err := h.authService.VerifyEmail(ctx, req.GetToken()); err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "verify email failed",
+				zap.Error(err),
+			)
+		} else {
+			logger.DebugContext(ctx, "verify email failed",
+				zap.String("error", err.Error()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	return &pb.VerifyEmailResponse{}, nil
+}
+
+func (h *AuthHandler) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	logger.DebugContext(ctx, "handling RequestPasswordReset request")
+
+	if err := h.authService.RequestPasswordReset(ctx, req.GetEmail()); err != nil {
+		logger.ErrorContext(ctx, "request password reset failed",
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+func (h *AuthHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	logger.DebugContext(ctx, "handling ResetPassword request")
+
+	if err := h.authService.ResetPassword(ctx, req.GetToken(), req.GetNewPassword()); err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "reset password failed",
+				zap.Error(err),
+			)
+		} else {
+			logger.DebugContext(ctx, "reset password failed",
+				zap.String("error", err.Error()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	return &pb.ResetPasswordResponse{}, nil
+}
+
+func rolesToStrings(roles []kernel.Role) []string {
+	values := make([]string, len(roles))
+	for i, role := range roles {
+		values[i] = role.String()
+	}
+	return values
+}
@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/kernel"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata the gateway sets from the caller's verified access token. The signature proves
+// the ID and roles came from the gateway rather than from whoever opened the connection.
+const (
+	userIDMetadataKey            = "x-user-id"
+	userRolesMetadataKey         = "x-user-roles"
+	identitySignatureMetadataKey = "x-identity-signature"
+)
+
+var (
+	errIdentityNotSigned        = errors.New("caller identity is not signed")
+	errInvalidIdentitySignature = errors.New("invalid caller identity signature")
+	errIdentitySignatureExpired = errors.New("caller identity signature has expired")
+)
+
+// adminMethods can only be called by admins
+var adminMethods = map[string]bool{
+	"/auth.v1.AuthService/GrantRole":   true,
+	"/auth.v1.AuthService/RevokeRole":  true,
+	"/auth.v1.AuthService/DisableUser": true,
+	"/auth.v1.AuthService/EnableUser":  true,
+}
+
+// AuthorizationInterceptor rejects calls to admin-only methods from callers without the
+// admin role. identitySecret must match the API gateway's IDENTITY_SECRET.
+func AuthorizationInterceptor(identitySecret string) grpc.UnaryServerInterceptor {
+	secret := []byte(identitySecret)
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !adminMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		roles, err := verifiedCallerRoles(ctx, secret, time.Now())
+		if err != nil {
+			logger.WarnContext(ctx, "admin method called without a verified identity",
+				zap.String("method", info.FullMethod),
+				zap.Error(err),
+			)
+			return nil, status.Error(codes.Unauthenticated, "caller identity not verified")
+		}
+
+		if !slices.Contains(roles, kernel.RoleAdmin.String()) {
+			logger.WarnContext(ctx, "admin method called without admin role",
+				zap.String("method", info.FullMethod),
+				zap.String("caller_id", callerID(ctx)),
+			)
+			return nil, status.Error(codes.PermissionDenied, "admin role required")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// callerID returns the ID of the user making the call, empty for internal callers
+func callerID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	ids := md.Get(userIDMetadataKey)
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[0]
+}
+
+// verifiedCallerRoles returns the roles of the user making the call once the gateway's
+// "<expires_unix>.<hex hmac-sha256(user_id|roles|expires_unix)>" signature checks out
+func verifiedCallerRoles(ctx context.Context, secret []byte, now time.Time) ([]string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errIdentityNotSigned
+	}
+
+	ids := md.Get(userIDMetadataKey)
+	signatures := md.Get(identitySignatureMetadataKey)
+	if len(ids) != 1 || len(signatures) != 1 {
+		return nil, errIdentityNotSigned
+	}
+	roles := md.Get(userRolesMetadataKey)
+
+	exp, sig, ok := strings.Cut(signatures[0], ".")
+	if !ok {
+		return nil, errInvalidIdentitySignature
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s|%s|%s", ids[0], strings.Join(roles, ","), exp)
+	if !hmac.Equal([]byte(sig), []byte(hex.EncodeToString(mac.Sum(nil)))) {
+		return nil, errInvalidIdentitySignature
+	}
+
+	expUnix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return nil, errInvalidIdentitySignature
+	}
+	if now.Unix() >= expUnix {
+		return nil, errIdentitySignatureExpired
+	}
+
+	return roles, nil
+}
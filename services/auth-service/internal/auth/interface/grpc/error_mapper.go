@@ -1,16 +1,52 @@
 package grpc
 
 import (
+	"errors"
 	"strings"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/auth/model"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/auth/domain/user"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // mapDomainErrorToGRPC maps domain errors to gRPC status codes
 func mapDomainErrorToGRPC(err error) error {
 	msg := err.Error()
 
+	// Role management
+	if errors.Is(err, user.ErrRoleNotGrantable) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, user.ErrCannotRevokeOwnAdmin) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	// Login throttling, with the wait in a RetryInfo detail
+	var blocked *model.LoginBlockedError
+	if errors.As(err, &blocked) {
+		st := status.New(codes.ResourceExhausted, err.Error())
+		if detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(blocked.RetryAfter),
+		}); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+
+	// Account lifecycle
+	if errors.Is(err, user.ErrUserDisabled) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, user.ErrCannotDisableSelf) || errors.Is(err, user.ErrEmailVerified) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, model.ErrInvalidOneTimeToken) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// User already exists
 	if strings.Contains(msg, "already exists") {
 		return status.Error(codes.AlreadyExists, "user already exists")
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/auth-service/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+)
+
+// namespace prefixes every metric of the system, e.g. auction_grpc_server_requests_total
+const namespace = "auction"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "requests_total",
+		Help:      "gRPC requests handled, by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC request latency, by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// Init registers the runtime and service collectors, labelled with the service name
+func Init(serviceName string) {
+	prometheus.WrapRegistererWith(prometheus.Labels{"service": serviceName}, registry).MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		grpcRequests,
+		grpcDuration,
+	)
+}
+
+// NewServer creates the HTTP server exposing /metrics
+func NewServer(cfg config.MetricsConfig) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	return &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+// ObserveGRPCRequest records a handled gRPC request
+func ObserveGRPCRequest(method string, code codes.Code, latency time.Duration) {
+	grpcRequests.WithLabelValues(method, code.String()).Inc()
+	grpcDuration.WithLabelValues(method).Observe(latency.Seconds())
+}
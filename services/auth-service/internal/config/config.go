@@ -11,7 +11,14 @@ type Config struct {
 	Redis    RedisConfig
 	JWT      JWTConfig
 	Bcrypt   BcryptConfig
+	Roles    RolesConfig
+	Session  SessionConfig
+	Mail     MailConfig
+	Login    LoginThrottleConfig
+	Kafka    KafkaConfig
 	Logger   LoggerConfig
+	Tracing  TracingConfig
+	Metrics  MetricsConfig
 }
 
 type DatabaseConfig struct {
@@ -23,6 +30,9 @@ type DatabaseConfig struct {
 
 type GRPCConfig struct {
 	Port string
+	// IdentitySecret verifies the caller identity the API gateway forwards; the gateway must
+	// sign with the same secret
+	IdentitySecret string
 }
 
 type RedisConfig struct {
@@ -32,12 +42,17 @@ type RedisConfig struct {
 }
 
 type JWTConfig struct {
-	AccessSecretKey  string
 	RefreshSecretKey string
+	// KeyEncryptionKey encrypts the signing private keys shared through Redis
+	KeyEncryptionKey string
 	AccessTTL        int // in seconds
 	RefreshTTL       int // in seconds
 	Issuer           string
 	Audience         string
+
+	SigningAlgorithm    string // access token signing: EdDSA or RS256
+	KeyRotationInterval int    // in seconds, how long a signing key signs before a new one replaces it
+	KeyCheckInterval    int    // in seconds, how often replicas reload the keys and check for rotation
 }
 
 type BcryptConfig struct {
@@ -50,7 +65,8 @@ func Load() *Config {
 		Env:         getEnv("ENV", "local"),
 
 		GRPC: GRPCConfig{
-			Port: getEnv("GRPC_PORT", "50051"),
+			Port:           getEnv("GRPC_PORT", "50051"),
+			IdentitySecret: mustEnv("IDENTITY_SECRET"),
 		},
 
 		Database: DatabaseConfig{
@@ -61,12 +77,16 @@ func Load() *Config {
 		},
 
 		JWT: JWTConfig{
-			AccessSecretKey:  mustEnv("JWT_ACCESS_SECRET_KEY"),
 			RefreshSecretKey: mustEnv("JWT_REFRESH_SECRET_KEY"),
+			KeyEncryptionKey: mustEnv("JWT_KEY_ENCRYPTION_KEY"),
 			AccessTTL:        getEnvAsInt("JWT_ACCESS_TTL", 15*60),    // default 15 minutes
 			RefreshTTL:       getEnvAsInt("JWT_REFRESH_TTL", 1440*60), // default 1440 minutes (1 day)
 			Issuer:           getEnv("JWT_ISSUER", "auth-service"),
 			Audience:         getEnv("JWT_AUDIENCE", "auth-clients"),
+
+			SigningAlgorithm:    getEnv("JWT_SIGNING_ALGORITHM", "EdDSA"),
+			KeyRotationInterval: getEnvAsInt("JWT_KEY_ROTATION_INTERVAL", 7*24*60*60), // default 7 days
+			KeyCheckInterval:    getEnvAsInt("JWT_KEY_CHECK_INTERVAL", 60),
 		},
 
 		Redis: RedisConfig{
@@ -79,6 +99,13 @@ func Load() *Config {
 			Cost: getEnvAsInt("BCRYPT_COST", 10),
 		},
 
-		Logger: loadLoggerConfig(),
+		Roles:   loadRolesConfig(),
+		Session: loadSessionConfig(),
+		Mail:    loadMailConfig(),
+		Login:   loadLoginThrottleConfig(),
+		Kafka:   loadKafkaConfig(),
+		Logger:  loadLoggerConfig(),
+		Tracing: loadTracingConfig(),
+		Metrics: loadMetricsConfig(),
 	}
 }
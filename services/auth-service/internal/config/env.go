@@ -30,3 +30,14 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		var floatValue float64
+		_, err := fmt.Sscanf(value, "%g", &floatValue)
+		if err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// KafkaConfig holds Kafka configuration
+type KafkaConfig struct {
+	Brokers              []string
+	AuditTopic           string // security events, read by the audit logger
+	ProducerMaxAttempts  int
+	ProducerBatchTimeout time.Duration
+}
+
+func loadKafkaConfig() KafkaConfig {
+	return KafkaConfig{
+		Brokers:              strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+		AuditTopic:           getEnv("KAFKA_AUDIT_TOPIC", "audit-logs"),
+		ProducerMaxAttempts:  getEnvAsInt("KAFKA_PRODUCER_MAX_ATTEMPTS", 3),
+		ProducerBatchTimeout: time.Duration(getEnvAsInt("KAFKA_PRODUCER_BATCH_TIMEOUT_MS", 10)) * time.Millisecond,
+	}
+}
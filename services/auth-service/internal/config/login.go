@@ -0,0 +1,22 @@
+package config
+
+// LoginThrottleConfig holds failed login throttling configuration
+type LoginThrottleConfig struct {
+	MaxAccountFailures int // failures that lock an account; 0 disables account lockout
+	MaxIPFailures      int // failures that lock a source IP; 0 disables IP lockout
+	FailureWindow      int // in seconds, failures older than this are forgotten
+	LockoutDuration    int // in seconds
+	BaseDelay          int // in milliseconds, wait after the first failure, doubled by each further one
+	MaxDelay           int // in milliseconds
+}
+
+func loadLoginThrottleConfig() LoginThrottleConfig {
+	return LoginThrottleConfig{
+		MaxAccountFailures: getEnvAsInt("LOGIN_MAX_ACCOUNT_FAILURES", 5),
+		MaxIPFailures:      getEnvAsInt("LOGIN_MAX_IP_FAILURES", 20),
+		FailureWindow:      getEnvAsInt("LOGIN_FAILURE_WINDOW", 15*60),   // default 15 minutes
+		LockoutDuration:    getEnvAsInt("LOGIN_LOCKOUT_DURATION", 15*60), // default 15 minutes
+		BaseDelay:          getEnvAsInt("LOGIN_BASE_DELAY_MS", 1000),
+		MaxDelay:           getEnvAsInt("LOGIN_MAX_DELAY_MS", 30*1000),
+	}
+}
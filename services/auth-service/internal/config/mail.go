@@ -0,0 +1,20 @@
+package config
+
+// MailConfig holds outgoing email configuration
+type MailConfig struct {
+	Driver   string // log writes emails to the service log, file appends them to FilePath
+	FilePath string
+	From     string
+
+	// AppBaseURL is the frontend URL that email links point to
+	AppBaseURL string
+}
+
+func loadMailConfig() MailConfig {
+	return MailConfig{
+		Driver:     getEnv("MAIL_DRIVER", "log"),
+		FilePath:   getEnv("MAIL_FILE_PATH", "mail.log"),
+		From:       getEnv("MAIL_FROM", "no-reply@auction.local"),
+		AppBaseURL: getEnv("APP_BASE_URL", "http://localhost:3000"),
+	}
+}
@@ -0,0 +1,12 @@
+package config
+
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	Port string // HTTP port serving /metrics
+}
+
+func loadMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		Port: getEnv("METRICS_PORT", "9101"),
+	}
+}
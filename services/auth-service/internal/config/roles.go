@@ -0,0 +1,23 @@
+package config
+
+import "strings"
+
+// RolesConfig holds role assignment configuration
+type RolesConfig struct {
+	// BootstrapAdminEmails are granted the admin role when they verify their email, so a fresh
+	// deployment has an admin to grant every other role
+	BootstrapAdminEmails []string
+}
+
+func loadRolesConfig() RolesConfig {
+	var emails []string
+	for _, email := range strings.Split(getEnv("ADMIN_BOOTSTRAP_EMAILS", ""), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, strings.ToLower(email))
+		}
+	}
+
+	return RolesConfig{
+		BootstrapAdminEmails: emails,
+	}
+}
@@ -0,0 +1,12 @@
+package config
+
+// SessionConfig holds login session configuration
+type SessionConfig struct {
+	MaxPerUser int // logging in beyond this ends the user's least recently used session; 0 disables the limit
+}
+
+func loadSessionConfig() SessionConfig {
+	return SessionConfig{
+		MaxPerUser: getEnvAsInt("SESSION_MAX_PER_USER", 5),
+	}
+}
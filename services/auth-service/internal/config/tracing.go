@@ -0,0 +1,16 @@
+package config
+
+// TracingConfig holds tracing configuration
+type TracingConfig struct {
+	Exporter    string  // "none", "stdout", "file" or a registered exporter
+	FilePath    string  // output of the "file" exporter
+	SampleRatio float64 // share of new traces that are sampled
+}
+
+func loadTracingConfig() TracingConfig {
+	return TracingConfig{
+		Exporter:    getEnv("TRACING_EXPORTER", "none"),
+		FilePath:    getEnv("TRACING_FILE_PATH", "traces.jsonl"),
+		SampleRatio: getEnvAsFloat("TRACING_SAMPLE_RATIO", 1.0),
+	}
+}
@@ -3,4 +3,5 @@ package kernel
 type IDGenerator interface {
 	NewUserID() UserID
 	NewTokenID() TokenID
+	NewSessionID() SessionID
 }
@@ -2,16 +2,19 @@ package kernel
 
 import "errors"
 
+// Role is what a user is allowed to do. Every user is a buyer; seller and admin are granted
+// by an admin.
 type Role string
 
 const (
-	RoleAdmin Role = "admin"
-	RoleUser  Role = "user"
+	RoleBuyer  Role = "buyer"
+	RoleSeller Role = "seller"
+	RoleAdmin  Role = "admin"
 )
 
 func NewRole(raw string) (Role, error) {
 	switch raw {
-	case string(RoleAdmin), string(RoleUser):
+	case string(RoleBuyer), string(RoleSeller), string(RoleAdmin):
 		return Role(raw), nil
 	default:
 		return "", errors.New("invalid role")
@@ -0,0 +1,18 @@
+package kernel
+
+import "errors"
+
+type SessionID string
+
+var ErrSessionIDCannotBeEmpty = errors.New("session ID cannot be empty")
+
+func NewSessionID(raw string) (SessionID, error) {
+	if raw == "" {
+		return "", ErrSessionIDCannotBeEmpty
+	}
+	return SessionID(raw), nil
+}
+
+func (s SessionID) String() string {
+	return string(s)
+}
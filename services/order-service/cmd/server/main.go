@@ -2,21 +2,26 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/application/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/application/worker"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/config"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/order"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/messaging/kafka"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/payment"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/persistence/postgres"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/persistence/redis"
-	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/interface/grpc"
 	grpcserver "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/interface/grpc"
-	productv1pb "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/product/v1"
 
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
@@ -34,7 +39,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	// 2. Initialize core logging
+	// 2. Initialize core logging, tracing and metrics
 	log := logger.Init(&cfg.Logger)
 	defer log.Sync()
 
@@ -42,6 +47,14 @@ func main() {
 		zap.String("env", cfg.Logger.Environment),
 	)
 
+	shutdownTracing, err := tracing.Init(&cfg.Tracing, "order-service")
+	if err != nil {
+		zap.L().Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	metrics.Init("order-service")
+	metricsServer := metrics.NewServer(&cfg.Metrics)
+
 	// 3. Initialize Infrastructure
 	db := postgres.MustConnect(cfg.Database)
 	defer db.Close()
@@ -49,21 +62,17 @@ func main() {
 	redisClient := redis.MustConnect(cfg.Redis)
 	defer redisClient.Close()
 
-	productClientConn := grpc.MustConnProductClient(cfg.GRPC)
-	defer productClientConn.Close()
-
-	pbProductClient := productv1pb.NewProductServiceClient(productClientConn)
-	productGRPCClient := grpc.NewProductClient(pbProductClient)
-
 	// 4. Initialize Repositories & Managers
 	txManager := postgres.NewTxManager(db)
 	orderRepo := postgres.NewOrderRepository(db)
 	outboxRepo := postgres.NewOutboxRepository(db)
 	productPriceRepo := postgres.NewProductPriceRepository(db)
 	timeoutQueue := redis.NewTimeoutQueue(redisClient)
+	paymentProvider := payment.NewMockProvider(cfg.Payment.CallbackSecret)
+	quoteVerifier := order.NewQuoteVerifier(cfg.PriceQuote.Secret, cfg.PriceQuote.ExpiryGrace)
 
 	// 5. Initialize Application Services
-	orderAppService := service.NewOrderAppService(txManager, timeoutQueue, orderRepo, productPriceRepo, productGRPCClient)
+	orderAppService := service.NewOrderAppService(txManager, timeoutQueue, orderRepo, paymentProvider, quoteVerifier)
 	productAppService := service.NewProductAppService(productPriceRepo)
 
 	// 6. Initialize Workers & Messaging
@@ -75,8 +84,7 @@ func main() {
 	outboxRelayWorker := worker.NewOutboxRelayWorker(
 		outboxRepo,
 		producer,
-		cfg.Outbox.Interval,
-		cfg.Outbox.BatchSize,
+		&cfg.Outbox,
 	)
 
 	// Order Timeout Worker (Scans Redis for expired orders)
@@ -86,23 +94,26 @@ func main() {
 		&cfg.OrderTimeoutWorker,
 	)
 
+	// Dead-letter queue shared by both consumers
+	deadLetterQueue := kafka.NewDeadLetterQueue(&cfg.Kafka)
+	defer deadLetterQueue.Close()
+
 	// Kafka Consumer (Listens to Stock Service reservations)
 	reservationHandler := kafka.NewReservationEventHandler(orderAppService)
-	kafkaConsumer := kafka.NewConsumer(&cfg.Kafka, reservationHandler)
+	kafkaConsumer := kafka.NewConsumer(&cfg.Kafka, reservationHandler, deadLetterQueue)
 	defer kafkaConsumer.Close()
 
 	productEventHandler := kafka.NewProductEventHandler(productAppService)
-	productKafkaConfig := &config.KafkaConfig{
-		Brokers:         cfg.Kafka.Brokers,
-		ConsumerTopic:   "product-events", // The topic where Product Service sends updates
-		ConsumerGroupID: "order-service-product-sync",
-	}
-	productConsumer := kafka.NewConsumer(productKafkaConfig, productEventHandler)
+	productKafkaConfig := cfg.Kafka                     // Inherit brokers, tuning and retry policy
+	productKafkaConfig.ConsumerTopic = "product-events" // The topic where Product Service sends updates
+	productKafkaConfig.ConsumerGroupID = "order-service-product-sync"
+	productConsumer := kafka.NewConsumer(&productKafkaConfig, productEventHandler, deadLetterQueue)
 	defer productConsumer.Close()
 
 	// 7. Initialize gRPC Server
 	grpcHandler := grpcserver.NewOrderHandler(orderAppService)
-	grpcServer := grpcserver.NewServer(&cfg.GRPC, grpcHandler)
+	deadLetterHandler := grpcserver.NewDeadLetterHandler(deadLetterQueue)
+	grpcServer := grpcserver.NewServer(&cfg.GRPC, grpcHandler, deadLetterHandler)
 
 	// 8. Lifecycle Management
 	ctx, cancel := context.WithCancel(context.Background())
@@ -136,6 +147,14 @@ func main() {
 		}
 	}()
 
+	// Start Metrics Server
+	go func() {
+		zap.L().Info("metrics server listening", zap.Int("port", cfg.Metrics.Port))
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Error("metrics server failed", zap.Error(err))
+		}
+	}()
+
 	// Start gRPC Server
 	go func() {
 		zap.L().Info("grpc server listening", zap.Int("port", cfg.GRPC.Server.Port))
@@ -158,5 +177,19 @@ func main() {
 	// Stop gRPC server
 	grpcServer.GracefulStop()
 
+	// Stop metrics server
+	metricsCtx, metricsCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer metricsCancel()
+	if err := metricsServer.Shutdown(metricsCtx); err != nil {
+		zap.L().Warn("failed to stop metrics server", zap.Error(err))
+	}
+
+	// Flush buffered spans before exit
+	tracingCtx, tracingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer tracingCancel()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		zap.L().Warn("failed to flush traces", zap.Error(err))
+	}
+
 	zap.L().Info("order service stopped cleanly")
 }
@@ -3,9 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/order"
-	productprice "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/product_price"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/persistence/postgres"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/persistence/redis"
 )
@@ -14,24 +14,24 @@ type OrderAppService struct {
 	txManager    *postgres.TxManager
 	timeoutQueue *redis.TimeoutQueue
 	// Use a read-only repository for queries outside transactions
-	orderRepo          order.Repository
-	productPriceRepo   productprice.Repository
-	productPriceClient productprice.ProductClient
+	orderRepo       order.Repository
+	paymentProvider order.PaymentProvider
+	quoteVerifier   *order.QuoteVerifier
 }
 
 func NewOrderAppService(
 	tm *postgres.TxManager,
 	tq *redis.TimeoutQueue,
 	orderRepo order.Repository,
-	productPriceRepo productprice.Repository,
-	productPriceClient productprice.ProductClient,
+	paymentProvider order.PaymentProvider,
+	quoteVerifier *order.QuoteVerifier,
 ) *OrderAppService {
 	return &OrderAppService{
-		txManager:          tm,
-		timeoutQueue:       tq,
-		orderRepo:          orderRepo,
-		productPriceRepo:   productPriceRepo,
-		productPriceClient: productPriceClient,
+		txManager:       tm,
+		timeoutQueue:    tq,
+		orderRepo:       orderRepo,
+		paymentProvider: paymentProvider,
+		quoteVerifier:   quoteVerifier,
 	}
 }
 
@@ -106,7 +106,7 @@ func (s *OrderAppService) CancelExpiredOrder(ctx context.Context, orderIDStr str
 	// Execute within a transaction to ensure Outbox consistency
 	return s.txManager.Execute(ctx, func(p postgres.RepositoryProvider) error {
 		// 1. Fetch aggregate using the transactional repository
-		o, err := p.Orders().FindByID(ctx, orderID)
+		o, err := p.Orders().FindByIDForUpdate(ctx, orderID)
 		if err != nil {
 			return err
 		}
@@ -131,6 +131,63 @@ func (s *OrderAppService) CancelExpiredOrder(ctx context.Context, orderIDStr str
 	})
 }
 
+// CancelOrder cancels a pending order on behalf of its owner.
+// order.cancelled is staged in the same transaction, and Stock Service returns the
+// reserved stock when it consumes the event.
+func (s *OrderAppService) CancelOrder(
+	ctx context.Context,
+	orderIDStr, userIDStr, reason string,
+) (*order.Order, error) {
+	orderID, err := order.ParseOrderID(orderIDStr)
+	if err != nil {
+		return nil, err
+	}
+	uID, err := order.ParseUserID(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason == "" {
+		reason = "cancelled by user"
+	}
+
+	var o *order.Order
+	err = s.txManager.Execute(ctx, func(p postgres.RepositoryProvider) error {
+		var err error
+		o, err = p.Orders().FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if !o.IsOwnedBy(uID) {
+			return order.ErrOrderNotOwned
+		}
+
+		if err := o.Cancel(reason); err != nil {
+			return err
+		}
+
+		if err := p.Orders().Save(ctx, o); err != nil {
+			return err
+		}
+
+		for _, event := range o.DomainEvents() {
+			if err := p.Outbox().SaveEvent(ctx, o.ID().String(), event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The timeout worker skips non-pending orders, so a failed removal is harmless
+	_ = s.timeoutQueue.Remove(ctx, o.ID())
+
+	return o, nil
+}
+
 // GetOrder performs a simple read operation
 func (s *OrderAppService) GetOrder(ctx context.Context, orderIDStr string) (*order.Order, error) {
 	orderID, err := order.ParseOrderID(orderIDStr)
@@ -141,26 +198,24 @@ func (s *OrderAppService) GetOrder(ctx context.Context, orderIDStr string) (*ord
 	return s.orderRepo.FindByID(ctx, orderID)
 }
 
-// CreateOrderFromReservation implements kafka.OrderCreator interface
+// CreateOrderFromReservation implements kafka.OrderCreator interface.
+// The order is created at exactly the quoted price. Every reservation carries a quote signed
+// by stock-service, so a missing, tampered or expired quote is rejected.
 func (s *OrderAppService) CreateOrderFromReservation(
 	ctx context.Context,
 	reservationID, userID, productID string,
 	quantity int,
+	quote *order.PriceQuote,
 ) error {
-	priceInfo, err := s.productPriceRepo.GetByID(ctx, productID)
-	// 2. Fallback: If not found locally, fetch via gRPC from Product Service
-	if err != nil {
-		// Use a gRPC client (which you'll need to inject into this service)
-		priceInfo, err = s.productPriceClient.FetchProductDetail(ctx, productID)
-		if err != nil {
-			return fmt.Errorf("failed to fetch price from product service after local miss: %w", err)
-		}
+	if quote == nil {
+		return fmt.Errorf("reservation %s has no price quote: %w", reservationID, order.ErrInvalidPriceQuote)
+	}
 
-		// 3. (Optional but recommended) Update local cache for next time
-		_ = s.productPriceRepo.Upsert(ctx, priceInfo)
+	if err := s.quoteVerifier.Verify(quote, reservationID, productID, userID, quantity, time.Now()); err != nil {
+		return fmt.Errorf("price quote %s for reservation %s rejected: %w", quote.ID, reservationID, err)
 	}
 
-	_, err = s.CreateOrder(ctx, reservationID, userID, productID, quantity, priceInfo.UnitPrice, priceInfo.Currency)
+	_, err := s.CreateOrder(ctx, reservationID, userID, productID, quantity, quote.Amount, quote.Currency)
 	if err != nil {
 		return fmt.Errorf("failed to create order for reservation %s: %w", reservationID, err)
 	}
@@ -168,6 +223,23 @@ func (s *OrderAppService) CreateOrderFromReservation(
 	return nil
 }
 
+// CreateOrderFromAuction creates an order for an auction winner.
+// The unit price is the winning bid, not the product's listed price.
+func (s *OrderAppService) CreateOrderFromAuction(
+	ctx context.Context,
+	reservationID, userID, productID string,
+	quantity int,
+	unitPrice int64,
+	currency string,
+) error {
+	_, err := s.CreateOrder(ctx, reservationID, userID, productID, quantity, unitPrice, currency)
+	if err != nil {
+		return fmt.Errorf("failed to create order for auction reservation %s: %w", reservationID, err)
+	}
+
+	return nil
+}
+
 // ListUserOrders retrieves a paginated list of orders for a specific user.
 // This is a read-only operation and doesn't require a transaction.
 func (s *OrderAppService) ListUserOrders(
@@ -189,3 +261,180 @@ func (s *OrderAppService) ListUserOrders(
 
 	return orders, nil
 }
+
+// PayOrder charges the buyer for a pending order through the payment provider.
+// A completed charge marks the order PAID and stages order.paid in the outbox;
+// an asynchronous charge leaves the payment PENDING until the provider calls back.
+func (s *OrderAppService) PayOrder(
+	ctx context.Context,
+	orderIDStr, userIDStr, methodStr string,
+) (*order.Order, error) {
+	orderID, err := order.ParseOrderID(orderIDStr)
+	if err != nil {
+		return nil, err
+	}
+	uID, err := order.ParseUserID(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	method, err := order.ParsePaymentMethod(methodStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Open a payment attempt so that provider callbacks can reference it
+	var payment *order.Payment
+	err = s.txManager.Execute(ctx, func(p postgres.RepositoryProvider) error {
+		o, err := p.Orders().FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if !o.IsOwnedBy(uID) {
+			return order.ErrOrderNotOwned
+		}
+
+		payment, err = o.StartPayment(method)
+		if err != nil {
+			return err
+		}
+
+		return p.Orders().Save(ctx, o)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Charge outside the transaction so the provider round-trip holds no DB resources
+	result, err := s.paymentProvider.Charge(ctx, order.ChargeRequest{
+		PaymentID: payment.ID(),
+		OrderID:   orderID,
+		Amount:    payment.Amount(),
+		Method:    payment.Method(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", order.ErrPaymentFailed, err)
+	}
+
+	// 3. Apply the outcome to the aggregate
+	o, err := s.applyPaymentResult(ctx, orderID, payment.ID(), result.TransactionID, result.Status, result.FailureReason)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Status == order.PaymentStatusFailed {
+		return nil, fmt.Errorf("%w: %s", order.ErrPaymentFailed, result.FailureReason)
+	}
+	if o.Payment() != nil && o.Payment().IsRefunded() {
+		return nil, fmt.Errorf("%w: order closed while the charge was in flight, the charge was refunded", order.ErrInvalidOrderStatus)
+	}
+
+	return o, nil
+}
+
+// HandlePaymentCallback applies an asynchronous settlement reported by the payment provider.
+// Callbacks are idempotent: repeating an already applied outcome is a no-op.
+func (s *OrderAppService) HandlePaymentCallback(ctx context.Context, callback order.PaymentCallback) (*order.Order, error) {
+	if err := s.paymentProvider.VerifyCallback(ctx, callback); err != nil {
+		return nil, err
+	}
+
+	orderID, err := order.ParseOrderID(callback.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	paymentID, err := order.ParsePaymentID(callback.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyPaymentResult(ctx, orderID, paymentID, callback.TransactionID, callback.Status, callback.FailureReason)
+}
+
+// applyPaymentResult transitions the order according to a provider outcome and
+// stages the resulting domain events atomically. A charge completed after the order was
+// cancelled or expired is refunded instead of being dropped.
+func (s *OrderAppService) applyPaymentResult(
+	ctx context.Context,
+	orderID order.OrderID,
+	paymentID order.PaymentID,
+	transactionID string,
+	paymentStatus order.PaymentStatus,
+	failureReason string,
+) (*order.Order, error) {
+	var o *order.Order
+	err := s.txManager.Execute(ctx, func(p postgres.RepositoryProvider) error {
+		var err error
+		o, err = p.Orders().FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		current := o.Payment()
+		if current == nil {
+			return order.ErrPaymentNotFound
+		}
+		if current.ID().String() != paymentID.String() {
+			return order.ErrPaymentMismatch
+		}
+
+		switch paymentStatus {
+		case order.PaymentStatusCompleted:
+			if current.IsCompleted() || current.IsRefunded() {
+				return nil // Duplicate notification
+			}
+			if err := o.ValidateCanPay(); err != nil {
+				// The order was cancelled or expired while the charge was in flight, so the
+				// captured money goes back to the buyer. Refunding under the row lock keeps a
+				// concurrent retry from refunding twice; providers treat repeats as no-ops.
+				if err := s.paymentProvider.Refund(ctx, order.RefundRequest{
+					PaymentID:     current.ID(),
+					OrderID:       o.ID(),
+					TransactionID: transactionID,
+					Amount:        current.Amount(),
+				}); err != nil {
+					return fmt.Errorf("failed to refund charge captured after order closed: %w", err)
+				}
+				if err := o.RecordPaymentRefund(transactionID); err != nil {
+					return err
+				}
+				break
+			}
+			if err := o.ProcessPayment(current.Method(), transactionID); err != nil {
+				return err
+			}
+		case order.PaymentStatusFailed:
+			if current.IsFailed() || current.IsRefunded() {
+				return nil // Duplicate notification
+			}
+			if err := o.RecordPaymentFailure(failureReason); err != nil {
+				return err
+			}
+		default:
+			// Still pending at the provider, nothing to apply yet
+			return nil
+		}
+
+		if err := p.Orders().Save(ctx, o); err != nil {
+			return err
+		}
+
+		for _, event := range o.DomainEvents() {
+			if err := p.Outbox().SaveEvent(ctx, o.ID().String(), event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A paid order must no longer be picked up by the timeout worker.
+	// Failure here is tolerated: the worker skips orders that are no longer pending.
+	if o.Status() == order.OrderStatusPaid {
+		_ = s.timeoutQueue.Remove(ctx, o.ID())
+	}
+
+	return o, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	productprice "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/product_price"
 )
@@ -16,8 +17,8 @@ func NewProductAppService(repo productprice.Repository) *ProductAppService {
 	return &ProductAppService{priceRepo: repo}
 }
 
-// SyncProductPrice encapsulate
-func (s *ProductAppService) SyncProductPrice(ctx context.Context, productID string, price int64, currency string) error {
-	p := productprice.NewProductPrice(productID, price, currency)
-	return s.priceRepo.Upsert(ctx, p)
+// SyncProductPrice records a price change in the local price history
+func (s *ProductAppService) SyncProductPrice(ctx context.Context, productID string, price int64, currency string, effectiveFrom time.Time) error {
+	p := productprice.NewProductPrice(productID, price, currency, effectiveFrom)
+	return s.priceRepo.Record(ctx, p)
 }
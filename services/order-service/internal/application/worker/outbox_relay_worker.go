@@ -3,58 +3,92 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
 	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/tracing"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/messaging/kafka"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/persistence/postgres"
+	"github.com/samborkent/uuidv7"
+	"go.uber.org/zap"
 )
 
+// backlogSampleInterval is how often the relay samples the outbox backlog for metrics
+const backlogSampleInterval = 15 * time.Second
+
+// OutboxRelayWorker publishes outbox events to Kafka.
+// Every replica runs one; batches are claimed under relayID so each event is published once.
 type OutboxRelayWorker struct {
-	repo      *postgres.OutboxRepository
-	producer  *kafka.Producer
-	interval  time.Duration
-	batchSize int
+	repo     *postgres.OutboxRepository
+	producer *kafka.Producer
+	cfg      *config.OutboxConfig
+	relayID  string
 }
 
 func NewOutboxRelayWorker(
 	repo *postgres.OutboxRepository,
 	producer *kafka.Producer,
-	interval time.Duration,
-	batchSize int,
+	cfg *config.OutboxConfig,
 ) *OutboxRelayWorker {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
 	return &OutboxRelayWorker{
-		repo:      repo,
-		producer:  producer,
-		interval:  interval,
-		batchSize: batchSize,
+		repo:     repo,
+		producer: producer,
+		cfg:      cfg,
+		relayID:  fmt.Sprintf("%s-%s", hostname, uuidv7.New().String()),
 	}
 }
 
 func (w *OutboxRelayWorker) Start(ctx context.Context) error {
-	ticker := time.NewTicker(w.interval)
+	ticker := time.NewTicker(w.cfg.Interval)
 	defer ticker.Stop()
 
+	backlogTicker := time.NewTicker(backlogSampleInterval)
+	defer backlogTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
+			w.releaseClaims()
 			return nil
 		case <-ticker.C:
 			w.processEvents(ctx)
+		case <-backlogTicker.C:
+			w.sampleBacklog(ctx)
 		}
 	}
 }
 
 func (w *OutboxRelayWorker) processEvents(ctx context.Context) {
-	// 1. Fetch pending events from Postgres
-	records, err := w.repo.FetchPending(ctx, w.batchSize)
+	leaseExpiresAt := time.Now().Add(w.cfg.ClaimLease)
+
+	// 1. Claim due events from Postgres
+	records, err := w.repo.ClaimPending(ctx, w.relayID, w.cfg.ClaimLease, w.cfg.BatchSize)
 	if err != nil {
+		zap.L().Error("failed to claim outbox events", zap.Error(err))
 		return
 	}
 
+	// A batch holds at most one event per order, so retrying one later never lets another
+	// event of the same order overtake it
 	for _, rec := range records {
+		// Past the lease another relay may own the remaining records
+		if time.Now().After(leaseExpiresAt) {
+			return
+		}
+
 		// 2. Map raw database record to Kafka-specific envelope
 		var data map[string]interface{}
 		if err := json.Unmarshal(rec.Payload, &data); err != nil {
+			w.retryLater(ctx, rec, fmt.Errorf("invalid payload: %w", err))
 			continue
 		}
 
@@ -67,12 +101,77 @@ func (w *OutboxRelayWorker) processEvents(ctx context.Context) {
 			Data:          data,
 		}
 
-		// 3. Publish via the messaging infrastructure
-		if err := w.producer.Publish(ctx, msg); err != nil {
-			return // Stop current batch on network failure
+		// 3. Publish via the messaging infrastructure, in the trace that wrote the event
+		err := w.producer.Publish(w.traceContext(ctx, rec), msg)
+		metrics.ObserveOutboxPublish(rec.OccurredAt, err)
+		if err != nil {
+			w.retryLater(ctx, rec, err)
+			continue
 		}
 
 		// 4. Finalize state in DB
-		_ = w.repo.MarkAsPublished(ctx, rec.ID)
+		if err := w.repo.MarkAsPublished(ctx, rec.ID, w.relayID); err != nil {
+			if errors.Is(err, postgres.ErrOutboxClaimLost) {
+				zap.L().Warn("outbox claim lost after publishing, event may be published twice", zap.String("event_id", rec.ID))
+				continue
+			}
+			zap.L().Error("failed to mark outbox event as published", zap.String("event_id", rec.ID), zap.Error(err))
+		}
+	}
+}
+
+// sampleBacklog records how many events are waiting to be relayed and for how long
+func (w *OutboxRelayWorker) sampleBacklog(ctx context.Context) {
+	count, oldestAge, err := w.repo.Backlog(ctx)
+	if err != nil {
+		zap.L().Warn("failed to sample outbox backlog", zap.Error(err))
+		return
+	}
+	metrics.SetOutboxBacklog(count, oldestAge)
+}
+
+// traceContext restores the trace stored with the record; a broken one only costs the trace link
+func (w *OutboxRelayWorker) traceContext(ctx context.Context, rec *postgres.OutboxRecord) context.Context {
+	if len(rec.TraceContext) == 0 {
+		return ctx
+	}
+
+	var carrier map[string]string
+	if err := json.Unmarshal(rec.TraceContext, &carrier); err != nil {
+		zap.L().Warn("invalid outbox trace context", zap.String("event_id", rec.ID), zap.Error(err))
+		return ctx
+	}
+	return tracing.Extract(ctx, carrier)
+}
+
+// retryLater schedules the record for another attempt with backoff
+func (w *OutboxRelayWorker) retryLater(ctx context.Context, rec *postgres.OutboxRecord, cause error) {
+	zap.L().Error("failed to relay outbox event",
+		zap.String("event_id", rec.ID),
+		zap.String("event_type", rec.EventType),
+		zap.Int("retry_count", rec.RetryCount),
+		zap.Error(cause),
+	)
+
+	if err := w.repo.MarkForRetry(
+		ctx,
+		rec.ID,
+		w.relayID,
+		cause.Error(),
+		w.cfg.RetryBaseDelay,
+		w.cfg.RetryMaxDelay,
+		w.cfg.MaxRetries,
+	); err != nil {
+		zap.L().Error("failed to schedule outbox retry", zap.String("event_id", rec.ID), zap.Error(err))
+	}
+}
+
+// releaseClaims hands unfinished claims back on shutdown so other relays don't wait for the lease
+func (w *OutboxRelayWorker) releaseClaims() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := w.repo.ReleaseClaims(ctx, w.relayID); err != nil {
+		zap.L().Warn("failed to release outbox claims", zap.String("relay_id", w.relayID), zap.Error(err))
 	}
 }
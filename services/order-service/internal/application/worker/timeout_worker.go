@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/metrics"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/persistence/redis"
 	"go.uber.org/zap"
@@ -69,6 +70,8 @@ func (w *OrderTimeoutWorker) processExpiredOrders(ctx context.Context) error {
 		zap.Time("now", now),
 	)
 
+	w.sampleQueueSize(ctx)
+
 	// Get expired order IDs from Redis sorted set
 	orderIDs, err := w.timeoutQueue.GetExpired(ctx, now)
 	if err != nil {
@@ -121,6 +124,8 @@ func (w *OrderTimeoutWorker) processExpiredOrders(ctx context.Context) error {
 		}
 	}
 
+	metrics.RecordExpiredOrders(successCount, failCount)
+
 	zap.L().Info("expired orders processed",
 		zap.Int("success", successCount),
 		zap.Int("failed", failCount),
@@ -129,3 +134,13 @@ func (w *OrderTimeoutWorker) processExpiredOrders(ctx context.Context) error {
 
 	return nil
 }
+
+// sampleQueueSize records how many orders are waiting in the timeout queue
+func (w *OrderTimeoutWorker) sampleQueueSize(ctx context.Context) {
+	count, err := w.timeoutQueue.Count(ctx)
+	if err != nil {
+		zap.L().Warn("failed to sample timeout queue size", zap.Error(err))
+		return
+	}
+	metrics.SetTimeoutQueueSize(count)
+}
@@ -12,8 +12,12 @@ type Config struct {
 	Kafka              KafkaConfig
 	GRPC               GRPCConfig
 	Logger             LoggerConfig
+	Tracing            TracingConfig
+	Metrics            MetricsConfig
 	Outbox             OutboxConfig
 	OrderTimeoutWorker OrderTimeoutWorkerConfig
+	Payment            PaymentConfig
+	PriceQuote         PriceQuoteConfig
 }
 
 func Load() (*Config, error) {
@@ -23,8 +27,12 @@ func Load() (*Config, error) {
 		Kafka:              loadKafkaConfig(),
 		GRPC:               loadGRPCConfig(),
 		Logger:             loadLoggerConfig(),
+		Tracing:            loadTracingConfig(),
+		Metrics:            loadMetricsConfig(),
 		Outbox:             loadOutboxConfig(),
 		OrderTimeoutWorker: loadOrderTimeoutWorkerConfig(),
+		Payment:            loadPaymentConfig(),
+		PriceQuote:         loadPriceQuoteConfig(),
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -40,8 +48,12 @@ func (c *Config) Validate() error {
 		&c.Redis,
 		&c.Kafka,
 		&c.GRPC,
+		&c.Tracing,
+		&c.Metrics,
 		&c.Outbox,
 		&c.OrderTimeoutWorker,
+		&c.Payment,
+		&c.PriceQuote,
 	}
 
 	for _, v := range validators {
@@ -61,6 +73,15 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// mustEnv gets a required environment variable, such as a secret with no safe default
+func mustEnv(key string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		panic("environment variable " + key + " is required")
+	}
+	return value
+}
+
 // getEnvInt gets environment variable as int with default value
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
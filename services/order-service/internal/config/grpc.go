@@ -4,17 +4,14 @@ import (
 	"fmt"
 )
 
-// GRPCConfig aggregates all gRPC related settings for both inbound and outbound traffic.
+// GRPCConfig aggregates the gRPC settings of the Order Service's own listener.
 type GRPCConfig struct {
 	// Server settings for the Order Service's own gRPC listener
 	Server struct {
 		Port int
-	}
-
-	// ProductClient settings for connecting to the Product microservice
-	ProductClient struct {
-		Addr    string
-		Timeout int // connection timeout in seconds
+		// IdentitySecret verifies the caller identity the API gateway forwards; the gateway
+		// must sign with the same secret
+		IdentitySecret string
 	}
 }
 
@@ -24,10 +21,7 @@ func loadGRPCConfig() GRPCConfig {
 
 	// Inbound: The port this service will listen on
 	cfg.Server.Port = getEnvInt("ORDER_GRPC_PORT", 50051)
-
-	// Outbound: The address and timeout for the external Product Service
-	cfg.ProductClient.Addr = getEnv("PRODUCT_SERVICE_ADDR", "localhost:50051")
-	cfg.ProductClient.Timeout = getEnvInt("PRODUCT_SERVICE_TIMEOUT", 5)
+	cfg.Server.IdentitySecret = mustEnv("IDENTITY_SECRET")
 
 	return cfg
 }
@@ -38,12 +32,8 @@ func (c *GRPCConfig) Validate() error {
 		return fmt.Errorf("invalid order_grpc_port: %d", c.Server.Port)
 	}
 
-	if c.ProductClient.Addr == "" {
-		return fmt.Errorf("product_service_addr must not be empty")
-	}
-
-	if c.ProductClient.Timeout <= 0 {
-		return fmt.Errorf("product_service_timeout must be a positive integer")
+	if c.Server.IdentitySecret == "" {
+		return fmt.Errorf("identity_secret is required")
 	}
 
 	return nil
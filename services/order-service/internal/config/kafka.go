@@ -21,6 +21,12 @@ type KafkaConfig struct {
 	ConsumerMinBytes int
 	ConsumerMaxBytes int
 	ConsumerMaxWait  time.Duration
+
+	// Retry policy: failed messages are retried with exponential backoff, then moved to DLQTopic
+	DLQTopic                string
+	ConsumerMaxAttempts     int
+	ConsumerRetryBackoff    time.Duration
+	ConsumerRetryMaxBackoff time.Duration
 }
 
 func loadKafkaConfig() KafkaConfig {
@@ -40,6 +46,12 @@ func loadKafkaConfig() KafkaConfig {
 		ConsumerMinBytes: getEnvInt("KAFKA_CONSUMER_MIN_BYTES", 1e3),  // 1KB
 		ConsumerMaxBytes: getEnvInt("KAFKA_CONSUMER_MAX_BYTES", 10e6), // 10MB
 		ConsumerMaxWait:  getEnvDuration("KAFKA_CONSUMER_MAX_WAIT", 100*time.Millisecond),
+
+		// Consumer retry policy
+		DLQTopic:                getEnv("KAFKA_DLQ_TOPIC", "order-service-dlq"),
+		ConsumerMaxAttempts:     getEnvInt("KAFKA_CONSUMER_MAX_ATTEMPTS", 5),
+		ConsumerRetryBackoff:    getEnvDuration("KAFKA_CONSUMER_RETRY_BACKOFF", 200*time.Millisecond),
+		ConsumerRetryMaxBackoff: getEnvDuration("KAFKA_CONSUMER_RETRY_MAX_BACKOFF", 5*time.Second),
 	}
 }
 
@@ -56,5 +68,14 @@ func (c *KafkaConfig) Validate() error {
 	if c.ProducerMaxAttempts <= 0 {
 		return fmt.Errorf("producer_max_attempts must be positive")
 	}
+	if c.DLQTopic == "" {
+		return fmt.Errorf("kafka dlq topic is required")
+	}
+	if c.ConsumerMaxAttempts <= 0 {
+		return fmt.Errorf("consumer_max_attempts must be positive")
+	}
+	if c.ConsumerRetryBackoff <= 0 || c.ConsumerRetryMaxBackoff < c.ConsumerRetryBackoff {
+		return fmt.Errorf("consumer_retry_backoff must be positive and not exceed consumer_retry_max_backoff")
+	}
 	return nil
 }
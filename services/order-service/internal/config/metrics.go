@@ -0,0 +1,20 @@
+package config
+
+import "fmt"
+
+type MetricsConfig struct {
+	Port int // HTTP port serving /metrics
+}
+
+func loadMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		Port: getEnvInt("METRICS_PORT", 9104),
+	}
+}
+
+func (c *MetricsConfig) Validate() error {
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid metrics port: %d", c.Port)
+	}
+	return nil
+}
@@ -8,12 +8,24 @@ import (
 type OutboxConfig struct {
 	Interval  time.Duration
 	BatchSize int
+
+	// Claiming lets several relays share the table; the lease bounds how long a crashed relay holds rows
+	ClaimLease time.Duration
+
+	// Retry policy for failed publishes
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
 }
 
 func loadOutboxConfig() OutboxConfig {
 	return OutboxConfig{
-		Interval:  getEnvDuration("OUTBOX_RELAY_INTERVAL", 500*time.Millisecond),
-		BatchSize: getEnvInt("OUTBOX_BATCH_SIZE", 50),
+		Interval:       getEnvDuration("OUTBOX_RELAY_INTERVAL", 500*time.Millisecond),
+		BatchSize:      getEnvInt("OUTBOX_BATCH_SIZE", 50),
+		ClaimLease:     getEnvDuration("OUTBOX_CLAIM_LEASE", 30*time.Second),
+		MaxRetries:     getEnvInt("OUTBOX_MAX_RETRIES", 5),
+		RetryBaseDelay: getEnvDuration("OUTBOX_RETRY_BASE_DELAY", 1*time.Second),
+		RetryMaxDelay:  getEnvDuration("OUTBOX_RETRY_MAX_DELAY", 5*time.Minute),
 	}
 }
 
@@ -21,5 +33,14 @@ func (c *OutboxConfig) Validate() error {
 	if c.Interval <= 0 {
 		return fmt.Errorf("outbox_relay_interval must be positive")
 	}
+	if c.ClaimLease <= c.Interval {
+		return fmt.Errorf("outbox_claim_lease must be longer than outbox_relay_interval")
+	}
+	if c.MaxRetries <= 0 {
+		return fmt.Errorf("outbox_max_retries must be positive")
+	}
+	if c.RetryBaseDelay <= 0 || c.RetryMaxDelay < c.RetryBaseDelay {
+		return fmt.Errorf("outbox_retry_base_delay must be positive and not exceed outbox_retry_max_delay")
+	}
 	return nil
 }
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"fmt"
+)
+
+type PaymentConfig struct {
+	Provider       string
+	CallbackSecret string
+}
+
+func loadPaymentConfig() PaymentConfig {
+	return PaymentConfig{
+		Provider:       getEnv("PAYMENT_PROVIDER", "mock"),
+		CallbackSecret: getEnv("PAYMENT_CALLBACK_SECRET", "mock-payment-secret"),
+	}
+}
+
+func (c *PaymentConfig) Validate() error {
+	if c.Provider != "mock" {
+		return fmt.Errorf("unsupported payment_provider: %s", c.Provider)
+	}
+	if c.CallbackSecret == "" {
+		return fmt.Errorf("payment_callback_secret is required")
+	}
+	return nil
+}
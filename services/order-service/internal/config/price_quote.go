@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+type PriceQuoteConfig struct {
+	// Secret verifies reservation price quotes; stock-service must sign with the same secret
+	Secret string
+	// ExpiryGrace is how long after expiry a quote is still accepted, to absorb consumer lag
+	// and clock skew. Events dead-lettered for longer are rejected as expired on replay.
+	ExpiryGrace time.Duration
+}
+
+func loadPriceQuoteConfig() PriceQuoteConfig {
+	return PriceQuoteConfig{
+		Secret:      mustEnv("PRICE_QUOTE_SECRET"),
+		ExpiryGrace: getEnvDuration("PRICE_QUOTE_EXPIRY_GRACE", 2*time.Minute),
+	}
+}
+
+func (c *PriceQuoteConfig) Validate() error {
+	if c.Secret == "" {
+		return fmt.Errorf("price_quote_secret is required")
+	}
+	if c.ExpiryGrace < 0 {
+		return fmt.Errorf("price_quote_expiry_grace must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package config
+
+import "fmt"
+
+type TracingConfig struct {
+	Exporter string // "none", "stdout", "file" or a registered exporter
+	FilePath string // Output of the "file" exporter
+
+	// Share of new traces that are sampled; traces sampled upstream are always kept
+	SampleRatio float64
+}
+
+func loadTracingConfig() TracingConfig {
+	return TracingConfig{
+		Exporter:    getEnv("TRACING_EXPORTER", "none"),
+		FilePath:    getEnv("TRACING_FILE_PATH", "traces.jsonl"),
+		SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1.0),
+	}
+}
+
+func (c *TracingConfig) Validate() error {
+	if c.Exporter == "" {
+		return fmt.Errorf("tracing_exporter is required, use \"none\" to disable exporting")
+	}
+	if c.Exporter == "file" && c.FilePath == "" {
+		return fmt.Errorf("tracing_file_path is required for the file exporter")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("tracing_sample_ratio must be between 0 and 1")
+	}
+	return nil
+}
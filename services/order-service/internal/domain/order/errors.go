@@ -10,6 +10,11 @@ var (
 	ErrOrderExpired          = errors.New("order has expired")
 	ErrInvalidOrderStatus    = errors.New("invalid order status")
 	ErrInvalidQuantity       = errors.New("quantity must be positive")
+	ErrOrderNotOwned         = errors.New("order does not belong to user")
+
+	// Price quote errors
+	ErrInvalidPriceQuote = errors.New("invalid price quote")
+	ErrPriceQuoteExpired = errors.New("price quote has expired")
 
 	// Payment errors
 	ErrPaymentFailed               = errors.New("payment failed")
@@ -19,6 +24,10 @@ var (
 	ErrCannotFailCompletedPayment  = errors.New("cannot fail a completed payment")
 	ErrInvalidPaymentMethod        = errors.New("invalid payment method")
 	ErrInsufficientFunds           = errors.New("insufficient funds")
+	ErrPaymentNotFound             = errors.New("payment not found")
+	ErrPaymentMismatch             = errors.New("payment does not match order")
+	ErrInvalidPaymentSignature     = errors.New("invalid payment callback signature")
+	ErrInvalidPaymentStatus        = errors.New("invalid payment status")
 
 	// Value object errors
 	ErrEmptyOrderID         = errors.New("order id cannot be empty")
@@ -73,6 +73,30 @@ func NewOrder(
 	return order, nil
 }
 
+// StartPayment opens a payment attempt for the order.
+// A pending attempt is reused; a failed one is replaced so the buyer can retry.
+func (o *Order) StartPayment(method PaymentMethod) (*Payment, error) {
+	if o.status == OrderStatusPaid {
+		return nil, ErrOrderAlreadyPaid
+	}
+
+	if err := o.ValidateCanPay(); err != nil {
+		return nil, err
+	}
+
+	if o.payment == nil || o.payment.IsFailed() {
+		o.payment = NewPayment(o.id, o.pricing.TotalPrice(), method)
+		o.updatedAt = time.Now()
+	}
+
+	return o.payment, nil
+}
+
+// IsOwnedBy checks whether the order belongs to the given user
+func (o *Order) IsOwnedBy(userID UserID) bool {
+	return o.userID.String() == userID.String()
+}
+
 // ProcessPayment processes payment for the order
 func (o *Order) ProcessPayment(method PaymentMethod, transactionID string) error {
 	// Validate can pay
@@ -94,6 +118,7 @@ func (o *Order) ProcessPayment(method PaymentMethod, transactionID string) error
 	now := time.Now()
 	o.status = OrderStatusPaid
 	o.paidAt = &now
+	o.updatedAt = now
 
 	// Record domain event
 	o.recordEvent(NewOrderPaidEvent(
@@ -113,7 +138,27 @@ func (o *Order) RecordPaymentFailure(reason string) error {
 		o.payment = NewPayment(o.id, o.pricing.TotalPrice(), PaymentMethodMock)
 	}
 
-	return o.payment.MarkAsFailed(reason)
+	if err := o.payment.MarkAsFailed(reason); err != nil {
+		return err
+	}
+
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// RecordPaymentRefund records that the pending payment was captured after the order
+// could no longer be paid, and that the charge was refunded
+func (o *Order) RecordPaymentRefund(transactionID string) error {
+	if o.payment == nil {
+		return ErrPaymentNotFound
+	}
+
+	if err := o.payment.MarkAsRefunded(transactionID); err != nil {
+		return err
+	}
+
+	o.updatedAt = time.Now()
+	return nil
 }
 
 // Cancel cancels the order
@@ -130,6 +175,7 @@ func (o *Order) Cancel(reason string) error {
 	o.status = OrderStatusCancelled
 	o.cancelledAt = &now
 	o.cancelReason = &reason
+	o.updatedAt = now
 
 	// Record domain event
 	o.recordEvent(NewOrderCancelledEvent(
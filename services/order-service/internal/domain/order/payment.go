@@ -63,6 +63,20 @@ func (p *Payment) MarkAsFailed(reason string) error {
 	return nil
 }
 
+// MarkAsRefunded records that a charge captured after the order closed was refunded
+func (p *Payment) MarkAsRefunded(transactionID string) error {
+	if p.status != PaymentStatusPending {
+		return ErrInvalidPaymentStatus
+	}
+
+	now := time.Now()
+	p.status = PaymentStatusRefunded
+	p.transactionID = &transactionID
+	p.processedAt = &now
+
+	return nil
+}
+
 // Getters
 func (p *Payment) ID() PaymentID {
 	return p.id
@@ -110,6 +124,11 @@ func (p *Payment) IsFailed() bool {
 	return p.status == PaymentStatusFailed
 }
 
+// IsRefunded checks if payment was refunded
+func (p *Payment) IsRefunded() bool {
+	return p.status == PaymentStatusRefunded
+}
+
 // IsPending checks if payment is pending
 func (p *Payment) IsPending() bool {
 	return p.status == PaymentStatusPending
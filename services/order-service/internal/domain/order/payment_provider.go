@@ -0,0 +1,51 @@
+package order
+
+import "context"
+
+// PaymentProvider is the port to an external payment processor
+type PaymentProvider interface {
+	// Charge requests a charge for a payment attempt. Providers that settle
+	// asynchronously return PaymentStatusPending and report the final outcome
+	// through a payment callback.
+	Charge(ctx context.Context, req ChargeRequest) (*ChargeResult, error)
+
+	// Refund hands back a completed charge. Refunding the same payment again must be a
+	// no-op, since a refund whose outcome could not be saved is retried.
+	Refund(ctx context.Context, req RefundRequest) error
+
+	// VerifyCallback checks that a callback was issued by the provider
+	VerifyCallback(ctx context.Context, callback PaymentCallback) error
+}
+
+// ChargeRequest describes a single charge attempt
+type ChargeRequest struct {
+	PaymentID PaymentID
+	OrderID   OrderID
+	Amount    Money
+	Method    PaymentMethod
+}
+
+// RefundRequest describes the refund of a completed charge
+type RefundRequest struct {
+	PaymentID     PaymentID
+	OrderID       OrderID
+	TransactionID string
+	Amount        Money
+}
+
+// ChargeResult is the provider's answer to a charge attempt
+type ChargeResult struct {
+	TransactionID string
+	Status        PaymentStatus
+	FailureReason string
+}
+
+// PaymentCallback is an asynchronous settlement notification from the provider
+type PaymentCallback struct {
+	OrderID       string
+	PaymentID     string
+	TransactionID string
+	Status        PaymentStatus
+	FailureReason string
+	Signature     string
+}
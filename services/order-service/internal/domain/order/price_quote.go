@@ -0,0 +1,57 @@
+package order
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// PriceQuote is the signed unit price stock-service quoted when the reservation was made
+type PriceQuote struct {
+	ID        string
+	Amount    int64
+	Currency  string
+	ExpiresAt time.Time
+	Signature string
+}
+
+// QuoteVerifier verifies price quotes signed by stock-service.
+// The signature is hex hmac-sha256 over
+// "quote_id|reservation_id|product_id|user_id|quantity|amount|currency|expires_unix".
+// A quote stays acceptable for expiryGrace after it expires, so consumer lag or clock skew
+// between the services does not reject a reservation that was used in time.
+type QuoteVerifier struct {
+	secret      []byte
+	expiryGrace time.Duration
+}
+
+// NewQuoteVerifier creates a quote verifier
+func NewQuoteVerifier(secret string, expiryGrace time.Duration) *QuoteVerifier {
+	return &QuoteVerifier{secret: []byte(secret), expiryGrace: expiryGrace}
+}
+
+// Verify checks that the quote was issued for the reservation unaltered and had not expired
+// by now, the consumer's clock
+func (v *QuoteVerifier) Verify(q *PriceQuote, reservationID, productID, userID string, quantity int, now time.Time) error {
+	actual, err := hex.DecodeString(q.Signature)
+	if err != nil {
+		return ErrInvalidPriceQuote
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	fmt.Fprintf(mac, "%s|%s|%s|%s|%d|%d|%s|%d",
+		q.ID, reservationID, productID, userID, quantity,
+		q.Amount, q.Currency, q.ExpiresAt.Unix(),
+	)
+	if !hmac.Equal(mac.Sum(nil), actual) {
+		return ErrInvalidPriceQuote
+	}
+
+	if !now.Before(q.ExpiresAt.Add(v.expiryGrace)) {
+		return ErrPriceQuoteExpired
+	}
+
+	return nil
+}
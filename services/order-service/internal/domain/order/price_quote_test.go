@@ -0,0 +1,146 @@
+package order
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const testQuoteSecret = "test-secret"
+
+// signedQuote is quoted for reservation "res-1" of 2 units of "prod-1" by "user-1".
+// The signature is hmac-sha256 with testQuoteSecret over
+// "quote-1|res-1|prod-1|user-1|2|1500|USD|1790000000", as stock-service signs it.
+func signedQuote() *PriceQuote {
+	return &PriceQuote{
+		ID:        "quote-1",
+		Amount:    1500,
+		Currency:  "USD",
+		ExpiresAt: time.Unix(1790000000, 0),
+		Signature: "664c2612bf5a9c6bbf18550696b0764e4448f6df2dc3a93fdcbeaaf0e6e35a95",
+	}
+}
+
+func TestQuoteVerifierVerify(t *testing.T) {
+	expiresAt := time.Unix(1790000000, 0)
+	grace := 2 * time.Minute
+
+	tests := []struct {
+		name          string
+		secret        string
+		quote         func(q *PriceQuote)
+		reservationID string
+		productID     string
+		userID        string
+		quantity      int
+		now           time.Time
+		wantErr       error
+	}{
+		{
+			name:    "valid quote",
+			now:     expiresAt.Add(-time.Minute),
+			wantErr: nil,
+		},
+		{
+			name:    "used within the expiry grace",
+			now:     expiresAt.Add(grace - time.Second),
+			wantErr: nil,
+		},
+		{
+			name:    "expired past the grace",
+			now:     expiresAt.Add(grace),
+			wantErr: ErrPriceQuoteExpired,
+		},
+		{
+			name:    "wrong secret",
+			secret:  "other-secret",
+			now:     expiresAt.Add(-time.Minute),
+			wantErr: ErrInvalidPriceQuote,
+		},
+		{
+			name:    "altered amount",
+			quote:   func(q *PriceQuote) { q.Amount = 1 },
+			now:     expiresAt.Add(-time.Minute),
+			wantErr: ErrInvalidPriceQuote,
+		},
+		{
+			name:    "altered currency",
+			quote:   func(q *PriceQuote) { q.Currency = "JPY" },
+			now:     expiresAt.Add(-time.Minute),
+			wantErr: ErrInvalidPriceQuote,
+		},
+		{
+			name:    "extended expiry",
+			quote:   func(q *PriceQuote) { q.ExpiresAt = q.ExpiresAt.Add(time.Hour) },
+			now:     expiresAt.Add(time.Minute),
+			wantErr: ErrInvalidPriceQuote,
+		},
+		{
+			name:          "moved to another reservation",
+			reservationID: "res-2",
+			now:           expiresAt.Add(-time.Minute),
+			wantErr:       ErrInvalidPriceQuote,
+		},
+		{
+			name:      "moved to another product",
+			productID: "prod-2",
+			now:       expiresAt.Add(-time.Minute),
+			wantErr:   ErrInvalidPriceQuote,
+		},
+		{
+			name:    "moved to another user",
+			userID:  "user-2",
+			now:     expiresAt.Add(-time.Minute),
+			wantErr: ErrInvalidPriceQuote,
+		},
+		{
+			name:     "different quantity",
+			quantity: 3,
+			now:      expiresAt.Add(-time.Minute),
+			wantErr:  ErrInvalidPriceQuote,
+		},
+		{
+			name:    "signature is not hex",
+			quote:   func(q *PriceQuote) { q.Signature = "not-hex" },
+			now:     expiresAt.Add(-time.Minute),
+			wantErr: ErrInvalidPriceQuote,
+		},
+		{
+			name:    "missing signature",
+			quote:   func(q *PriceQuote) { q.Signature = "" },
+			now:     expiresAt.Add(-time.Minute),
+			wantErr: ErrInvalidPriceQuote,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := testQuoteSecret
+			if tt.secret != "" {
+				secret = tt.secret
+			}
+			q := signedQuote()
+			if tt.quote != nil {
+				tt.quote(q)
+			}
+			reservationID, productID, userID, quantity := "res-1", "prod-1", "user-1", 2
+			if tt.reservationID != "" {
+				reservationID = tt.reservationID
+			}
+			if tt.productID != "" {
+				productID = tt.productID
+			}
+			if tt.userID != "" {
+				userID = tt.userID
+			}
+			if tt.quantity != 0 {
+				quantity = tt.quantity
+			}
+
+			err := NewQuoteVerifier(secret, grace).Verify(q, reservationID, productID, userID, quantity, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -13,6 +13,10 @@ type Repository interface {
 	// FindByID finds an order by ID
 	FindByID(ctx context.Context, id OrderID) (*Order, error)
 
+	// FindByIDForUpdate finds an order by ID and locks its row until the transaction ends,
+	// so concurrent state transitions on the same order run one after another
+	FindByIDForUpdate(ctx context.Context, id OrderID) (*Order, error)
+
 	// FindByReservationID finds an order by reservation ID
 	FindByReservationID(ctx context.Context, reservationID ReservationID) (*Order, error)
 
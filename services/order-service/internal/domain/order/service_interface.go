@@ -4,7 +4,9 @@ import "context"
 
 // OrderCreator defines the interface for creating orders
 type Creator interface {
-	CreateOrderFromReservation(ctx context.Context, reservationID, userID, productID string, quantity int) error
+	// CreateOrderFromReservation prices the order at the reservation's signed price quote
+	CreateOrderFromReservation(ctx context.Context, reservationID, userID, productID string, quantity int, quote *PriceQuote) error
+	CreateOrderFromAuction(ctx context.Context, reservationID, userID, productID string, quantity int, unitPrice int64, currency string) error
 }
 
 type Service interface {
@@ -12,4 +14,7 @@ type Service interface {
 	CancelExpiredOrder(ctx context.Context, orderID string) error
 	GetOrder(ctx context.Context, orderID string) (*Order, error)
 	ListUserOrders(ctx context.Context, userID string, limit, offset int) ([]*Order, error)
+	PayOrder(ctx context.Context, orderID string, userID string, method string) (*Order, error)
+	HandlePaymentCallback(ctx context.Context, callback PaymentCallback) (*Order, error)
+	CancelOrder(ctx context.Context, orderID string, userID string, reason string) (*Order, error)
 }
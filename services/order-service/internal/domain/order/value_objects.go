@@ -190,12 +190,24 @@ const (
 	PaymentStatusPending   PaymentStatus = "PENDING"
 	PaymentStatusCompleted PaymentStatus = "COMPLETED"
 	PaymentStatusFailed    PaymentStatus = "FAILED"
+	// PaymentStatusRefunded marks a charge captured after its order closed and handed back
+	PaymentStatusRefunded PaymentStatus = "REFUNDED"
 )
 
 func (s PaymentStatus) String() string {
 	return string(s)
 }
 
+// ParsePaymentStatus validates a payment status reported by a provider
+func ParsePaymentStatus(s string) (PaymentStatus, error) {
+	status := PaymentStatus(s)
+	switch status {
+	case PaymentStatusPending, PaymentStatusCompleted, PaymentStatusFailed:
+		return status, nil
+	}
+	return "", ErrInvalidPaymentStatus
+}
+
 // PaymentMethod represents the payment method
 type PaymentMethod string
 
@@ -207,3 +219,16 @@ const (
 func (m PaymentMethod) String() string {
 	return string(m)
 }
+
+// ParsePaymentMethod validates a payment method, defaulting to MOCK when empty
+func ParsePaymentMethod(s string) (PaymentMethod, error) {
+	if s == "" {
+		return PaymentMethodMock, nil
+	}
+	method := PaymentMethod(s)
+	switch method {
+	case PaymentMethodMock, PaymentMethodCreditCard:
+		return method, nil
+	}
+	return "", ErrInvalidPaymentMethod
+}
@@ -1,7 +0,0 @@
-package productprice
-
-import "context"
-
-type ProductClient interface {
-	FetchProductDetail(ctx context.Context, productID string) (*ProductPrice, error)
-}
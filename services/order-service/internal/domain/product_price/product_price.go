@@ -1,19 +1,27 @@
 package productprice
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
+var ErrPriceNotFound = errors.New("product price not found")
+
+// ProductPrice is the unit price of a product from EffectiveFrom until the next recorded change
 type ProductPrice struct {
-	ProductID string
-	UnitPrice int64
-	Currency  string
-	UpdatedAt time.Time
+	ProductID     string    `db:"product_id"`
+	UnitPrice     int64     `db:"unit_price"`
+	Currency      string    `db:"currency"`
+	EffectiveFrom time.Time `db:"effective_from"`
+	UpdatedAt     time.Time `db:"updated_at"`
 }
 
-func NewProductPrice(id string, price int64, currency string) *ProductPrice {
+func NewProductPrice(id string, price int64, currency string, effectiveFrom time.Time) *ProductPrice {
 	return &ProductPrice{
-		ProductID: id,
-		UnitPrice: price,
-		Currency:  currency,
-		UpdatedAt: time.Now(),
+		ProductID:     id,
+		UnitPrice:     price,
+		Currency:      currency,
+		EffectiveFrom: effectiveFrom,
+		UpdatedAt:     time.Now(),
 	}
 }
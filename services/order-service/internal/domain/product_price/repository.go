@@ -1,10 +1,15 @@
 package productprice
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
-// Repository defines the storage contract for product price snapshots.
+// Repository defines the storage contract for the product price history.
 // This interface lives in the Domain/Application layer to maintain decoupling.
 type Repository interface {
-	GetByID(ctx context.Context, productID string) (*ProductPrice, error)
-	Upsert(ctx context.Context, price *ProductPrice) error
+	// FindEffectiveAt returns the price in effect at the given time, or ErrPriceNotFound
+	FindEffectiveAt(ctx context.Context, productID string, at time.Time) (*ProductPrice, error)
+	// Record adds a price to the history; recording the same change twice is a no-op
+	Record(ctx context.Context, price *ProductPrice) error
 }
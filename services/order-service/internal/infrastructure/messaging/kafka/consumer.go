@@ -3,22 +3,43 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/config"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+// ErrNonRetryable marks a handler error that retrying cannot fix, such as a rejected price
+// quote. The message is dead-lettered after the first attempt.
+var ErrNonRetryable = errors.New("non-retryable event")
+
 type EventHandler interface {
 	Handle(ctx context.Context, msg *EventMessage) error
 }
 
 type Consumer struct {
-	reader  *kafka.Reader
-	handler EventHandler
+	reader      *kafka.Reader
+	handler     EventHandler
+	deadLetters *DeadLetterQueue
+	topic       string
+	groupID     string
+
+	// Retry policy
+	maxAttempts     int
+	retryBackoff    time.Duration
+	retryMaxBackoff time.Duration
 }
 
-func NewConsumer(cfg *config.KafkaConfig, handler EventHandler) *Consumer {
+func NewConsumer(cfg *config.KafkaConfig, handler EventHandler, deadLetters *DeadLetterQueue) *Consumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:  cfg.Brokers,
 		GroupID:  cfg.ConsumerGroupID,
@@ -29,8 +50,14 @@ func NewConsumer(cfg *config.KafkaConfig, handler EventHandler) *Consumer {
 	})
 
 	return &Consumer{
-		reader:  reader,
-		handler: handler,
+		reader:          reader,
+		handler:         handler,
+		deadLetters:     deadLetters,
+		topic:           cfg.ConsumerTopic,
+		groupID:         cfg.ConsumerGroupID,
+		maxAttempts:     cfg.ConsumerMaxAttempts,
+		retryBackoff:    cfg.ConsumerRetryBackoff,
+		retryMaxBackoff: cfg.ConsumerRetryMaxBackoff,
 	}
 }
 
@@ -47,29 +74,116 @@ func (c *Consumer) Start(ctx context.Context) error {
 			zap.L().Error("failed to fetch message from kafka", zap.Error(err))
 			continue
 		}
+		metrics.SetConsumerLag(c.topic, c.groupID, m.Partition, m.HighWaterMark-m.Offset-1)
 
 		var event EventMessage
 		if err := json.Unmarshal(m.Value, &event); err != nil {
 			zap.L().Error("failed to unmarshal kafka message", zap.Error(err))
-			// Commit invalid message to skip it
+			// Invalid messages never succeed, dead-letter them without retrying
+			if !c.sendToDeadLetter(ctx, m, 1, err) {
+				return nil
+			}
+			metrics.RecordConsumedMessage(c.topic, c.groupID, "dead_lettered")
 			_ = c.reader.CommitMessages(ctx, m)
 			continue
 		}
 
-		// Execute business logic via handler
-		if err := c.handler.Handle(ctx, &event); err != nil {
-			zap.L().Error("failed to handle event", zap.String("event_id", event.EventID), zap.Error(err))
-			// In enterprise systems, we might send this to a DLQ (Dead Letter Queue) instead of retrying forever
-			continue
+		// Execute business logic via handler, retrying with backoff before giving up
+		if attempts, err := c.handleMessage(ctx, m, &event); err != nil {
+			if ctx.Err() != nil {
+				return nil // Offset stays uncommitted, the message is redelivered on restart
+			}
+			zap.L().Error("failed to handle event, moving to dlq",
+				zap.String("event_id", event.EventID),
+				zap.Int("attempts", attempts),
+				zap.Error(err),
+			)
+			if !c.sendToDeadLetter(ctx, m, attempts, err) {
+				return nil
+			}
+			metrics.RecordConsumedMessage(c.topic, c.groupID, "dead_lettered")
+		} else {
+			metrics.RecordConsumedMessage(c.topic, c.groupID, "processed")
 		}
 
-		// Commit offset only after successful processing (At-least-once)
+		// Commit offset only after the message is processed or dead-lettered (At-least-once)
 		if err := c.reader.CommitMessages(ctx, m); err != nil {
 			zap.L().Error("failed to commit message offset", zap.Error(err))
 		}
 	}
 }
 
+// handleMessage runs the handler in a consumer span continuing the producer's trace
+func (c *Consumer) handleMessage(ctx context.Context, m kafka.Message, event *EventMessage) (int, error) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{headers: &m.Headers})
+	ctx, span := tracing.Tracer().Start(ctx, c.topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(messagingAttributes(c.topic, event)...),
+		trace.WithAttributes(attribute.String("messaging.consumer.group.name", c.groupID)),
+	)
+	defer span.End()
+	ctx = logger.WithTraceID(ctx, tracing.TraceID(ctx))
+
+	attempts, err := c.handleWithRetry(ctx, event)
+	span.SetAttributes(attribute.Int("messaging.attempts", attempts))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to handle event")
+	}
+	return attempts, err
+}
+
+// handleWithRetry returns the number of attempts made and the last error
+func (c *Consumer) handleWithRetry(ctx context.Context, event *EventMessage) (int, error) {
+	backoff := c.retryBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := c.handler.Handle(ctx, event)
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt >= c.maxAttempts || errors.Is(err, ErrNonRetryable) {
+			return attempt, err
+		}
+
+		zap.L().Warn("failed to handle event, retrying",
+			zap.String("event_id", event.EventID),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, c.retryMaxBackoff)
+	}
+}
+
+// sendToDeadLetter retries until the message is on the dlq, since committing it otherwise loses it.
+// Returns false if the context is cancelled first.
+func (c *Consumer) sendToDeadLetter(ctx context.Context, m kafka.Message, attempts int, cause error) bool {
+	backoff := c.retryBackoff
+
+	for {
+		err := c.deadLetters.Publish(ctx, m, c.groupID, attempts, cause)
+		if err == nil {
+			return true
+		}
+
+		zap.L().Error("failed to publish to dlq", zap.Int64("offset", m.Offset), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, c.retryMaxBackoff)
+	}
+}
+
 func (c *Consumer) Close() error {
 	return c.reader.Close()
 }
@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/config"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -34,8 +38,14 @@ func NewProducer(cfg *config.KafkaConfig) *Producer {
 	}
 }
 
-// Publish publishes an event message to Kafka
+// Publish publishes an event message to Kafka, carrying the trace of ctx in the headers
 func (p *Producer) Publish(ctx context.Context, event *EventMessage) error {
+	ctx, span := tracing.Tracer().Start(ctx, p.topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(messagingAttributes(p.topic, event)...),
+	)
+	defer span.End()
+
 	zap.L().Debug("publishing event to kafka",
 		zap.String("topic", p.topic),
 		zap.String("event_type", event.EventType),
@@ -53,9 +63,12 @@ func (p *Producer) Publish(ctx context.Context, event *EventMessage) error {
 		Key:   []byte(event.AggregateID), // Partition by aggregate ID
 		Value: value,
 	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{headers: &msg.Headers})
 
 	// Publish
 	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "kafka publish failed")
 		zap.L().Error("failed to publish event to kafka",
 			zap.String("topic", p.topic),
 			zap.String("event_type", event.EventType),
@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"time"
 
 	productprice "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/product_price"
 	"go.uber.org/zap"
@@ -35,6 +36,24 @@ func (h *ProductEventHandler) Handle(ctx context.Context, msg *EventMessage) err
 		return fmt.Errorf("incomplete price data")
 	}
 
+	// Older events carry no effective_from; the price applied from when the event occurred
+	effectiveFrom := msg.OccurredAt
+	if raw, ok := msg.Data["effective_from"].(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			zap.L().Error("invalid effective_from in product event",
+				zap.String("event_id", msg.EventID),
+				zap.Error(err),
+			)
+			return fmt.Errorf("invalid effective_from: %w", err)
+		}
+		effectiveFrom = t
+	} else if raw, ok := msg.Data["occurred_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			effectiveFrom = t
+		}
+	}
+
 	// 2. Delegate to the syncer (Application logic)
-	return h.syncer.SyncProductPrice(ctx, productID, int64(priceRaw), currency)
+	return h.syncer.SyncProductPrice(ctx, productID, int64(priceRaw), currency, effectiveFrom)
 }
@@ -2,6 +2,9 @@ package kafka
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/order"
 	"go.uber.org/zap"
@@ -30,6 +33,9 @@ func (h *ReservationEventHandler) Handle(ctx context.Context, msg *EventMessage)
 	case "stock.reserved":
 		return h.handleReservationCreated(ctx, msg)
 
+	case "auction.won":
+		return h.handleAuctionWon(ctx, msg)
+
 	default:
 		// Ignore other reservation events
 		zap.L().Debug("ignoring reservation event",
@@ -65,6 +71,15 @@ func (h *ReservationEventHandler) handleReservationCreated(ctx context.Context,
 		return nil
 	}
 
+	quote, err := parsePriceQuote(msg.Data)
+	if err != nil {
+		zap.L().Error("malformed price quote in event",
+			zap.String("reservation_id", reservationID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("%w: %w", ErrNonRetryable, err)
+	}
+
 	zap.L().Info("creating order from reservation",
 		zap.String("reservation_id", reservationID),
 		zap.String("user_id", userID),
@@ -73,14 +88,25 @@ func (h *ReservationEventHandler) handleReservationCreated(ctx context.Context,
 	)
 
 	// Create order
-	err := h.orderCreator.CreateOrderFromReservation(
+	err = h.orderCreator.CreateOrderFromReservation(
 		ctx,
 		reservationID,
 		userID,
 		productID,
 		int(quantity),
+		quote,
 	)
 
+	// A rejected quote points at a misconfigured secret or a tampered event, which retrying
+	// cannot fix; dead-letter the event right away so it can be inspected and replayed
+	if errors.Is(err, order.ErrInvalidPriceQuote) || errors.Is(err, order.ErrPriceQuoteExpired) {
+		zap.L().Error("price quote rejected, not creating order",
+			zap.String("reservation_id", reservationID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("%w: %w", ErrNonRetryable, err)
+	}
+
 	if err != nil {
 		zap.L().Error("failed to create order from reservation",
 			zap.String("reservation_id", reservationID),
@@ -95,3 +121,101 @@ func (h *ReservationEventHandler) handleReservationCreated(ctx context.Context,
 
 	return nil
 }
+
+// parsePriceQuote reads the optional price_quote of a stock.reserved event
+func parsePriceQuote(data map[string]interface{}) (*order.PriceQuote, error) {
+	raw, ok := data["price_quote"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	id, _ := raw["quote_id"].(string)
+	amount, _ := raw["amount"].(float64) // JSON numbers are float64
+	currency, _ := raw["currency"].(string)
+	signature, _ := raw["signature"].(string)
+	expiresRaw, _ := raw["expires_at"].(string)
+	if id == "" || currency == "" || signature == "" {
+		return nil, order.ErrInvalidPriceQuote
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, expiresRaw)
+	if err != nil {
+		return nil, order.ErrInvalidPriceQuote
+	}
+
+	return &order.PriceQuote{
+		ID:        id,
+		Amount:    int64(amount),
+		Currency:  currency,
+		ExpiresAt: expiresAt,
+		Signature: signature,
+	}, nil
+}
+
+func (h *ReservationEventHandler) handleAuctionWon(ctx context.Context, msg *EventMessage) error {
+	reservationID, ok := msg.Data["reservation_id"].(string)
+	if !ok {
+		zap.L().Error("missing reservation_id in event")
+		return nil // Skip this message
+	}
+
+	userID, ok := msg.Data["user_id"].(string)
+	if !ok {
+		zap.L().Error("missing user_id in event")
+		return nil
+	}
+
+	productID, ok := msg.Data["product_id"].(string)
+	if !ok {
+		zap.L().Error("missing product_id in event")
+		return nil
+	}
+
+	quantity, ok := msg.Data["quantity"].(float64) // JSON numbers are float64
+	if !ok {
+		zap.L().Error("missing quantity in event")
+		return nil
+	}
+
+	unitPrice, ok := msg.Data["unit_price"].(float64)
+	if !ok {
+		zap.L().Error("missing unit_price in event")
+		return nil
+	}
+
+	currency, ok := msg.Data["currency"].(string)
+	if !ok {
+		zap.L().Error("missing currency in event")
+		return nil
+	}
+
+	zap.L().Info("creating order from auction",
+		zap.String("auction_id", msg.AggregateID),
+		zap.String("reservation_id", reservationID),
+		zap.String("user_id", userID),
+		zap.Int64("unit_price", int64(unitPrice)),
+	)
+
+	err := h.orderCreator.CreateOrderFromAuction(
+		ctx,
+		reservationID,
+		userID,
+		productID,
+		int(quantity),
+		int64(unitPrice),
+		currency,
+	)
+	if err != nil {
+		zap.L().Error("failed to create order from auction",
+			zap.String("reservation_id", reservationID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	zap.L().Info("order created from auction",
+		zap.String("reservation_id", reservationID),
+	)
+
+	return nil
+}
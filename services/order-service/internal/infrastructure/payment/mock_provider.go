@@ -0,0 +1,95 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/order"
+)
+
+// Mock outcomes are decided by the last two digits of the charged amount (in cents),
+// similar to the amount-driven test modes offered by real payment sandboxes:
+//
+//	xx51 -> declined (insufficient funds)
+//	xx52 -> accepted asynchronously, settled later through the payment callback
+//	else -> approved immediately
+const (
+	mockDeclineSuffix = 51
+	mockPendingSuffix = 52
+)
+
+// MockProvider is a deterministic in-process payment provider for development and testing.
+// The same payment always yields the same transaction ID and outcome.
+type MockProvider struct {
+	callbackSecret []byte
+}
+
+var _ order.PaymentProvider = (*MockProvider)(nil)
+
+// NewMockProvider creates a mock provider that signs callbacks with the given secret
+func NewMockProvider(callbackSecret string) *MockProvider {
+	return &MockProvider{
+		callbackSecret: []byte(callbackSecret),
+	}
+}
+
+// Charge settles a charge attempt without any network I/O
+func (p *MockProvider) Charge(ctx context.Context, req order.ChargeRequest) (*order.ChargeResult, error) {
+	result := &order.ChargeResult{
+		TransactionID: transactionID(req.PaymentID),
+	}
+
+	switch req.Amount.Amount() % 100 {
+	case mockDeclineSuffix:
+		result.Status = order.PaymentStatusFailed
+		result.FailureReason = order.ErrInsufficientFunds.Error()
+	case mockPendingSuffix:
+		result.Status = order.PaymentStatusPending
+	default:
+		result.Status = order.PaymentStatusCompleted
+	}
+
+	return result, nil
+}
+
+// Refund always succeeds; the mock keeps no balances to hand back
+func (p *MockProvider) Refund(ctx context.Context, req order.RefundRequest) error {
+	return nil
+}
+
+// VerifyCallback checks the HMAC signature of a callback
+func (p *MockProvider) VerifyCallback(ctx context.Context, callback order.PaymentCallback) error {
+	expected, err := hex.DecodeString(p.Sign(callback))
+	if err != nil {
+		return err
+	}
+
+	actual, err := hex.DecodeString(callback.Signature)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return order.ErrInvalidPaymentSignature
+	}
+
+	return nil
+}
+
+// Sign computes the hex-encoded HMAC-SHA256 signature the mock expects on a callback
+func (p *MockProvider) Sign(callback order.PaymentCallback) string {
+	mac := hmac.New(sha256.New, p.callbackSecret)
+	mac.Write([]byte(strings.Join([]string{
+		callback.OrderID,
+		callback.PaymentID,
+		callback.TransactionID,
+		string(callback.Status),
+		callback.FailureReason,
+	}, "|")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// transactionID derives a stable provider transaction ID from the payment ID
+func transactionID(paymentID order.PaymentID) string {
+	sum := sha256.Sum256([]byte(paymentID.String()))
+	return "mock_txn_" + hex.EncodeToString(sum[:12])
+}
@@ -93,6 +93,32 @@ func (r *OrderRepository) FindByID(ctx context.Context, id order.OrderID) (*orde
 	return ModelToDomain(&model)
 }
 
+// FindByIDForUpdate retrieves a single order and holds its row lock for the rest of the
+// transaction. Outside a transaction the lock is released as soon as the query returns.
+func (r *OrderRepository) FindByIDForUpdate(ctx context.Context, id order.OrderID) (*order.Order, error) {
+	query := `
+		SELECT id, order_id, reservation_id, user_id, product_id, quantity,
+			   unit_price, total_price, currency, status,
+			   payment_id, payment_method, payment_status,
+			   payment_transaction_id, payment_processed_at, payment_failure_reason,
+			   created_at, expires_at, paid_at, cancelled_at, cancel_reason, updated_at
+		FROM orders
+		WHERE order_id = $1
+		FOR UPDATE
+	`
+
+	var model OrderModel
+	err := sqlx.GetContext(ctx, r.db, &model, query, id.String())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, order.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to lock order by id: %w", err)
+	}
+
+	return ModelToDomain(&model)
+}
+
 // FindByReservationID retrieves an order linked to a specific reservation
 func (r *OrderRepository) FindByReservationID(ctx context.Context, resID order.ReservationID) (*order.Order, error) {
 	query := `
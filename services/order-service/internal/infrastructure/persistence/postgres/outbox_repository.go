@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/order"
 	"github.com/jmoiron/sqlx"
 )
@@ -19,8 +22,27 @@ type OutboxRecord struct {
 	EventType     string    `db:"event_type"`
 	Payload       []byte    `db:"payload"` // Raw JSON bytes from DB
 	OccurredAt    time.Time `db:"occurred_at"`
+	RetryCount    int       `db:"retry_count"`
+	TraceContext  []byte    `db:"trace_context"` // W3C trace context as JSON, NULL if the writer had none
 }
 
+// ErrOutboxClaimLost means the claim on a record expired and another relay took it over
+var ErrOutboxClaimLost = errors.New("outbox record claim lost")
+
+// OutboxRepository stores domain events until the relay publishes them
+//
+//	ALTER TABLE outbox
+//		ADD COLUMN claimed_by TEXT,
+//		ADD COLUMN claimed_until TIMESTAMPTZ,
+//		ADD COLUMN retry_count INT NOT NULL DEFAULT 0,
+//		ADD COLUMN next_retry_at TIMESTAMPTZ,
+//		ADD COLUMN last_error TEXT;
+//	-- status is 'pending', 'published', or 'failed' once MaxRetries publishes have failed
+//	ALTER TABLE outbox DROP CONSTRAINT IF EXISTS outbox_status_check;
+//	ALTER TABLE outbox ADD CONSTRAINT outbox_status_check
+//		CHECK (status IN ('pending', 'published', 'failed'));
+//	CREATE INDEX outbox_pending_aggregate_idx ON outbox (aggregate_id, occurred_at, id)
+//		WHERE status = 'pending';
 type OutboxRepository struct {
 	db sqlx.ExtContext // Accepts both *sqlx.DB and *sqlx.Tx
 }
@@ -40,11 +62,19 @@ func (r *OutboxRepository) SaveEvent(ctx context.Context, aggregateID string, ev
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
+	// Store the caller's trace so the relay publishes the event in the same trace
+	var traceContext []byte
+	if carrier := tracing.Inject(ctx); carrier != nil {
+		if traceContext, err = json.Marshal(carrier); err != nil {
+			return fmt.Errorf("failed to marshal trace context: %w", err)
+		}
+	}
+
 	query := `
 		INSERT INTO outbox (
-			id, aggregate_type, aggregate_id, event_type, payload, occurred_at, status
+			id, aggregate_type, aggregate_id, event_type, payload, occurred_at, status, trace_context
 		) VALUES (
-			gen_random_uuid(), 'order', $1, $2, $3, $4, 'pending'
+			gen_random_uuid(), 'order', $1, $2, $3, $4, 'pending', $5
 		)
 	`
 	_, err = r.db.ExecContext(ctx, query,
@@ -52,6 +82,7 @@ func (r *OutboxRepository) SaveEvent(ctx context.Context, aggregateID string, ev
 		event.EventType(),
 		payload,
 		event.OccurredAt(),
+		traceContext,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert outbox record: %w", err)
@@ -59,27 +90,133 @@ func (r *OutboxRepository) SaveEvent(ctx context.Context, aggregateID string, ev
 	return nil
 }
 
-// FetchPending retrieves a batch of events that haven't been published yet
-func (r *OutboxRepository) FetchPending(ctx context.Context, limit int) ([]*OutboxRecord, error) {
+// ClaimPending claims a batch of due events that haven't been published yet.
+// SKIP LOCKED and the claimed_until lease keep concurrent relays off each other's rows;
+// rows whose lease expired (e.g. the relay crashed) are claimable again.
+// Only the oldest pending event of each aggregate is claimable, so an event waiting for a
+// retry holds back the later events of its order instead of being overtaken by them.
+func (r *OutboxRepository) ClaimPending(ctx context.Context, relayID string, lease time.Duration, limit int) ([]*OutboxRecord, error) {
 	query := `
-		SELECT id, event_type, aggregate_type, aggregate_id, payload, occurred_at
-		FROM outbox
-		WHERE status = 'pending'
-		ORDER BY occurred_at ASC
-		LIMIT $1
+		UPDATE outbox
+		SET claimed_by = $1, claimed_until = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT candidate.id
+			FROM outbox candidate
+			WHERE candidate.status = 'pending'
+			  AND (candidate.next_retry_at IS NULL OR candidate.next_retry_at <= NOW())
+			  AND (candidate.claimed_until IS NULL OR candidate.claimed_until < NOW())
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM outbox earlier
+				WHERE earlier.aggregate_id = candidate.aggregate_id
+				  AND earlier.status = 'pending'
+				  AND (earlier.occurred_at, earlier.id) < (candidate.occurred_at, candidate.id)
+			  )
+			ORDER BY candidate.occurred_at ASC, candidate.id ASC
+			LIMIT $3
+			FOR UPDATE OF candidate SKIP LOCKED
+		)
+		RETURNING id, event_type, aggregate_type, aggregate_id, payload, occurred_at, retry_count, trace_context
 	`
 
 	var records []*OutboxRecord
-	if err := sqlx.SelectContext(ctx, r.db, &records, query, limit); err != nil {
+	if err := sqlx.SelectContext(ctx, r.db, &records, query, relayID, lease.Milliseconds(), limit); err != nil {
 		return nil, err
 	}
 
+	// RETURNING gives no ordering guarantee
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].OccurredAt.Equal(records[j].OccurredAt) {
+			return records[i].OccurredAt.Before(records[j].OccurredAt)
+		}
+		return records[i].ID < records[j].ID
+	})
+
 	return records, nil
 }
 
-// MarkAsPublished updates the event status to prevent re-processing
-func (r *OutboxRepository) MarkAsPublished(ctx context.Context, eventID string) error {
-	query := `UPDATE outbox SET status = 'published', published_at = NOW() WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, eventID)
+// MarkAsPublished updates the event status to prevent re-processing and releases the claim
+func (r *OutboxRepository) MarkAsPublished(ctx context.Context, eventID string, relayID string) error {
+	query := `
+		UPDATE outbox
+		SET status = 'published', published_at = NOW(), claimed_by = NULL, claimed_until = NULL
+		WHERE id = $1 AND claimed_by = $2
+	`
+	result, err := r.db.ExecContext(ctx, query, eventID, relayID)
+	if err != nil {
+		return err
+	}
+	return claimResult(result.RowsAffected())
+}
+
+// MarkForRetry records a failed publish and schedules the next attempt with exponential backoff
+// (baseDelay * 2^retry_count, capped at maxDelay). After maxRetries failures the event is 'failed'.
+func (r *OutboxRepository) MarkForRetry(
+	ctx context.Context,
+	eventID string,
+	relayID string,
+	errorMsg string,
+	baseDelay time.Duration,
+	maxDelay time.Duration,
+	maxRetries int,
+) error {
+	query := `
+		UPDATE outbox
+		SET retry_count = retry_count + 1,
+		    last_error = $1,
+		    next_retry_at = NOW() + LEAST($2 * POW(2, retry_count), $3) * INTERVAL '1 millisecond',
+		    status = CASE WHEN retry_count + 1 >= $4 THEN 'failed' ELSE 'pending' END,
+		    claimed_by = NULL, claimed_until = NULL
+		WHERE id = $5 AND claimed_by = $6
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		errorMsg,
+		baseDelay.Milliseconds(),
+		maxDelay.Milliseconds(),
+		maxRetries,
+		eventID,
+		relayID,
+	)
+	if err != nil {
+		return err
+	}
+	return claimResult(result.RowsAffected())
+}
+
+// Backlog returns the number of events waiting to be relayed and the age of the oldest one
+func (r *OutboxRepository) Backlog(ctx context.Context) (int64, time.Duration, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(occurred_at)), 0)
+		FROM outbox
+		WHERE status = 'pending'
+	`
+
+	var count int64
+	var oldestAgeSeconds float64
+	if err := r.db.QueryRowxContext(ctx, query).Scan(&count, &oldestAgeSeconds); err != nil {
+		return 0, 0, fmt.Errorf("failed to query outbox backlog: %w", err)
+	}
+
+	return count, time.Duration(oldestAgeSeconds * float64(time.Second)), nil
+}
+
+// ReleaseClaims hands back the unfinished claims of a relay, e.g. on shutdown
+func (r *OutboxRepository) ReleaseClaims(ctx context.Context, relayID string) error {
+	query := `
+		UPDATE outbox
+		SET claimed_by = NULL, claimed_until = NULL
+		WHERE claimed_by = $1 AND status = 'pending'
+	`
+	_, err := r.db.ExecContext(ctx, query, relayID)
 	return err
 }
+
+func claimResult(rows int64, err error) error {
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrOutboxClaimLost
+	}
+	return nil
+}
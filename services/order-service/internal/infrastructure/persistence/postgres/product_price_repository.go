@@ -3,12 +3,23 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"errors"
+	"time"
 
 	productprice "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/product_price"
 	"github.com/jmoiron/sqlx"
 )
 
+// ProductPriceRepository stores the price history built from product price events
+//
+//	CREATE TABLE product_price_history (
+//		product_id TEXT NOT NULL,
+//		unit_price BIGINT NOT NULL,
+//		currency TEXT NOT NULL,
+//		effective_from TIMESTAMPTZ NOT NULL,
+//		updated_at TIMESTAMPTZ NOT NULL,
+//		UNIQUE (product_id, effective_from)
+//	);
 type ProductPriceRepository struct {
 	db sqlx.ExtContext
 }
@@ -17,30 +28,34 @@ func NewProductPriceRepository(db *sqlx.DB) *ProductPriceRepository {
 	return &ProductPriceRepository{db: db}
 }
 
-// GetByID retrieves the price for a specific product from local cache.
-func (r *ProductPriceRepository) GetByID(ctx context.Context, productID string) (*productprice.ProductPrice, error) {
-	query := `SELECT product_id, unit_price, currency, updated_at FROM product_prices WHERE product_id = $1`
+// FindEffectiveAt retrieves the latest price change at or before the given time from the local history.
+func (r *ProductPriceRepository) FindEffectiveAt(ctx context.Context, productID string, at time.Time) (*productprice.ProductPrice, error) {
+	query := `
+		SELECT product_id, unit_price, currency, effective_from, updated_at
+		FROM product_price_history
+		WHERE product_id = $1 AND effective_from <= $2
+		ORDER BY effective_from DESC
+		LIMIT 1
+	`
 
 	var p productprice.ProductPrice
-	err := sqlx.GetContext(ctx, r.db, &p, query, productID)
+	err := sqlx.GetContext(ctx, r.db, &p, query, productID, at)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product price not found in local cache: %s", productID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, productprice.ErrPriceNotFound
 		}
 		return nil, err
 	}
 	return &p, nil
 }
 
-// Upsert updates or inserts product pricing when receiving events from Product Service.
-func (r *ProductPriceRepository) Upsert(ctx context.Context, p *productprice.ProductPrice) error {
+// Record inserts a price change when receiving events from Product Service.
+// Redelivered events hit the (product_id, effective_from) key and are ignored.
+func (r *ProductPriceRepository) Record(ctx context.Context, p *productprice.ProductPrice) error {
 	query := `
-		INSERT INTO product_prices (product_id, unit_price, currency, updated_at)
-		VALUES (:product_id, :unit_price, :currency, :updated_at)
-		ON CONFLICT (product_id) DO UPDATE SET
-			unit_price = EXCLUDED.unit_price,
-			currency = EXCLUDED.currency,
-			updated_at = EXCLUDED.updated_at
+		INSERT INTO product_price_history (product_id, unit_price, currency, effective_from, updated_at)
+		VALUES (:product_id, :unit_price, :currency, :effective_from, :updated_at)
+		ON CONFLICT (product_id, effective_from) DO NOTHING
 	`
 	_, err := sqlx.NamedExecContext(ctx, r.db, query, p)
 	return err
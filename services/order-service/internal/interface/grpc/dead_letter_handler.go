@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/infrastructure/messaging/kafka"
+	pb "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/deadletter/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DeadLetterHandler serves the admin API over the order-service dead-letter topic
+type DeadLetterHandler struct {
+	pb.UnimplementedDeadLetterServiceServer
+	deadLetters *kafka.DeadLetterQueue
+}
+
+func NewDeadLetterHandler(deadLetters *kafka.DeadLetterQueue) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetters: deadLetters,
+	}
+}
+
+// ListDeadLetters pages through the dead-letter topic in offset order
+func (h *DeadLetterHandler) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	if req.FromOffset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "from_offset must not be negative")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 50 // Default page size
+	}
+	limit = min(limit, 500)
+
+	deadLetters, next, err := h.deadLetters.List(ctx, req.FromOffset, limit)
+	if err != nil {
+		zap.L().Error("failed to list dead letters", zap.Error(err))
+		return nil, status.Error(codes.Unavailable, "failed to read dead-letter topic")
+	}
+
+	resp := &pb.ListDeadLettersResponse{
+		DeadLetters: make([]*pb.DeadLetter, 0, len(deadLetters)),
+		NextOffset:  next,
+	}
+	for _, d := range deadLetters {
+		resp.DeadLetters = append(resp.DeadLetters, deadLetterToProto(d))
+	}
+
+	return resp, nil
+}
+
+// ReplayDeadLetter republishes a dead letter into the topic it was consumed from
+func (h *DeadLetterHandler) ReplayDeadLetter(ctx context.Context, req *pb.ReplayDeadLetterRequest) (*pb.ReplayDeadLetterResponse, error) {
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+
+	d, err := h.deadLetters.Replay(ctx, req.Offset)
+	if err != nil {
+		if errors.Is(err, kafka.ErrDeadLetterNotFound) {
+			return nil, status.Error(codes.NotFound, "dead letter not found")
+		}
+		zap.L().Error("failed to replay dead letter", zap.Int64("offset", req.Offset), zap.Error(err))
+		return nil, status.Error(codes.Unavailable, "failed to replay dead letter")
+	}
+
+	zap.L().Info("dead letter replayed",
+		zap.Int64("offset", req.Offset),
+		zap.String("original_topic", d.OriginalTopic),
+		zap.String("event_id", d.EventID),
+	)
+
+	return &pb.ReplayDeadLetterResponse{
+		DeadLetter: deadLetterToProto(d),
+	}, nil
+}
+
+func deadLetterToProto(d *kafka.DeadLetter) *pb.DeadLetter {
+	resp := &pb.DeadLetter{
+		Offset:            d.Offset,
+		OriginalTopic:     d.OriginalTopic,
+		OriginalPartition: int32(d.OriginalPartition),
+		OriginalOffset:    d.OriginalOffset,
+		ConsumerGroup:     d.ConsumerGroup,
+		EventId:           d.EventID,
+		EventType:         d.EventType,
+		Key:               string(d.Key),
+		Payload:           string(d.Value),
+		Error:             d.Error,
+		Attempts:          int32(d.Attempts),
+	}
+	if !d.FailedAt.IsZero() {
+		resp.FailedAt = timestamppb.New(d.FailedAt)
+	}
+	return resp
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"errors"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/domain/order"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mapDomainErrorToGRPC maps domain errors to gRPC status codes
+func mapDomainErrorToGRPC(err error) error {
+	// Order errors
+	if errors.Is(err, order.ErrOrderNotFound) {
+		return status.Error(codes.NotFound, "order not found")
+	}
+	if errors.Is(err, order.ErrOrderNotOwned) {
+		return status.Error(codes.PermissionDenied, "order does not belong to user")
+	}
+	if errors.Is(err, order.ErrOrderAlreadyPaid) {
+		return status.Error(codes.FailedPrecondition, "order already paid")
+	}
+	if errors.Is(err, order.ErrOrderAlreadyCancelled) {
+		return status.Error(codes.FailedPrecondition, "order already cancelled")
+	}
+	if errors.Is(err, order.ErrOrderExpired) {
+		return status.Error(codes.FailedPrecondition, "order has expired")
+	}
+	if errors.Is(err, order.ErrInvalidOrderStatus) {
+		return status.Error(codes.FailedPrecondition, "invalid order status")
+	}
+	if errors.Is(err, order.ErrEmptyOrderID) || errors.Is(err, order.ErrInvalidOrderIDFormat) {
+		return status.Error(codes.InvalidArgument, "invalid order id")
+	}
+	if errors.Is(err, order.ErrEmptyUserID) {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	// Payment errors
+	if errors.Is(err, order.ErrPaymentFailed) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, order.ErrInvalidPaymentSignature) {
+		return status.Error(codes.Unauthenticated, "invalid payment callback signature")
+	}
+	if errors.Is(err, order.ErrPaymentNotFound) || errors.Is(err, order.ErrPaymentMismatch) {
+		return status.Error(codes.FailedPrecondition, "payment does not match order")
+	}
+	if errors.Is(err, order.ErrPaymentAlreadyCompleted) ||
+		errors.Is(err, order.ErrCannotCompleteFailedPayment) ||
+		errors.Is(err, order.ErrCannotFailCompletedPayment) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, order.ErrInvalidPaymentMethod) {
+		return status.Error(codes.InvalidArgument, "invalid payment method")
+	}
+	if errors.Is(err, order.ErrInvalidPaymentStatus) {
+		return status.Error(codes.InvalidArgument, "invalid payment status")
+	}
+	if errors.Is(err, order.ErrEmptyPaymentID) {
+		return status.Error(codes.InvalidArgument, "invalid payment id")
+	}
+
+	return status.Error(codes.Internal, "internal server error")
+}
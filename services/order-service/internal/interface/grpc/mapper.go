@@ -10,7 +10,7 @@ import (
 func domainOrderToProto(o *order.Order) *orderv1.OrderResponse {
 	pricing := o.Pricing()
 
-	return &orderv1.OrderResponse{
+	resp := &orderv1.OrderResponse{
 		OrderId:       o.ID().String(),
 		ReservationId: o.ReservationID().String(),
 		UserId:        o.UserID().String(),
@@ -29,4 +29,11 @@ func domainOrderToProto(o *order.Order) *orderv1.OrderResponse {
 		ExpiresAt: o.ExpiresAt().Unix(),
 		UpdatedAt: o.UpdatedAt().Unix(),
 	}
+
+	if payment := o.Payment(); payment != nil {
+		resp.PaymentId = payment.ID().String()
+		resp.PaymentStatus = string(payment.Status())
+	}
+
+	return resp
 }
@@ -64,3 +64,66 @@ func (h *OrderHandler) ListUserOrders(ctx context.Context, req *pb.ListUserOrder
 
 	return resp, nil
 }
+
+// PayOrder charges the authenticated buyer for a pending order
+func (h *OrderHandler) PayOrder(ctx context.Context, req *pb.PayOrderRequest) (*pb.OrderResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	o, err := h.service.PayOrder(ctx, req.OrderId, req.UserId, req.PaymentMethod)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	return domainOrderToProto(o), nil
+}
+
+// HandlePaymentCallback applies an asynchronous settlement from the payment provider
+func (h *OrderHandler) HandlePaymentCallback(ctx context.Context, req *pb.PaymentCallbackRequest) (*pb.OrderResponse, error) {
+	if req.OrderId == "" || req.PaymentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id and payment_id are required")
+	}
+	if req.Signature == "" {
+		return nil, status.Error(codes.Unauthenticated, "signature is required")
+	}
+
+	paymentStatus, err := order.ParsePaymentStatus(req.Status)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	o, err := h.service.HandlePaymentCallback(ctx, order.PaymentCallback{
+		OrderID:       req.OrderId,
+		PaymentID:     req.PaymentId,
+		TransactionID: req.TransactionId,
+		Status:        paymentStatus,
+		FailureReason: req.FailureReason,
+		Signature:     req.Signature,
+	})
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	return domainOrderToProto(o), nil
+}
+
+// CancelOrder cancels a pending order on behalf of its owner
+func (h *OrderHandler) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.OrderResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	o, err := h.service.CancelOrder(ctx, req.OrderId, req.UserId, req.Reason)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	return domainOrderToProto(o), nil
+}
@@ -5,7 +5,9 @@ import (
 	"net"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/order-service/internal/config"
+	deadletterpb "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/deadletter/v1"
 	pb "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/order/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -15,11 +17,16 @@ type Server struct {
 	config     *config.GRPCConfig
 }
 
-func NewServer(cfg *config.GRPCConfig, handler *OrderHandler) *Server {
+func NewServer(cfg *config.GRPCConfig, handler *OrderHandler, deadLetterHandler *DeadLetterHandler) *Server {
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(UnaryServerInterceptor()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			UnaryServerInterceptor(),
+			AuthorizationInterceptor(cfg.Server.IdentitySecret),
+		),
 	)
 	pb.RegisterOrderServiceServer(s, handler)
+	deadletterpb.RegisterDeadLetterServiceServer(s, deadLetterHandler)
 
 	// Enable reflection for debugging tools (e.g., Evans, Postman)
 	reflection.Register(s)
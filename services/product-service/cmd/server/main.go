@@ -2,17 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/application/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/application/worker"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/infrastructure/messaging/kafka"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/infrastructure/persistence/postgres"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/infrastructure/storage/local"
 	grpcserver "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/interface/grpc"
 
 	"github.com/jmoiron/sqlx"
@@ -44,6 +50,16 @@ func main() {
 		zap.String("environment", cfg.Logger.Environment),
 	)
 
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(&cfg.Tracing, cfg.ServiceName)
+	if err != nil {
+		log.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	// Initialize metrics
+	metrics.Init(cfg.ServiceName)
+	metricsServer := metrics.NewServer(&cfg.Metrics)
+
 	// Initialize database
 	db, err := initDatabase(&cfg.Database)
 	if err != nil {
@@ -56,9 +72,18 @@ func main() {
 	productRepo := postgres.NewProductRepository(db)
 	productWriter := postgres.NewProductWriter(db)
 	outboxRepo := postgres.NewOutboxRepository(db)
+	categoryRepo := postgres.NewCategoryRepository(db)
+
+	// Initialize blob storage
+	blobStorage, err := local.NewBlobStorage(&cfg.Storage)
+	if err != nil {
+		log.Error("failed to initialize blob storage", zap.Error(err))
+		os.Exit(1)
+	}
 
 	// Initialize application services
-	productService := service.NewProductService(productRepo, productWriter)
+	productService := service.NewProductService(productRepo, productWriter, categoryRepo, blobStorage)
+	categoryService := service.NewCategoryService(categoryRepo)
 
 	// Initialize Kafka producer
 	producer := kafka.NewProducer(&cfg.Kafka)
@@ -67,14 +92,18 @@ func main() {
 	// Initialize workers
 	outboxRelay := worker.NewOutboxRelay(outboxRepo, producer, &cfg.Outbox)
 	snapshotJobWorker := worker.NewSnapshotJob(productRepo, outboxRepo, cfg.Kafka.Brokers, cfg.Kafka.ProducerTopic, &cfg.Snapshot)
+	saleScheduler := worker.NewSaleScheduler(productRepo, productWriter, &cfg.Sales)
 
 	// Initialize Kafka consumer
+	deadLetterQueue := kafka.NewDeadLetterQueue(&cfg.Kafka)
+	defer deadLetterQueue.Close()
 	stockEventHandler := kafka.NewStockEventHandler(productService)
-	consumer := kafka.NewConsumer(&cfg.Kafka, stockEventHandler)
+	consumer := kafka.NewConsumer(&cfg.Kafka, stockEventHandler, deadLetterQueue)
 	defer consumer.Close()
 
 	// Initialize gRPC server
-	grpcServer := grpcserver.NewServer(&cfg.Server, productService)
+	deadLetterHandler := grpcserver.NewDeadLetterHandler(deadLetterQueue)
+	grpcServer := grpcserver.NewServer(&cfg.Server, productService, categoryService, deadLetterHandler)
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -102,6 +131,13 @@ func main() {
 		}
 	}()
 
+	go func() {
+		zap.L().Info("starting sale scheduler worker")
+		if err := saleScheduler.Start(ctx); err != nil && ctx.Err() == nil {
+			zap.L().Error("sale scheduler error", zap.Error(err))
+		}
+	}()
+
 	go func() {
 		zap.L().Info("starting kafka consumer")
 		if err := consumer.Start(ctx); err != nil && ctx.Err() == nil {
@@ -109,6 +145,16 @@ func main() {
 		}
 	}()
 
+	// Start metrics server
+	go func() {
+		zap.L().Info("starting metrics server",
+			zap.Int("port", cfg.Metrics.Port),
+		)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Error("metrics server error", zap.Error(err))
+		}
+	}()
+
 	// Start gRPC server
 	go func() {
 		zap.L().Info("starting grpc server",
@@ -133,6 +179,20 @@ func main() {
 	// Stop gRPC server
 	grpcServer.Stop()
 
+	// Stop metrics server
+	metricsCtx, metricsCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer metricsCancel()
+	if err := metricsServer.Shutdown(metricsCtx); err != nil {
+		zap.L().Warn("failed to stop metrics server", zap.Error(err))
+	}
+
+	// Flush pending spans
+	tracingCtx, tracingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer tracingCancel()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		zap.L().Warn("failed to flush traces", zap.Error(err))
+	}
+
 	zap.L().Info("server stopped")
 }
 
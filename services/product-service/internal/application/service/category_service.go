@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/domain/product"
+)
+
+// CategoryService handles category use cases
+type CategoryService struct {
+	categoryRepo product.CategoryRepository
+}
+
+// NewCategoryService creates a new CategoryService
+func NewCategoryService(categoryRepo product.CategoryRepository) *CategoryService {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// CreateCategory creates a category; an empty parentID creates a root category
+func (s *CategoryService) CreateCategory(ctx context.Context, name string, parentID string) (*product.Category, error) {
+	var parent *product.Category
+	if parentID != "" {
+		pid, err := product.ParseCategoryID(parentID)
+		if err != nil {
+			return nil, err
+		}
+
+		parent, err = s.categoryRepo.FindByID(ctx, pid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := product.NewCategory(name, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.categoryRepo.Save(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to save category: %w", err)
+	}
+
+	return c, nil
+}
+
+// ListCategories lists all categories
+func (s *CategoryService) ListCategories(ctx context.Context) ([]*product.Category, error) {
+	return s.categoryRepo.FindAll(ctx)
+}
@@ -2,26 +2,36 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/logger"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/domain/product"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/infrastructure/persistence/postgres"
+	"go.uber.org/zap"
 )
 
 // ProductService handles product use cases
 type ProductService struct {
 	productRepo         product.Repository
 	productTxRepository *postgres.ProductTxRepository
+	categoryRepo        product.CategoryRepository
+	blobStorage         product.BlobStorage
 }
 
 // NewProductService creates a new ProductService
 func NewProductService(
 	productRepo product.Repository,
 	productTxRepository *postgres.ProductTxRepository,
+	categoryRepo product.CategoryRepository,
+	blobStorage product.BlobStorage,
 ) *ProductService {
 	return &ProductService{
 		productRepo:         productRepo,
 		productTxRepository: productTxRepository,
+		categoryRepo:        categoryRepo,
+		blobStorage:         blobStorage,
 	}
 }
 
@@ -34,6 +44,8 @@ func (s *ProductService) CreateProduct(
 	regularPrice int64,
 	flashSalePrice *int64,
 	currency string,
+	priceType product.PriceType,
+	purchaseLimit int,
 ) (*product.Product, error) {
 	sid, err := product.ParseSellerID(sellerID)
 	if err != nil {
@@ -45,21 +57,9 @@ func (s *ProductService) CreateProduct(
 		return nil, fmt.Errorf("invalid regular price: %w", err)
 	}
 
-	var pricing product.Pricing
-	if flashSalePrice != nil {
-		flashMoney, err := product.NewMoney(*flashSalePrice, currency)
-		if err != nil {
-			return nil, fmt.Errorf("invalid flash sale price: %w", err)
-		}
-		pricing, err = product.NewPricingWithFlashSale(regularMoney, flashMoney)
-		if err != nil {
-			return nil, fmt.Errorf("invalid pricing: %w", err)
-		}
-	} else {
-		pricing, err = product.NewPricing(regularMoney)
-		if err != nil {
-			return nil, fmt.Errorf("invalid pricing: %w", err)
-		}
+	pricing, err := buildPricing(priceType, regularMoney, flashSalePrice, currency)
+	if err != nil {
+		return nil, err
 	}
 
 	p, err := product.NewProduct(sid, name, description, pricing)
@@ -67,6 +67,10 @@ func (s *ProductService) CreateProduct(
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
 
+	if err := p.UpdatePurchaseLimit(purchaseLimit); err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+
 	// Use productTxRepository (handles transaction + events)
 	if err := s.productTxRepository.Save(ctx, p); err != nil {
 		return nil, fmt.Errorf("failed to save product: %w", err)
@@ -199,28 +203,410 @@ func (s *ProductService) UpdateProductPricing(
 		return fmt.Errorf("invalid regular price: %w", err)
 	}
 
-	var pricing product.Pricing
-	if flashSalePrice != nil {
-		flashMoney, err := product.NewMoney(*flashSalePrice, currency)
+	pricing, err := buildPricing(p.Pricing().PriceType(), regularMoney, flashSalePrice, currency)
+	if err != nil {
+		return err
+	}
+
+	if err := p.UpdatePricing(pricing); err != nil {
+		return fmt.Errorf("failed to update pricing: %w", err)
+	}
+
+	// Use productTxRepository (handles transaction + product.price_updated)
+	if err := s.productTxRepository.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProductPurchaseLimit updates the per-user purchase limit of a product
+func (s *ProductService) UpdateProductPurchaseLimit(
+	ctx context.Context,
+	productID string,
+	purchaseLimit int,
+) error {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product id: %w", err)
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+
+	if err := p.UpdatePurchaseLimit(purchaseLimit); err != nil {
+		return fmt.Errorf("failed to update purchase limit: %w", err)
+	}
+
+	// Use ProductRepository (no events, the limit is published with product.published)
+	if err := s.productRepo.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	return nil
+}
+
+// ScheduleProductSale sets the sale window of a product.
+// The sale scheduler publishes and deactivates the product when the window opens and closes.
+func (s *ProductService) ScheduleProductSale(
+	ctx context.Context,
+	productID string,
+	startsAt *time.Time,
+	endsAt *time.Time,
+) error {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product id: %w", err)
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+
+	window, err := product.NewSaleWindow(startsAt, endsAt)
+	if err != nil {
+		return fmt.Errorf("invalid sale window: %w", err)
+	}
+
+	if err := p.ScheduleSale(window); err != nil {
+		return fmt.Errorf("failed to schedule sale: %w", err)
+	}
+
+	// Use ProductRepository (no events, the window is published with product.published)
+	if err := s.productRepo.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProductClassification sets the category and tags of a product.
+// An empty categoryID removes the product from its category.
+func (s *ProductService) UpdateProductClassification(
+	ctx context.Context,
+	productID string,
+	categoryID string,
+	tags []string,
+) error {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product id: %w", err)
+	}
+
+	var cid *product.CategoryID
+	if categoryID != "" {
+		id, err := product.ParseCategoryID(categoryID)
 		if err != nil {
-			return fmt.Errorf("invalid flash sale price: %w", err)
+			return err
 		}
-		pricing, err = product.NewPricingWithFlashSale(regularMoney, flashMoney)
-		if err != nil {
-			return fmt.Errorf("invalid pricing: %w", err)
+		if _, err := s.categoryRepo.FindByID(ctx, id); err != nil {
+			return err
 		}
-	} else {
-		pricing, err = product.NewPricing(regularMoney)
-		if err != nil {
-			return fmt.Errorf("invalid pricing: %w", err)
+		cid = &id
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+
+	if err := p.Classify(cid, tags); err != nil {
+		return fmt.Errorf("failed to classify product: %w", err)
+	}
+
+	// Use ProductRepository (no events, classification only affects listings)
+	if err := s.productRepo.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	return nil
+}
+
+// AddProductImage stores an image in blob storage and appends it to the product
+func (s *ProductService) AddProductImage(
+	ctx context.Context,
+	productID string,
+	contentType string,
+	data []byte,
+) (product.Image, error) {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return product.Image{}, fmt.Errorf("invalid product id: %w", err)
+	}
+
+	ext, err := product.ImageExtension(contentType)
+	if err != nil {
+		return product.Image{}, err
+	}
+	if len(data) > product.MaxImageSize {
+		return product.Image{}, product.ErrImageTooLarge
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return product.Image{}, fmt.Errorf("product not found: %w", err)
+	}
+
+	imageID := product.NewImageID()
+	key := fmt.Sprintf("products/%s/%s%s", pid, imageID, ext)
+	image := product.NewImage(imageID, key, s.blobStorage.URL(key), contentType)
+
+	// Validate against the aggregate before uploading
+	if err := p.AddImage(image); err != nil {
+		return product.Image{}, fmt.Errorf("failed to add image: %w", err)
+	}
+
+	if err := s.blobStorage.Put(ctx, key, contentType, data); err != nil {
+		return product.Image{}, fmt.Errorf("failed to store image: %w", err)
+	}
+
+	if err := s.productRepo.Save(ctx, p); err != nil {
+		// Best effort: the blob is unreferenced without the product row
+		if delErr := s.blobStorage.Delete(ctx, key); delErr != nil {
+			logger.WarnContext(ctx, "failed to delete orphaned image",
+				zap.String("key", key),
+				zap.Error(delErr),
+			)
 		}
+		return product.Image{}, fmt.Errorf("failed to save product: %w", err)
 	}
 
-	if err := p.UpdatePricing(pricing); err != nil {
-		return fmt.Errorf("failed to update pricing: %w", err)
+	return image, nil
+}
+
+// RemoveProductImage removes an image from the product and deletes its blob
+func (s *ProductService) RemoveProductImage(ctx context.Context, productID string, imageID string) error {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product id: %w", err)
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+
+	image, err := p.RemoveImage(product.ImageID(imageID))
+	if err != nil {
+		return fmt.Errorf("failed to remove image: %w", err)
+	}
+
+	if err := s.productRepo.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	// Delete after saving: a leftover blob is harmless, a dangling reference is not
+	if err := s.blobStorage.Delete(ctx, image.StorageKey()); err != nil {
+		logger.WarnContext(ctx, "failed to delete removed image",
+			zap.String("key", image.StorageKey()),
+			zap.Error(err),
+		)
+	}
+
+	return nil
+}
+
+// ReorderProductImages sets the display order of the product's images
+func (s *ProductService) ReorderProductImages(ctx context.Context, productID string, imageIDs []string) error {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product id: %w", err)
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+
+	ids := make([]product.ImageID, 0, len(imageIDs))
+	for _, id := range imageIDs {
+		ids = append(ids, product.ImageID(id))
+	}
+
+	if err := p.ReorderImages(ids); err != nil {
+		return fmt.Errorf("failed to reorder images: %w", err)
+	}
+
+	if err := s.productRepo.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	return nil
+}
+
+// AddProductVariant adds a variant to the product; the price override is in the product's currency
+func (s *ProductService) AddProductVariant(
+	ctx context.Context,
+	productID string,
+	sku string,
+	attributes map[string]string,
+	priceOverride *int64,
+) (product.Variant, error) {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return product.Variant{}, fmt.Errorf("invalid product id: %w", err)
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return product.Variant{}, fmt.Errorf("product not found: %w", err)
+	}
+
+	override, err := variantPriceOverride(p, priceOverride)
+	if err != nil {
+		return product.Variant{}, err
+	}
+
+	variant, err := product.NewVariant(sku, attributes, override)
+	if err != nil {
+		return product.Variant{}, err
+	}
+
+	if err := p.AddVariant(variant); err != nil {
+		return product.Variant{}, fmt.Errorf("failed to add variant: %w", err)
+	}
+
+	// Use ProductRepository (stock-service learns the variants from the next product.published)
+	if err := s.productRepo.Save(ctx, p); err != nil {
+		return product.Variant{}, fmt.Errorf("failed to save product: %w", err)
+	}
+
+	return variant, nil
+}
+
+// UpdateProductVariant replaces a variant's attributes and price override (nil removes the override)
+func (s *ProductService) UpdateProductVariant(
+	ctx context.Context,
+	productID string,
+	variantID string,
+	attributes map[string]string,
+	priceOverride *int64,
+) error {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product id: %w", err)
+	}
+
+	vid, err := product.ParseVariantID(variantID)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+
+	override, err := variantPriceOverride(p, priceOverride)
+	if err != nil {
+		return err
+	}
+
+	if err := p.UpdateVariant(vid, attributes, override); err != nil {
+		return fmt.Errorf("failed to update variant: %w", err)
+	}
+
+	if err := s.productRepo.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveProductVariant removes a variant from the product
+func (s *ProductService) RemoveProductVariant(ctx context.Context, productID string, variantID string) error {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product id: %w", err)
+	}
+
+	vid, err := product.ParseVariantID(variantID)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+
+	if err := p.RemoveVariant(vid); err != nil {
+		return fmt.Errorf("failed to remove variant: %w", err)
+	}
+
+	if err := s.productRepo.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStockStatus records the stock status reported by stock-service for a product,
+// or for one of its variants when variantID is set. A product whose variants have all
+// run out is marked as sold out.
+func (s *ProductService) UpdateStockStatus(
+	ctx context.Context,
+	productID string,
+	variantID string,
+	status product.StockStatus,
+) error {
+	pid, err := product.ParseProductID(productID)
+	if err != nil {
+		return fmt.Errorf("invalid product id: %w", err)
+	}
+
+	p, err := s.productRepo.FindByID(ctx, pid)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+
+	if variantID == "" {
+		// The status of a product with variants is aggregated from them
+		if p.HasVariants() {
+			logger.WarnContext(ctx, "ignoring product-level stock status of a product with variants",
+				zap.String("product_id", productID),
+				zap.String("stock_status", string(status)),
+			)
+			return nil
+		}
+		p.UpdateStockStatus(status)
+		if err := s.productRepo.Save(ctx, p); err != nil {
+			return fmt.Errorf("failed to save product: %w", err)
+		}
+		return nil
+	}
+
+	vid, err := product.ParseVariantID(variantID)
+	if err != nil {
+		return err
+	}
+
+	if err := p.UpdateVariantStockStatus(vid, status); err != nil {
+		if errors.Is(err, product.ErrVariantNotFound) {
+			// The variant was removed after the event was published
+			logger.WarnContext(ctx, "stock status for unknown variant, skipping",
+				zap.String("product_id", productID),
+				zap.String("variant_id", variantID),
+			)
+			return nil
+		}
+		return err
+	}
+
+	if p.StockStatus() == product.StockStatusOutOfStock && p.Status().CanMarkAsSoldOut() {
+		if err := p.MarkAsSoldOut(); err != nil {
+			return fmt.Errorf("cannot mark as sold out: %w", err)
+		}
+		// Use productTxRepository (handles transaction + product.sold_out)
+		if err := s.productTxRepository.Save(ctx, p); err != nil {
+			return fmt.Errorf("failed to save product: %w", err)
+		}
+		return nil
 	}
 
-	// Use ProductRepository (no events)
 	if err := s.productRepo.Save(ctx, p); err != nil {
 		return fmt.Errorf("failed to save product: %w", err)
 	}
@@ -302,3 +688,76 @@ func (s *ProductService) GetActiveProducts(
 
 	return products, nil
 }
+
+// SearchProducts searches active products and returns one page of results with the
+// cursor of the next page (nil on the last page)
+func (s *ProductService) SearchProducts(
+	ctx context.Context,
+	criteria product.SearchCriteria,
+) ([]*product.Product, *product.SearchCursor, error) {
+	// Fetch one extra product to tell whether another page follows
+	probe := criteria
+	probe.Limit++
+
+	products, err := s.productRepo.Search(ctx, probe)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search products: %w", err)
+	}
+
+	if len(products) <= criteria.Limit {
+		return products, nil, nil
+	}
+
+	products = products[:criteria.Limit]
+	next := product.NewSearchCursor(criteria, products[len(products)-1])
+	return products, &next, nil
+}
+
+// variantPriceOverride creates the price override of a variant in the product's currency
+func variantPriceOverride(p *product.Product, amount *int64) (*product.Money, error) {
+	if amount == nil {
+		return nil, nil
+	}
+	money, err := product.NewMoney(*amount, p.Pricing().RegularPrice().Currency())
+	if err != nil {
+		return nil, fmt.Errorf("invalid price override: %w", err)
+	}
+	return &money, nil
+}
+
+// buildPricing creates the pricing value object for the given price type
+func buildPricing(
+	priceType product.PriceType,
+	regularMoney product.Money,
+	flashSalePrice *int64,
+	currency string,
+) (product.Pricing, error) {
+	if priceType == product.PriceTypeAuction {
+		if flashSalePrice != nil {
+			return product.Pricing{}, product.ErrAuctionFlashSaleNotAllowed
+		}
+		pricing, err := product.NewAuctionPricing(regularMoney)
+		if err != nil {
+			return product.Pricing{}, fmt.Errorf("invalid pricing: %w", err)
+		}
+		return pricing, nil
+	}
+
+	if flashSalePrice != nil {
+		flashMoney, err := product.NewMoney(*flashSalePrice, currency)
+		if err != nil {
+			return product.Pricing{}, fmt.Errorf("invalid flash sale price: %w", err)
+		}
+		pricing, err := product.NewPricingWithFlashSale(regularMoney, flashMoney)
+		if err != nil {
+			return product.Pricing{}, fmt.Errorf("invalid pricing: %w", err)
+		}
+		return pricing, nil
+	}
+
+	pricing, err := product.NewPricing(regularMoney)
+	if err != nil {
+		return product.Pricing{}, fmt.Errorf("invalid pricing: %w", err)
+	}
+	return pricing, nil
+}
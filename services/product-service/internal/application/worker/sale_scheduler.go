@@ -0,0 +1,142 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/config"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/domain/product"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/infrastructure/persistence/postgres"
+	"go.uber.org/zap"
+)
+
+// SaleScheduler publishes products when their sale window opens and deactivates
+// them when it closes. Transitions are saved with their events through the outbox.
+// Due products are claimed before they are transitioned, so each replica works a
+// disjoint batch and every transition is published once.
+type SaleScheduler struct {
+	productRepo         product.Repository
+	productTxRepository *postgres.ProductTxRepository
+	interval            time.Duration
+	batchSize           int
+	claimTTL            time.Duration
+}
+
+// NewSaleScheduler creates a new sale scheduler
+func NewSaleScheduler(
+	productRepo product.Repository,
+	productTxRepository *postgres.ProductTxRepository,
+	cfg *config.SaleSchedulerConfig,
+) *SaleScheduler {
+	return &SaleScheduler{
+		productRepo:         productRepo,
+		productTxRepository: productTxRepository,
+		interval:            cfg.Interval,
+		batchSize:           cfg.BatchSize,
+		claimTTL:            cfg.ClaimTTL,
+	}
+}
+
+// Start starts the sale scheduler
+func (s *SaleScheduler) Start(ctx context.Context) error {
+	zap.L().Info("starting sale scheduler",
+		zap.Duration("interval", s.interval),
+		zap.Int("batch_size", s.batchSize),
+	)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Catch up on transitions missed while the service was down
+	s.tick(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.tick(ctx)
+
+		case <-ctx.Done():
+			zap.L().Info("sale scheduler stopping")
+			return nil
+		}
+	}
+}
+
+// tick runs one scheduling pass. Ends are processed before starts so a product
+// whose window already closed is never published.
+func (s *SaleScheduler) tick(ctx context.Context) {
+	now := time.Now()
+
+	if err := s.endDueSales(ctx, now); err != nil {
+		zap.L().Error("failed to end due sales", zap.Error(err))
+	}
+
+	if err := s.startDueSales(ctx, now); err != nil {
+		zap.L().Error("failed to start due sales", zap.Error(err))
+	}
+}
+
+// startDueSales publishes products whose sale window has opened
+func (s *SaleScheduler) startDueSales(ctx context.Context, now time.Time) error {
+	products, err := s.productRepo.ClaimDueToStart(ctx, now, now.Add(s.claimTTL), s.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to claim products due to start: %w", err)
+	}
+
+	for _, p := range products {
+		if err := p.StartScheduledSale(now); err != nil {
+			zap.L().Warn("skipping scheduled sale start",
+				zap.String("product_id", p.ID().String()),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if err := s.productTxRepository.Save(ctx, p); err != nil {
+			zap.L().Error("failed to save scheduled sale start",
+				zap.String("product_id", p.ID().String()),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		zap.L().Info("scheduled sale started",
+			zap.String("product_id", p.ID().String()),
+		)
+	}
+
+	return nil
+}
+
+// endDueSales deactivates products whose sale window has closed
+func (s *SaleScheduler) endDueSales(ctx context.Context, now time.Time) error {
+	products, err := s.productRepo.ClaimDueToEnd(ctx, now, now.Add(s.claimTTL), s.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to claim products due to end: %w", err)
+	}
+
+	for _, p := range products {
+		if err := p.EndScheduledSale(now); err != nil {
+			zap.L().Warn("skipping scheduled sale end",
+				zap.String("product_id", p.ID().String()),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if err := s.productTxRepository.Save(ctx, p); err != nil {
+			zap.L().Error("failed to save scheduled sale end",
+				zap.String("product_id", p.ID().String()),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		zap.L().Info("scheduled sale ended",
+			zap.String("product_id", p.ID().String()),
+		)
+	}
+
+	return nil
+}
@@ -78,8 +78,27 @@ func (j *SnapshotJob) generateSnapshot(ctx context.Context) error {
 
 	// Extract product IDs
 	activeProductIDs := make([]string, 0, len(products))
+	auctionProductIDs := make([]string, 0)
+	purchaseLimits := make(map[string]int)
+	saleWindows := make(map[string]product.SaleWindow)
+	prices := make(map[string]product.Money)
+	variants := make(map[string][]product.Variant)
 	for _, p := range products {
 		activeProductIDs = append(activeProductIDs, p.ID().String())
+		if p.Pricing().IsAuction() {
+			auctionProductIDs = append(auctionProductIDs, p.ID().String())
+		} else {
+			prices[p.ID().String()] = p.Pricing().CurrentPrice()
+		}
+		if p.HasPurchaseLimit() {
+			purchaseLimits[p.ID().String()] = p.PurchaseLimit()
+		}
+		if p.SaleWindow().IsScheduled() {
+			saleWindows[p.ID().String()] = p.SaleWindow()
+		}
+		if p.HasVariants() {
+			variants[p.ID().String()] = p.Variants()
+		}
 	}
 
 	zap.L().Info("active products collected",
@@ -102,6 +121,11 @@ func (j *SnapshotJob) generateSnapshot(ctx context.Context) error {
 	now := time.Now()
 	snapshotEvent := product.NewProductSnapshotEvent(
 		activeProductIDs,
+		auctionProductIDs,
+		purchaseLimits,
+		saleWindows,
+		prices,
+		variants,
 		partitionOffsets,
 		now,
 	)
@@ -112,6 +136,31 @@ func (j *SnapshotJob) generateSnapshot(ctx context.Context) error {
 		offsetsMap[fmt.Sprintf("%d", partID)] = offset
 	}
 
+	windowsMap := make(map[string]interface{}, len(snapshotEvent.SaleWindows))
+	for productID, window := range snapshotEvent.SaleWindows {
+		entry := make(map[string]string, 2)
+		if startsAt := window.StartsAt(); startsAt != nil {
+			entry["starts_at"] = startsAt.Format(time.RFC3339)
+		}
+		if endsAt := window.EndsAt(); endsAt != nil {
+			entry["ends_at"] = endsAt.Format(time.RFC3339)
+		}
+		windowsMap[productID] = entry
+	}
+
+	pricesMap := make(map[string]interface{}, len(snapshotEvent.Prices))
+	for productID, price := range snapshotEvent.Prices {
+		pricesMap[productID] = map[string]interface{}{
+			"amount":   price.Amount(),
+			"currency": price.Currency(),
+		}
+	}
+
+	variantsMap := make(map[string]interface{}, len(snapshotEvent.Variants))
+	for productID, productVariants := range snapshotEvent.Variants {
+		variantsMap[productID] = postgres.VariantsToPayload(productVariants)
+	}
+
 	// Step 5: Create outbox event
 	outboxEvent := postgres.NewOutboxEvent(
 		"product",
@@ -119,6 +168,11 @@ func (j *SnapshotJob) generateSnapshot(ctx context.Context) error {
 		snapshotEvent.EventType(),
 		map[string]interface{}{
 			"active_products":   snapshotEvent.ActiveProducts,
+			"auction_products":  snapshotEvent.AuctionProducts,
+			"purchase_limits":   snapshotEvent.PurchaseLimits,
+			"sale_windows":      windowsMap,
+			"prices":            pricesMap,
+			"variants":          variantsMap,
 			"partition_offsets": offsetsMap,
 			"total":             snapshotEvent.Total,
 			"occurred_at":       snapshotEvent.OccurredAt().Format(time.RFC3339),
@@ -17,7 +17,11 @@ type Config struct {
 	Kafka    KafkaConfig
 	Outbox   OutboxConfig
 	Logger   LoggerConfig
+	Tracing  TracingConfig
+	Metrics  MetricsConfig
 	Snapshot SnapshotConfig
+	Sales    SaleSchedulerConfig
+	Storage  StorageConfig
 }
 
 // Load loads configuration from environment variables
@@ -30,7 +34,11 @@ func Load() (*Config, error) {
 		Kafka:       loadKafkaConfig(),
 		Outbox:      loadOutboxConfig(),
 		Logger:      loadLoggerConfig(),
+		Tracing:     loadTracingConfig(),
+		Metrics:     loadMetricsConfig(),
 		Snapshot:    loadSnapshotConfig(),
+		Sales:       loadSaleSchedulerConfig(),
+		Storage:     loadStorageConfig(),
 	}
 
 	// Validate configuration
@@ -52,6 +60,21 @@ func (c *Config) Validate() error {
 	if err := c.Kafka.Validate(); err != nil {
 		return fmt.Errorf("kafka config: %w", err)
 	}
+	if err := c.Outbox.Validate(); err != nil {
+		return fmt.Errorf("outbox config: %w", err)
+	}
+	if err := c.Tracing.Validate(); err != nil {
+		return fmt.Errorf("tracing config: %w", err)
+	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics config: %w", err)
+	}
+	if err := c.Storage.Validate(); err != nil {
+		return fmt.Errorf("storage config: %w", err)
+	}
+	if err := c.Sales.Validate(); err != nil {
+		return fmt.Errorf("sale scheduler config: %w", err)
+	}
 	return nil
 }
 
@@ -63,6 +86,15 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// mustEnv gets a required environment variable, such as a secret with no safe default
+func mustEnv(key string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		panic("environment variable " + key + " is required")
+	}
+	return value
+}
+
 // getEnvInt gets environment variable as int with default value
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
@@ -73,6 +105,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat gets environment variable as float64 with default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // getEnvDuration gets environment variable as duration with default value
 func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
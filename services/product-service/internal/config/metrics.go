@@ -0,0 +1,23 @@
+package config
+
+import "fmt"
+
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	Port int // HTTP port serving /metrics
+}
+
+// loadMetricsConfig loads metrics configuration
+func loadMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		Port: getEnvInt("METRICS_PORT", 9102),
+	}
+}
+
+// Validate validates metrics configuration
+func (c *MetricsConfig) Validate() error {
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid metrics port: %d", c.Port)
+	}
+	return nil
+}
@@ -1,20 +1,50 @@
 package config
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // OutboxConfig holds outbox relay configuration
 type OutboxConfig struct {
-	BatchSize     int
-	PollInterval  time.Duration
-	CleanupAge    time.Duration
-	CleanupPeriod time.Duration
+	BatchSize      int
+	PollInterval   time.Duration
+	ClaimLease     time.Duration // how long a relay owns a claimed batch
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	CleanupAge     time.Duration
+	CleanupPeriod  time.Duration
 }
 
 func loadOutboxConfig() OutboxConfig {
 	return OutboxConfig{
-		BatchSize:     getEnvInt("OUTBOX_BATCH_SIZE", 100),
-		PollInterval:  getEnvDuration("OUTBOX_POLL_INTERVAL", 1*time.Second),
-		CleanupAge:    getEnvDuration("OUTBOX_CLEANUP_AGE", 7*24*time.Hour),    // 7 days
-		CleanupPeriod: getEnvDuration("OUTBOX_CLEANUP_PERIOD", 1*24*time.Hour), // 1 day
+		BatchSize:      getEnvInt("OUTBOX_BATCH_SIZE", 100),
+		PollInterval:   getEnvDuration("OUTBOX_POLL_INTERVAL", 1*time.Second),
+		ClaimLease:     getEnvDuration("OUTBOX_CLAIM_LEASE", 30*time.Second),
+		MaxRetries:     getEnvInt("OUTBOX_MAX_RETRIES", 5),
+		RetryBaseDelay: getEnvDuration("OUTBOX_RETRY_BASE_DELAY", 1*time.Second),
+		RetryMaxDelay:  getEnvDuration("OUTBOX_RETRY_MAX_DELAY", 5*time.Minute),
+		CleanupAge:     getEnvDuration("OUTBOX_CLEANUP_AGE", 7*24*time.Hour),    // 7 days
+		CleanupPeriod:  getEnvDuration("OUTBOX_CLEANUP_PERIOD", 1*24*time.Hour), // 1 day
 	}
 }
+
+func (c OutboxConfig) Validate() error {
+	if c.BatchSize <= 0 {
+		return errors.New("outbox batch size must be positive")
+	}
+	if c.PollInterval <= 0 {
+		return errors.New("outbox poll interval must be positive")
+	}
+	if c.ClaimLease <= c.PollInterval {
+		return errors.New("outbox claim lease must be longer than the poll interval")
+	}
+	if c.MaxRetries <= 0 {
+		return errors.New("outbox max retries must be positive")
+	}
+	if c.RetryBaseDelay <= 0 || c.RetryMaxDelay < c.RetryBaseDelay {
+		return errors.New("outbox retry base delay must be positive and not exceed the max delay")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type SaleSchedulerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	// ClaimTTL is how long a replica holds a due product before another may transition it
+	ClaimTTL time.Duration
+}
+
+func loadSaleSchedulerConfig() SaleSchedulerConfig {
+	return SaleSchedulerConfig{
+		Interval:  getEnvDuration("SALE_SCHEDULER_INTERVAL", 10*time.Second),
+		BatchSize: getEnvInt("SALE_SCHEDULER_BATCH_SIZE", 100),
+		ClaimTTL:  getEnvDuration("SALE_SCHEDULER_CLAIM_TTL", 1*time.Minute),
+	}
+}
+
+func (c SaleSchedulerConfig) Validate() error {
+	if c.Interval <= 0 {
+		return errors.New("sale scheduler interval must be positive")
+	}
+	if c.BatchSize <= 0 {
+		return errors.New("sale scheduler batch size must be positive")
+	}
+	if c.ClaimTTL <= 0 {
+		return errors.New("sale scheduler claim ttl must be positive")
+	}
+	return nil
+}
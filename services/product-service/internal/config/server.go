@@ -5,11 +5,15 @@ import "errors"
 // ServerConfig holds server configuration
 type ServerConfig struct {
 	GRPCPort int
+	// IdentitySecret verifies the caller identity the API gateway forwards; the gateway must
+	// sign with the same secret
+	IdentitySecret string
 }
 
 func loadServerConfig() ServerConfig {
 	return ServerConfig{
-		GRPCPort: getEnvInt("GRPC_PORT", 50051),
+		GRPCPort:       getEnvInt("GRPC_PORT", 50051),
+		IdentitySecret: mustEnv("IDENTITY_SECRET"),
 	}
 }
 
@@ -17,5 +21,8 @@ func (c ServerConfig) Validate() error {
 	if c.GRPCPort <= 0 || c.GRPCPort > 65535 {
 		return errors.New("invalid gRPC port")
 	}
+	if c.IdentitySecret == "" {
+		return errors.New("identity secret is required")
+	}
 	return nil
 }
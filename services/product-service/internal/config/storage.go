@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+// StorageConfig configures product image storage.
+// The local backend writes files under LocalDir; they are served from PublicBaseURL
+// by any static file server (e.g. nginx in front of the directory).
+type StorageConfig struct {
+	LocalDir      string
+	PublicBaseURL string
+}
+
+func loadStorageConfig() StorageConfig {
+	return StorageConfig{
+		LocalDir:      getEnv("BLOB_LOCAL_DIR", "./data/images"),
+		PublicBaseURL: strings.TrimRight(getEnv("BLOB_PUBLIC_BASE_URL", "http://localhost:8090/images"), "/"),
+	}
+}
+
+// Validate validates storage configuration
+func (c *StorageConfig) Validate() error {
+	if c.LocalDir == "" {
+		return errors.New("local directory is required")
+	}
+	if c.PublicBaseURL == "" {
+		return errors.New("public base url is required")
+	}
+	return nil
+}
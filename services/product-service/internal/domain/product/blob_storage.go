@@ -0,0 +1,16 @@
+package product
+
+import "context"
+
+// BlobStorage stores product images
+// Defined in domain layer, implemented in infrastructure layer (Dependency Inversion)
+type BlobStorage interface {
+	// Put stores data under key, replacing any existing blob
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+
+	// Delete removes the blob under key; deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+
+	// URL returns the public URL of the blob under key
+	URL(key string) string
+}
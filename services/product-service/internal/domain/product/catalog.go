@@ -0,0 +1,87 @@
+package product
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	MaxTags      = 20
+	MaxImages    = 10
+	MaxImageSize = 5 * 1024 * 1024
+)
+
+// tagPattern allows lowercase words joined by single hyphens, e.g. "limited-edition"
+var tagPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+const tagMaxLength = 32
+
+// NormalizeTags lowercases, trims and de-duplicates tags, keeping their first-seen order
+func NormalizeTags(raw []string) ([]string, error) {
+	tags := make([]string, 0, len(raw))
+	for _, t := range raw {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if len(t) > tagMaxLength || !tagPattern.MatchString(t) {
+			return nil, ErrInvalidTag
+		}
+		if !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+
+	if len(tags) > MaxTags {
+		return nil, ErrTooManyTags
+	}
+	return tags, nil
+}
+
+// imageExtensions are the accepted image content types and their file extensions
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// ImageExtension returns the file extension for a supported image content type
+func ImageExtension(contentType string) (string, error) {
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return "", ErrUnsupportedImageType
+	}
+	return ext, nil
+}
+
+// Image references a product image in blob storage
+type Image struct {
+	id          ImageID
+	storageKey  string
+	url         string
+	contentType string
+}
+
+func NewImage(id ImageID, storageKey, url, contentType string) Image {
+	return Image{
+		id:          id,
+		storageKey:  storageKey,
+		url:         url,
+		contentType: contentType,
+	}
+}
+
+func (i Image) ID() ImageID {
+	return i.id
+}
+
+// StorageKey is the key of the image in blob storage
+func (i Image) StorageKey() string {
+	return i.storageKey
+}
+
+func (i Image) URL() string {
+	return i.url
+}
+
+func (i Image) ContentType() string {
+	return i.contentType
+}
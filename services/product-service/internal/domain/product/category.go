@@ -0,0 +1,64 @@
+package product
+
+import (
+	"strings"
+	"time"
+)
+
+const CATEGORY_NAME_MAX_LENGTH = 100
+
+// Category groups products in a tree; a product belongs to at most one category
+// and matches filters on that category and all of its ancestors
+type Category struct {
+	id        CategoryID
+	parentID  *CategoryID
+	name      string
+	createdAt time.Time
+}
+
+// NewCategory creates a category under parent (nil for a root category)
+func NewCategory(name string, parent *Category) (*Category, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len(name) > CATEGORY_NAME_MAX_LENGTH {
+		return nil, ErrInvalidCategoryName
+	}
+
+	c := &Category{
+		id:        NewCategoryID(),
+		name:      name,
+		createdAt: time.Now(),
+	}
+	if parent != nil {
+		parentID := parent.id
+		c.parentID = &parentID
+	}
+
+	return c, nil
+}
+
+// ReconstructCategory reconstructs a category from persistence (for Repository use)
+func ReconstructCategory(id CategoryID, parentID *CategoryID, name string, createdAt time.Time) *Category {
+	return &Category{
+		id:        id,
+		parentID:  parentID,
+		name:      name,
+		createdAt: createdAt,
+	}
+}
+
+func (c *Category) ID() CategoryID {
+	return c.id
+}
+
+// ParentID returns the parent category, nil for a root category
+func (c *Category) ParentID() *CategoryID {
+	return c.parentID
+}
+
+func (c *Category) Name() string {
+	return c.name
+}
+
+func (c *Category) CreatedAt() time.Time {
+	return c.createdAt
+}
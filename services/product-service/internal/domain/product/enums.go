@@ -53,12 +53,12 @@ func (s StockStatus) IsValid() bool {
 	}
 }
 
-// PriceType represents pricing strategy type (reserved for future auction support)
+// PriceType represents pricing strategy type
 type PriceType string
 
 const (
 	PriceTypeFixed   PriceType = "FIXED"   // fixed price (flash sale)
-	PriceTypeAuction PriceType = "AUCTION" // auction (future)
+	PriceTypeAuction PriceType = "AUCTION" // auction (bidding handled by Stock Service)
 )
 
 func (pt PriceType) IsValid() bool {
@@ -12,4 +12,34 @@ var (
 	ErrCannotUpdateActiveProduct           = errors.New("cannot update active product info")
 	ErrCannotUpdatePricingForActiveProduct = errors.New("cannot update pricing for active product")
 	ErrUnauthorizedDelete                  = errors.New("unauthorized to delete this product")
+	ErrAuctionFlashSaleNotAllowed          = errors.New("auction products cannot have a flash sale price")
+	ErrCannotChangePriceType               = errors.New("cannot change price type of an existing product")
+	ErrInvalidPurchaseLimit                = errors.New("purchase limit cannot be negative")
+	ErrAuctionPurchaseLimitNotAllowed      = errors.New("auction products cannot have a purchase limit")
+	ErrInvalidSaleWindow                   = errors.New("sale end must be after sale start")
+	ErrSaleWindowEnded                     = errors.New("sale window has already ended")
+	ErrSaleNotDue                          = errors.New("scheduled sale transition is not due")
+	ErrInvalidSearchSort                   = errors.New("invalid search sort")
+	ErrInvalidSearchCursor                 = errors.New("invalid search cursor")
+	ErrInvalidSearchStockStatus            = errors.New("stock status filter must be IN_STOCK or LOW_STOCK")
+	ErrInvalidPriceRange                   = errors.New("invalid price range")
+	ErrCategoryNotFound                    = errors.New("category not found")
+	ErrInvalidCategoryID                   = errors.New("invalid category id")
+	ErrInvalidCategoryName                 = errors.New("category name must be 1 to 100 characters")
+	ErrTooManyTags                         = errors.New("too many tags")
+	ErrInvalidTag                          = errors.New("invalid tag")
+	ErrTooManyImages                       = errors.New("too many images")
+	ErrImageNotFound                       = errors.New("image not found")
+	ErrUnsupportedImageType                = errors.New("unsupported image type")
+	ErrImageTooLarge                       = errors.New("image too large")
+	ErrInvalidImageOrder                   = errors.New("image order must list every image exactly once")
+	ErrVariantNotFound                     = errors.New("variant not found")
+	ErrInvalidVariantID                    = errors.New("invalid variant id")
+	ErrInvalidSKU                          = errors.New("invalid sku")
+	ErrDuplicateSKU                        = errors.New("sku is already used by another variant")
+	ErrTooManyVariants                     = errors.New("too many variants")
+	ErrInvalidVariantAttributes            = errors.New("invalid variant attributes")
+	ErrInvalidVariantPrice                 = errors.New("variant price override must be greater than zero")
+	ErrAuctionVariantsNotAllowed           = errors.New("auction products cannot have variants")
+	ErrVariantCurrencyMismatch             = errors.New("variant price must be in the product's currency")
 )
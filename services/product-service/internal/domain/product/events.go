@@ -35,16 +35,32 @@ func (e ProductCreatedEvent) EventType() string {
 
 // ProductPublishedEvent is emitted when a product is published
 type ProductPublishedEvent struct {
-	ProductID  ProductID
-	Money      Money
-	occurredAt time.Time
-}
-
-func NewProductPublishedEvent(productID ProductID, money Money, occurredAt time.Time) ProductPublishedEvent {
+	ProductID     ProductID
+	Money         Money
+	PriceType     PriceType
+	PurchaseLimit int // per-user lifetime limit, 0 = unlimited
+	SaleWindow    SaleWindow
+	Variants      []Variant // empty for a product without variants
+	occurredAt    time.Time
+}
+
+func NewProductPublishedEvent(
+	productID ProductID,
+	money Money,
+	priceType PriceType,
+	purchaseLimit int,
+	saleWindow SaleWindow,
+	variants []Variant,
+	occurredAt time.Time,
+) ProductPublishedEvent {
 	return ProductPublishedEvent{
-		ProductID:  productID,
-		Money:      money,
-		occurredAt: occurredAt,
+		ProductID:     productID,
+		Money:         money,
+		PriceType:     priceType,
+		PurchaseLimit: purchaseLimit,
+		SaleWindow:    saleWindow,
+		Variants:      variants,
+		occurredAt:    occurredAt,
 	}
 }
 
@@ -56,6 +72,45 @@ func (e ProductPublishedEvent) EventType() string {
 	return "product.published"
 }
 
+// ProductPriceUpdatedEvent is emitted when a product's pricing changes.
+// Pricing only changes while the product is off sale, so the new prices apply to every
+// reservation from EffectiveFrom on.
+type ProductPriceUpdatedEvent struct {
+	ProductID      ProductID
+	RegularPrice   Money
+	FlashSalePrice *Money
+	PriceType      PriceType
+	EffectiveFrom  time.Time
+	occurredAt     time.Time
+}
+
+func NewProductPriceUpdatedEvent(productID ProductID, pricing Pricing, effectiveFrom time.Time, occurredAt time.Time) ProductPriceUpdatedEvent {
+	return ProductPriceUpdatedEvent{
+		ProductID:      productID,
+		RegularPrice:   pricing.RegularPrice(),
+		FlashSalePrice: pricing.FlashSalePrice(),
+		PriceType:      pricing.PriceType(),
+		EffectiveFrom:  effectiveFrom,
+		occurredAt:     occurredAt,
+	}
+}
+
+func (e ProductPriceUpdatedEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e ProductPriceUpdatedEvent) EventType() string {
+	return "product.price_updated"
+}
+
+// CurrentPrice is the price charged from EffectiveFrom on
+func (e ProductPriceUpdatedEvent) CurrentPrice() Money {
+	if e.FlashSalePrice != nil {
+		return *e.FlashSalePrice
+	}
+	return e.RegularPrice
+}
+
 // ProductDeactivatedEvent is emitted when a product is deactivated
 type ProductDeactivatedEvent struct {
 	ProductID  ProductID
@@ -102,7 +157,12 @@ func (e ProductSoldOutEvent) EventType() string {
 type ProductSnapshotEvent struct {
 	GeneratedAt      time.Time
 	ActiveProducts   []string
-	PartitionOffsets map[int]int64 // partition_id -> offset at snapshot time
+	AuctionProducts  []string              // subset of active products sold by auction
+	PurchaseLimits   map[string]int        // product_id -> per-user limit, limited products only
+	SaleWindows      map[string]SaleWindow // product_id -> sale window, scheduled products only
+	Prices           map[string]Money      // product_id -> current price, fixed-price products only
+	Variants         map[string][]Variant  // product_id -> variants, products with variants only
+	PartitionOffsets map[int]int64         // partition_id -> offset at snapshot time
 	Total            int
 	occurredAt       time.Time
 }
@@ -110,11 +170,21 @@ type ProductSnapshotEvent struct {
 // NewProductSnapshotEvent creates a new snapshot event
 func NewProductSnapshotEvent(
 	activeProductIDs []string,
+	auctionProductIDs []string,
+	purchaseLimits map[string]int,
+	saleWindows map[string]SaleWindow,
+	prices map[string]Money,
+	variants map[string][]Variant,
 	partitionOffsets map[int]int64,
 	occurredAt time.Time,
 ) *ProductSnapshotEvent {
 	return &ProductSnapshotEvent{
 		ActiveProducts:   activeProductIDs,
+		AuctionProducts:  auctionProductIDs,
+		PurchaseLimits:   purchaseLimits,
+		SaleWindows:      saleWindows,
+		Prices:           prices,
+		Variants:         variants,
 		PartitionOffsets: partitionOffsets,
 		Total:            len(activeProductIDs),
 		occurredAt:       occurredAt,
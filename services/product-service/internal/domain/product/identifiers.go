@@ -55,3 +55,51 @@ func (id SellerID) IsEmpty() bool {
 func (id SellerID) Equals(other SellerID) bool {
 	return id == other
 }
+
+type CategoryID string
+
+func NewCategoryID() CategoryID {
+	return CategoryID(uuidv7.New().String())
+}
+
+func ParseCategoryID(id string) (CategoryID, error) {
+	if !uuidv7.IsValidString(id) {
+		return "", ErrInvalidCategoryID
+	}
+	return CategoryID(id), nil
+}
+
+func (id CategoryID) String() string {
+	return string(id)
+}
+
+func (id CategoryID) IsEmpty() bool {
+	return id == ""
+}
+
+type ImageID string
+
+func NewImageID() ImageID {
+	return ImageID(uuidv7.New().String())
+}
+
+func (id ImageID) String() string {
+	return string(id)
+}
+
+type VariantID string
+
+func NewVariantID() VariantID {
+	return VariantID(uuidv7.New().String())
+}
+
+func ParseVariantID(id string) (VariantID, error) {
+	if !uuidv7.IsValidString(id) {
+		return "", ErrInvalidVariantID
+	}
+	return VariantID(id), nil
+}
+
+func (id VariantID) String() string {
+	return string(id)
+}
@@ -11,13 +11,24 @@ var PRODUCT_NAME_MAX_LENGTH = 200
 
 // Product is the aggregate root for the product domain
 type Product struct {
-	id           ProductID
-	sellerID     SellerID
-	name         string
-	description  string
-	pricing      Pricing
-	status       ProductStatus
-	stockStatus  StockStatus
+	id          ProductID
+	sellerID    SellerID
+	name        string
+	description string
+	pricing     Pricing
+	status      ProductStatus
+	stockStatus StockStatus
+	// purchaseLimit caps how many units one user may buy over the product's lifetime (0 = unlimited)
+	purchaseLimit int
+	// saleWindow is the scheduled on-sale period driven by the sale scheduler
+	saleWindow SaleWindow
+	// categoryID is the category the product is listed under (nil = uncategorized)
+	categoryID *CategoryID
+	tags       []string
+	// images are ordered; the first image is the product's cover
+	images []Image
+	// variants are the purchasable options; when present, stockStatus aggregates theirs
+	variants     []Variant
 	createdAt    time.Time
 	updatedAt    time.Time
 	domainEvents []DomainEvent
@@ -77,19 +88,31 @@ func ReconstructProduct(
 	pricing Pricing,
 	status ProductStatus,
 	stockStatus StockStatus,
+	purchaseLimit int,
+	saleWindow SaleWindow,
+	categoryID *CategoryID,
+	tags []string,
+	images []Image,
+	variants []Variant,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) *Product {
 	return &Product{
-		id:          id,
-		sellerID:    sellerID,
-		name:        name,
-		description: description,
-		pricing:     pricing,
-		status:      status,
-		stockStatus: stockStatus,
-		createdAt:   createdAt,
-		updatedAt:   updatedAt,
+		id:            id,
+		sellerID:      sellerID,
+		name:          name,
+		description:   description,
+		pricing:       pricing,
+		status:        status,
+		stockStatus:   stockStatus,
+		purchaseLimit: purchaseLimit,
+		saleWindow:    saleWindow,
+		categoryID:    categoryID,
+		tags:          tags,
+		images:        images,
+		variants:      variants,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
 	}
 }
 
@@ -122,6 +145,52 @@ func (p *Product) StockStatus() StockStatus {
 	return p.stockStatus
 }
 
+func (p *Product) PurchaseLimit() int {
+	return p.purchaseLimit
+}
+
+func (p *Product) HasPurchaseLimit() bool {
+	return p.purchaseLimit > 0
+}
+
+func (p *Product) SaleWindow() SaleWindow {
+	return p.saleWindow
+}
+
+func (p *Product) CategoryID() *CategoryID {
+	return p.categoryID
+}
+
+func (p *Product) Tags() []string {
+	tags := make([]string, len(p.tags))
+	copy(tags, p.tags)
+	return tags
+}
+
+func (p *Product) Images() []Image {
+	images := make([]Image, len(p.images))
+	copy(images, p.images)
+	return images
+}
+
+func (p *Product) Variants() []Variant {
+	variants := make([]Variant, len(p.variants))
+	copy(variants, p.variants)
+	return variants
+}
+
+func (p *Product) HasVariants() bool {
+	return len(p.variants) > 0
+}
+
+// VariantPrice is the price a variant is sold at: its override, or the product's current price
+func (p *Product) VariantPrice(v Variant) Money {
+	if v.priceOverride != nil {
+		return *v.priceOverride
+	}
+	return p.pricing.CurrentPrice()
+}
+
 func (p *Product) CreatedAt() time.Time {
 	return p.createdAt
 }
@@ -136,8 +205,13 @@ func (p *Product) Publish() error {
 		return ErrCannotPublishProduct
 	}
 
+	now := time.Now()
+	if p.saleWindow.HasEnded(now) {
+		return ErrSaleWindowEnded
+	}
+
 	p.status = ProductStatusActive
-	p.updatedAt = time.Now()
+	p.updatedAt = now
 
 	var money Money
 	if p.pricing.flashSalePrice != nil {
@@ -146,18 +220,20 @@ func (p *Product) Publish() error {
 		money = p.pricing.regularPrice
 	}
 
-	p.recordEvent(NewProductPublishedEvent(p.id, money, p.updatedAt))
+	p.recordEvent(NewProductPublishedEvent(p.id, money, p.pricing.priceType, p.purchaseLimit, p.saleWindow, p.Variants(), p.updatedAt))
 
 	return nil
 }
 
-// Deactivate deactivates the product (removes from sale)
+// Deactivate deactivates the product (removes from sale).
+// Deactivating ends any scheduled sale, so the scheduler does not publish it again.
 func (p *Product) Deactivate() error {
 	if !p.status.CanDeactivate() {
 		return ErrCannotDeactivateProduct
 	}
 
 	p.status = ProductStatusInactive
+	p.saleWindow = SaleWindow{}
 	p.updatedAt = time.Now()
 
 	p.recordEvent(NewProductDeactivatedEvent(p.id, p.updatedAt))
@@ -165,6 +241,54 @@ func (p *Product) Deactivate() error {
 	return nil
 }
 
+// ScheduleSale sets the period during which the product is on sale.
+// The sale scheduler publishes the product when the window opens and deactivates it
+// when the window closes; an empty window cancels the schedule.
+func (p *Product) ScheduleSale(window SaleWindow) error {
+	if !p.status.CanUpdate() {
+		return ErrCannotUpdateActiveProduct
+	}
+
+	now := time.Now()
+	if window.HasEnded(now) {
+		return ErrSaleWindowEnded
+	}
+
+	p.saleWindow = window
+	p.updatedAt = now
+
+	return nil
+}
+
+// IsDueToStart reports whether the scheduler should publish the product
+func (p *Product) IsDueToStart(now time.Time) bool {
+	return p.status.CanPublish() &&
+		p.saleWindow.StartsAt() != nil &&
+		p.saleWindow.HasStarted(now) &&
+		!p.saleWindow.HasEnded(now)
+}
+
+// IsDueToEnd reports whether the scheduler should deactivate the product
+func (p *Product) IsDueToEnd(now time.Time) bool {
+	return p.status == ProductStatusActive && p.saleWindow.HasEnded(now)
+}
+
+// StartScheduledSale publishes the product because its sale window opened
+func (p *Product) StartScheduledSale(now time.Time) error {
+	if !p.IsDueToStart(now) {
+		return ErrSaleNotDue
+	}
+	return p.Publish()
+}
+
+// EndScheduledSale deactivates the product because its sale window closed
+func (p *Product) EndScheduledSale(now time.Time) error {
+	if !p.IsDueToEnd(now) {
+		return ErrSaleNotDue
+	}
+	return p.Deactivate()
+}
+
 // MarkAsSoldOut marks the product as sold out (triggered by stock.depleted event)
 func (p *Product) MarkAsSoldOut() error {
 	if !p.status.CanMarkAsSoldOut() {
@@ -205,15 +329,136 @@ func (p *Product) UpdateInfo(name string, description string) error {
 	return nil
 }
 
-// UpdatePricing updates product pricing
+// Classify sets the product's category (nil removes it) and replaces its tags
+func (p *Product) Classify(categoryID *CategoryID, tags []string) error {
+	if !p.status.CanUpdate() {
+		return ErrCannotUpdateActiveProduct
+	}
+
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	p.categoryID = categoryID
+	p.tags = normalized
+	p.updatedAt = time.Now()
+
+	return nil
+}
+
+// AddImage appends an image to the end of the product's image list
+func (p *Product) AddImage(image Image) error {
+	if !p.status.CanUpdate() {
+		return ErrCannotUpdateActiveProduct
+	}
+
+	if len(p.images) >= MaxImages {
+		return ErrTooManyImages
+	}
+
+	p.images = append(p.images, image)
+	p.updatedAt = time.Now()
+
+	return nil
+}
+
+// RemoveImage removes an image and returns it so its blob can be deleted
+func (p *Product) RemoveImage(id ImageID) (Image, error) {
+	if !p.status.CanUpdate() {
+		return Image{}, ErrCannotUpdateActiveProduct
+	}
+
+	for i, image := range p.images {
+		if image.id == id {
+			p.images = append(p.images[:i:i], p.images[i+1:]...)
+			p.updatedAt = time.Now()
+			return image, nil
+		}
+	}
+
+	return Image{}, ErrImageNotFound
+}
+
+// ReorderImages reorders the images; ids must list every image exactly once
+func (p *Product) ReorderImages(ids []ImageID) error {
+	if !p.status.CanUpdate() {
+		return ErrCannotUpdateActiveProduct
+	}
+
+	if len(ids) != len(p.images) {
+		return ErrInvalidImageOrder
+	}
+
+	byID := make(map[ImageID]Image, len(p.images))
+	for _, image := range p.images {
+		byID[image.id] = image
+	}
+
+	reordered := make([]Image, 0, len(ids))
+	for _, id := range ids {
+		image, ok := byID[id]
+		if !ok {
+			return ErrInvalidImageOrder
+		}
+		delete(byID, id)
+		reordered = append(reordered, image)
+	}
+
+	p.images = reordered
+	p.updatedAt = time.Now()
+
+	return nil
+}
+
+// UpdatePricing updates product pricing and records a price change for order-service
 func (p *Product) UpdatePricing(newPricing Pricing) error {
 	if !p.status.CanUpdate() {
 		return ErrCannotUpdatePricingForActiveProduct
 	}
 
+	if p.pricing.priceType != newPricing.priceType {
+		return ErrCannotChangePriceType
+	}
+
+	if p.pricing.Equals(newPricing) {
+		return nil
+	}
+
+	for _, v := range p.variants {
+		if v.priceOverride != nil && v.priceOverride.Currency() != newPricing.RegularPrice().Currency() {
+			return ErrVariantCurrencyMismatch
+		}
+	}
+
 	p.pricing = newPricing
 	p.updatedAt = time.Now()
 
+	p.recordEvent(NewProductPriceUpdatedEvent(p.id, p.pricing, p.updatedAt, p.updatedAt))
+
+	return nil
+}
+
+// UpdatePurchaseLimit sets the per-user lifetime purchase limit (0 removes the limit).
+// Like pricing, it can only change while the product is off sale; stock-service
+// picks up the new limit from the next product.published event.
+func (p *Product) UpdatePurchaseLimit(limit int) error {
+	if !p.status.CanUpdate() {
+		return ErrCannotUpdateActiveProduct
+	}
+
+	if limit < 0 {
+		return ErrInvalidPurchaseLimit
+	}
+
+	// An auction has a single winner, so a per-user limit would only block the close
+	if limit > 0 && p.pricing.IsAuction() {
+		return ErrAuctionPurchaseLimitNotAllowed
+	}
+
+	p.purchaseLimit = limit
+	p.updatedAt = time.Now()
+
 	return nil
 }
 
@@ -223,6 +468,117 @@ func (p *Product) UpdateStockStatus(newStatus StockStatus) {
 	p.updatedAt = time.Now()
 }
 
+// AddVariant adds a variant; its stock is set in stock-service under the variant's ID
+func (p *Product) AddVariant(variant Variant) error {
+	if !p.status.CanUpdate() {
+		return ErrCannotUpdateActiveProduct
+	}
+
+	// An auction sells a single lot, so there is nothing to choose between
+	if p.pricing.IsAuction() {
+		return ErrAuctionVariantsNotAllowed
+	}
+
+	if len(p.variants) >= MaxVariants {
+		return ErrTooManyVariants
+	}
+
+	for _, v := range p.variants {
+		if strings.EqualFold(v.sku, variant.sku) {
+			return ErrDuplicateSKU
+		}
+	}
+
+	if err := p.checkVariantPrice(variant.priceOverride); err != nil {
+		return err
+	}
+
+	p.variants = append(p.variants, variant)
+	p.stockStatus = aggregateStockStatus(p.variants)
+	p.updatedAt = time.Now()
+
+	return nil
+}
+
+// UpdateVariant replaces a variant's attributes and price override (nil removes the override)
+func (p *Product) UpdateVariant(id VariantID, attributes map[string]string, priceOverride *Money) error {
+	if !p.status.CanUpdate() {
+		return ErrCannotUpdateActiveProduct
+	}
+
+	i := p.variantIndex(id)
+	if i < 0 {
+		return ErrVariantNotFound
+	}
+
+	normalized, err := normalizeVariantAttributes(attributes)
+	if err != nil {
+		return err
+	}
+	if priceOverride != nil && priceOverride.IsZero() {
+		return ErrInvalidVariantPrice
+	}
+	if err := p.checkVariantPrice(priceOverride); err != nil {
+		return err
+	}
+
+	p.variants[i].attributes = normalized
+	p.variants[i].priceOverride = priceOverride
+	p.updatedAt = time.Now()
+
+	return nil
+}
+
+// RemoveVariant removes a variant; its stock counter in stock-service is no longer sold
+func (p *Product) RemoveVariant(id VariantID) error {
+	if !p.status.CanUpdate() {
+		return ErrCannotUpdateActiveProduct
+	}
+
+	i := p.variantIndex(id)
+	if i < 0 {
+		return ErrVariantNotFound
+	}
+
+	p.variants = append(p.variants[:i:i], p.variants[i+1:]...)
+	p.stockStatus = aggregateStockStatus(p.variants)
+	p.updatedAt = time.Now()
+
+	return nil
+}
+
+// UpdateVariantStockStatus updates a variant's stock status (called when consuming stock events)
+// and re-aggregates the product's stock status
+func (p *Product) UpdateVariantStockStatus(id VariantID, newStatus StockStatus) error {
+	i := p.variantIndex(id)
+	if i < 0 {
+		return ErrVariantNotFound
+	}
+
+	p.variants[i].stockStatus = newStatus
+	p.stockStatus = aggregateStockStatus(p.variants)
+	p.updatedAt = time.Now()
+
+	return nil
+}
+
+func (p *Product) variantIndex(id VariantID) int {
+	for i, v := range p.variants {
+		if v.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkVariantPrice ensures a price override is in the product's currency
+func (p *Product) checkVariantPrice(priceOverride *Money) error {
+	if priceOverride != nil && priceOverride.Currency() != p.pricing.regularPrice.Currency() {
+		return ErrVariantCurrencyMismatch
+	}
+	return nil
+}
+
 // CanBeDeletedBy checks if the product can be deleted by the given seller
 func (p *Product) CanBeDeletedBy(sellerID SellerID) bool {
 	// Business rule: only the seller can delete
@@ -1,6 +1,9 @@
 package product
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the interface for product persistence
 // Defined in domain layer, implemented in infrastructure layer (Dependency Inversion)
@@ -17,9 +20,21 @@ type Repository interface {
 	// FindByStatus finds products by status with pagination
 	FindByStatus(ctx context.Context, status ProductStatus, limit, offset int) ([]*Product, error)
 
+	// Search finds up to criteria.Limit active products matching the criteria,
+	// in criteria order after the cursor
+	Search(ctx context.Context, criteria SearchCriteria) ([]*Product, error)
+
 	// FindActiveProducts finds all active products
 	FindAllActiveProducts(ctx context.Context) ([]*Product, error)
 
+	// ClaimDueToStart claims draft/inactive products whose sale window has opened until
+	// claimedUntil, so each is published by a single scheduler replica
+	ClaimDueToStart(ctx context.Context, now time.Time, claimedUntil time.Time, limit int) ([]*Product, error)
+
+	// ClaimDueToEnd claims active products whose sale window has closed until claimedUntil,
+	// so each is deactivated by a single scheduler replica
+	ClaimDueToEnd(ctx context.Context, now time.Time, claimedUntil time.Time, limit int) ([]*Product, error)
+
 	// Delete deletes a product
 	Delete(ctx context.Context, id ProductID) error
 
@@ -32,3 +47,15 @@ type Repository interface {
 	// CountByStatus counts products by status
 	CountByStatus(ctx context.Context, status ProductStatus) (int, error)
 }
+
+// CategoryRepository defines the interface for category persistence
+type CategoryRepository interface {
+	// Save saves a new category
+	Save(ctx context.Context, category *Category) error
+
+	// FindByID finds a category by ID
+	FindByID(ctx context.Context, id CategoryID) (*Category, error)
+
+	// FindAll finds all categories; callers build the tree from ParentID
+	FindAll(ctx context.Context) ([]*Category, error)
+}
@@ -0,0 +1,181 @@
+package product
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// SearchSort orders catalog search results
+type SearchSort string
+
+const (
+	SearchSortNewest    SearchSort = "NEWEST"     // most recently created first
+	SearchSortPriceAsc  SearchSort = "PRICE_ASC"  // cheapest current price first
+	SearchSortPriceDesc SearchSort = "PRICE_DESC" // most expensive current price first
+)
+
+func (s SearchSort) IsValid() bool {
+	switch s {
+	case SearchSortNewest, SearchSortPriceAsc, SearchSortPriceDesc:
+		return true
+	default:
+		return false
+	}
+}
+
+// SearchCriteria filters and orders a search over active products.
+// Prices are compared against the current price (flash sale price if set, else regular).
+type SearchCriteria struct {
+	Query         string // full-text query on name and description, empty matches all
+	MinPrice      *int64
+	MaxPrice      *int64
+	Currency      string
+	StockStatuses []StockStatus // IN_STOCK and/or LOW_STOCK, empty matches any
+	CategoryID    *CategoryID   // matches the category and all of its descendants
+	Tags          []string      // products must carry every tag
+	Sort          SearchSort
+	After         *SearchCursor // continue after this product
+	Limit         int
+}
+
+// NewSearchCriteria validates the filters and applies defaults
+func NewSearchCriteria(
+	query string,
+	minPrice, maxPrice *int64,
+	currency string,
+	stockStatuses []StockStatus,
+	categoryID *CategoryID,
+	tags []string,
+	sort SearchSort,
+	after *SearchCursor,
+	limit int,
+) (SearchCriteria, error) {
+	if sort == "" {
+		sort = SearchSortNewest
+	}
+	if !sort.IsValid() {
+		return SearchCriteria{}, ErrInvalidSearchSort
+	}
+	if (minPrice != nil && *minPrice < 0) || (maxPrice != nil && *maxPrice < 0) {
+		return SearchCriteria{}, ErrInvalidPriceRange
+	}
+	if minPrice != nil && maxPrice != nil && *minPrice > *maxPrice {
+		return SearchCriteria{}, ErrInvalidPriceRange
+	}
+
+	for _, s := range stockStatuses {
+		if s != StockStatusInStock && s != StockStatusLowStock {
+			return SearchCriteria{}, ErrInvalidSearchStockStatus
+		}
+	}
+
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return SearchCriteria{}, err
+	}
+
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	criteria := SearchCriteria{
+		Query:         query,
+		MinPrice:      minPrice,
+		MaxPrice:      maxPrice,
+		Currency:      currency,
+		StockStatuses: stockStatuses,
+		CategoryID:    categoryID,
+		Tags:          tags,
+		Sort:          sort,
+		After:         after,
+		Limit:         limit,
+	}
+
+	// A cursor only marks a position within the results it was issued for
+	if after != nil && (after.Sort != sort || after.Filters != criteria.filtersDigest()) {
+		return SearchCriteria{}, ErrInvalidSearchCursor
+	}
+
+	return criteria, nil
+}
+
+// filtersDigest identifies the filters of a search, ignoring the order of the
+// stock statuses and tags
+func (c SearchCriteria) filtersDigest() string {
+	statuses := slices.Clone(c.StockStatuses)
+	slices.Sort(statuses)
+	tags := slices.Clone(c.Tags)
+	slices.Sort(tags)
+
+	raw, _ := json.Marshal(struct {
+		Query         string        `json:"q"`
+		MinPrice      *int64        `json:"min"`
+		MaxPrice      *int64        `json:"max"`
+		Currency      string        `json:"cur"`
+		StockStatuses []StockStatus `json:"st,omitempty"`
+		CategoryID    *CategoryID   `json:"cat"`
+		Tags          []string      `json:"tags,omitempty"`
+	}{c.Query, c.MinPrice, c.MaxPrice, c.Currency, statuses, c.CategoryID, tags})
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:16])
+}
+
+// SearchCursor is the position of the last product on a search page.
+// Results are ordered by the sort key with the product ID as tie-breaker, so paging
+// stays stable while products are added or removed. The cursor carries a digest of the
+// search filters and is rejected if the next page is requested with different ones.
+type SearchCursor struct {
+	Sort      SearchSort `json:"s"`
+	Filters   string     `json:"f"`
+	ID        ProductID  `json:"id"`
+	Price     int64      `json:"p,omitempty"`
+	CreatedAt time.Time  `json:"c"`
+}
+
+// NewSearchCursor returns the cursor of the search positioned after the given product
+func NewSearchCursor(criteria SearchCriteria, p *Product) SearchCursor {
+	c := SearchCursor{Sort: criteria.Sort, Filters: criteria.filtersDigest(), ID: p.ID()}
+	if criteria.Sort == SearchSortNewest {
+		c.CreatedAt = p.CreatedAt()
+	} else {
+		c.Price = p.Pricing().CurrentPrice().Amount()
+	}
+	return c
+}
+
+// Encode returns the opaque cursor token handed to clients
+func (c SearchCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeSearchCursor parses a cursor token from Encode
+func DecodeSearchCursor(token string) (*SearchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidSearchCursor
+	}
+
+	var c SearchCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, ErrInvalidSearchCursor
+	}
+	if !c.Sort.IsValid() || c.ID.IsEmpty() {
+		return nil, ErrInvalidSearchCursor
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,188 @@
+package product
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSearchCursorEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor SearchCursor
+	}{
+		{
+			name: "newest",
+			cursor: SearchCursor{
+				Sort:      SearchSortNewest,
+				Filters:   "f00d",
+				ID:        "0190a6f0-0000-7000-8000-000000000001",
+				CreatedAt: time.Date(2026, 5, 1, 12, 30, 0, 123456000, time.UTC),
+			},
+		},
+		{
+			name: "price ascending",
+			cursor: SearchCursor{
+				Sort:    SearchSortPriceAsc,
+				Filters: "f00d",
+				ID:      "0190a6f0-0000-7000-8000-000000000002",
+				Price:   1999,
+			},
+		},
+		{
+			name: "price descending",
+			cursor: SearchCursor{
+				Sort:    SearchSortPriceDesc,
+				Filters: "beef",
+				ID:      "0190a6f0-0000-7000-8000-000000000003",
+				Price:   50,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeSearchCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodeSearchCursor() error = %v", err)
+			}
+			if got.Sort != tt.cursor.Sort || got.Filters != tt.cursor.Filters || got.ID != tt.cursor.ID ||
+				got.Price != tt.cursor.Price || !got.CreatedAt.Equal(tt.cursor.CreatedAt) {
+				t.Fatalf("DecodeSearchCursor() = %+v, want %+v", *got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeSearchCursorRejectsInvalidTokens(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "not base64", token: "!!!"},
+		{name: "not json", token: encode("not json")},
+		{name: "unknown sort", token: encode(`{"s":"RANDOM","f":"f00d","id":"p1"}`)},
+		{name: "missing sort", token: encode(`{"f":"f00d","id":"p1"}`)},
+		{name: "missing product", token: encode(`{"s":"NEWEST","f":"f00d"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeSearchCursor(tt.token); !errors.Is(err, ErrInvalidSearchCursor) {
+				t.Fatalf("DecodeSearchCursor() error = %v, want %v", err, ErrInvalidSearchCursor)
+			}
+		})
+	}
+}
+
+func TestNewSearchCriteriaChecksCursorFilters(t *testing.T) {
+	minPrice, otherMinPrice := int64(100), int64(200)
+	category := CategoryID("0190a6f0-0000-7000-8000-0000000000c1")
+
+	type search struct {
+		query      string
+		minPrice   *int64
+		statuses   []StockStatus
+		categoryID *CategoryID
+		tags       []string
+		sort       SearchSort
+	}
+	newCriteria := func(s search, after *SearchCursor) (SearchCriteria, error) {
+		return NewSearchCriteria(s.query, s.minPrice, nil, "USD", s.statuses, s.categoryID, s.tags, s.sort, after, 0)
+	}
+
+	first := search{
+		query:      "shoes",
+		minPrice:   &minPrice,
+		statuses:   []StockStatus{StockStatusInStock, StockStatusLowStock},
+		categoryID: &category,
+		tags:       []string{"red", "sale"},
+		sort:       SearchSortPriceAsc,
+	}
+
+	tests := []struct {
+		name    string
+		next    func(s search) search
+		wantErr error
+	}{
+		{
+			name:    "same filters",
+			next:    func(s search) search { return s },
+			wantErr: nil,
+		},
+		{
+			name: "same filters in another order",
+			next: func(s search) search {
+				s.statuses = []StockStatus{StockStatusLowStock, StockStatusInStock}
+				s.tags = []string{"Sale", "red"}
+				return s
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "other query",
+			next:    func(s search) search { s.query = "boots"; return s },
+			wantErr: ErrInvalidSearchCursor,
+		},
+		{
+			name:    "other price range",
+			next:    func(s search) search { s.minPrice = &otherMinPrice; return s },
+			wantErr: ErrInvalidSearchCursor,
+		},
+		{
+			name:    "price filter dropped",
+			next:    func(s search) search { s.minPrice = nil; return s },
+			wantErr: ErrInvalidSearchCursor,
+		},
+		{
+			name:    "other stock statuses",
+			next:    func(s search) search { s.statuses = []StockStatus{StockStatusInStock}; return s },
+			wantErr: ErrInvalidSearchCursor,
+		},
+		{
+			name:    "category dropped",
+			next:    func(s search) search { s.categoryID = nil; return s },
+			wantErr: ErrInvalidSearchCursor,
+		},
+		{
+			name:    "other tags",
+			next:    func(s search) search { s.tags = []string{"red"}; return s },
+			wantErr: ErrInvalidSearchCursor,
+		},
+		{
+			name:    "other sort",
+			next:    func(s search) search { s.sort = SearchSortPriceDesc; return s },
+			wantErr: ErrInvalidSearchCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria, err := newCriteria(first, nil)
+			if err != nil {
+				t.Fatalf("NewSearchCriteria() error = %v", err)
+			}
+			// The client gets the cursor as a token and sends it back for the next page
+			cursor, err := DecodeSearchCursor(SearchCursor{
+				Sort:    criteria.Sort,
+				Filters: criteria.filtersDigest(),
+				ID:      "0190a6f0-0000-7000-8000-000000000001",
+				Price:   150,
+			}.Encode())
+			if err != nil {
+				t.Fatalf("DecodeSearchCursor() error = %v", err)
+			}
+
+			_, err = newCriteria(tt.next(first), cursor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewSearchCriteria() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package product
 
 import (
 	"errors"
+	"time"
 )
 
 type Money struct {
@@ -58,6 +59,7 @@ func isValidCurrency(code string) bool {
 
 // Pricing represents the pricing strategy of a product
 type Pricing struct {
+	priceType      PriceType
 	regularPrice   Money
 	flashSalePrice *Money // optional flash sale price
 }
@@ -68,6 +70,7 @@ func NewPricing(regularPrice Money) (Pricing, error) {
 		return Pricing{}, errors.New("regular price must be greater than zero")
 	}
 	return Pricing{
+		priceType:    PriceTypeFixed,
 		regularPrice: regularPrice,
 	}, nil
 }
@@ -84,11 +87,32 @@ func NewPricingWithFlashSale(regularPrice Money, flashSalePrice Money) (Pricing,
 		return Pricing{}, errors.New("regular price must be greater than flash sale price")
 	}
 	return Pricing{
+		priceType:      PriceTypeFixed,
 		regularPrice:   regularPrice,
 		flashSalePrice: &flashSalePrice,
 	}, nil
 }
 
+// NewAuctionPricing creates a new auction pricing.
+// The regular price holds the listing (opening) price; the final price is decided by bidding.
+func NewAuctionPricing(listingPrice Money) (Pricing, error) {
+	if listingPrice.IsZero() {
+		return Pricing{}, errors.New("listing price must be greater than zero")
+	}
+	return Pricing{
+		priceType:    PriceTypeAuction,
+		regularPrice: listingPrice,
+	}, nil
+}
+
+func (p Pricing) PriceType() PriceType {
+	return p.priceType
+}
+
+func (p Pricing) IsAuction() bool {
+	return p.priceType == PriceTypeAuction
+}
+
 func (p Pricing) RegularPrice() Money {
 	return p.regularPrice
 }
@@ -101,6 +125,17 @@ func (p Pricing) HasFlashSale() bool {
 	return p.flashSalePrice != nil
 }
 
+// Equals reports whether both pricings charge the same prices
+func (p Pricing) Equals(other Pricing) bool {
+	if p.priceType != other.priceType || !p.regularPrice.Equals(other.regularPrice) {
+		return false
+	}
+	if p.flashSalePrice == nil || other.flashSalePrice == nil {
+		return p.flashSalePrice == nil && other.flashSalePrice == nil
+	}
+	return p.flashSalePrice.Equals(*other.flashSalePrice)
+}
+
 // CurrentPrice returns the effective current price
 func (p Pricing) CurrentPrice() Money {
 	if p.flashSalePrice != nil {
@@ -108,3 +143,43 @@ func (p Pricing) CurrentPrice() Money {
 	}
 	return p.regularPrice
 }
+
+// SaleWindow is the scheduled period during which a product is on sale.
+// Either bound may be open (nil).
+type SaleWindow struct {
+	startsAt *time.Time
+	endsAt   *time.Time
+}
+
+func NewSaleWindow(startsAt, endsAt *time.Time) (SaleWindow, error) {
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return SaleWindow{}, ErrInvalidSaleWindow
+	}
+	return SaleWindow{
+		startsAt: startsAt,
+		endsAt:   endsAt,
+	}, nil
+}
+
+func (w SaleWindow) StartsAt() *time.Time {
+	return w.startsAt
+}
+
+func (w SaleWindow) EndsAt() *time.Time {
+	return w.endsAt
+}
+
+// IsScheduled reports whether either bound is set
+func (w SaleWindow) IsScheduled() bool {
+	return w.startsAt != nil || w.endsAt != nil
+}
+
+// HasStarted reports whether the sale has started at the given time (open start = started)
+func (w SaleWindow) HasStarted(now time.Time) bool {
+	return w.startsAt == nil || !now.Before(*w.startsAt)
+}
+
+// HasEnded reports whether the sale has ended at the given time (open end = never)
+func (w SaleWindow) HasEnded(now time.Time) bool {
+	return w.endsAt != nil && !now.Before(*w.endsAt)
+}
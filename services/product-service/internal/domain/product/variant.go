@@ -0,0 +1,138 @@
+package product
+
+import (
+	"maps"
+	"regexp"
+	"strings"
+)
+
+const (
+	MaxVariants          = 100
+	MaxVariantAttributes = 10
+)
+
+// skuPattern allows seller SKUs such as "TSHIRT-RED-L" or "tee.red_l"
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+const variantAttributeMaxLength = 50
+
+// Variant is a purchasable option of a product (e.g. a size or colour).
+// Each variant has its own stock counter in stock-service.
+type Variant struct {
+	id         VariantID
+	sku        string
+	attributes map[string]string
+	// priceOverride replaces the product's current price for this variant (nil = product price)
+	priceOverride *Money
+	stockStatus   StockStatus
+}
+
+// NewVariant creates a variant with a new ID
+func NewVariant(sku string, attributes map[string]string, priceOverride *Money) (Variant, error) {
+	sku = strings.TrimSpace(sku)
+	if !skuPattern.MatchString(sku) {
+		return Variant{}, ErrInvalidSKU
+	}
+
+	normalized, err := normalizeVariantAttributes(attributes)
+	if err != nil {
+		return Variant{}, err
+	}
+
+	if priceOverride != nil && priceOverride.IsZero() {
+		return Variant{}, ErrInvalidVariantPrice
+	}
+
+	return Variant{
+		id:            NewVariantID(),
+		sku:           sku,
+		attributes:    normalized,
+		priceOverride: priceOverride,
+		stockStatus:   StockStatusUnknown,
+	}, nil
+}
+
+// ReconstructVariant reconstructs a variant from persistence (for Repository use)
+func ReconstructVariant(
+	id VariantID,
+	sku string,
+	attributes map[string]string,
+	priceOverride *Money,
+	stockStatus StockStatus,
+) Variant {
+	return Variant{
+		id:            id,
+		sku:           sku,
+		attributes:    attributes,
+		priceOverride: priceOverride,
+		stockStatus:   stockStatus,
+	}
+}
+
+func (v Variant) ID() VariantID {
+	return v.id
+}
+
+func (v Variant) SKU() string {
+	return v.sku
+}
+
+func (v Variant) Attributes() map[string]string {
+	return maps.Clone(v.attributes)
+}
+
+func (v Variant) PriceOverride() *Money {
+	return v.priceOverride
+}
+
+func (v Variant) StockStatus() StockStatus {
+	return v.stockStatus
+}
+
+// normalizeVariantAttributes trims attribute names and values, e.g. {"size": "L", "color": "red"}
+func normalizeVariantAttributes(raw map[string]string) (map[string]string, error) {
+	if len(raw) > MaxVariantAttributes {
+		return nil, ErrInvalidVariantAttributes
+	}
+
+	attributes := make(map[string]string, len(raw))
+	for key, value := range raw {
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "" || value == "" ||
+			len(key) > variantAttributeMaxLength || len(value) > variantAttributeMaxLength {
+			return nil, ErrInvalidVariantAttributes
+		}
+		attributes[key] = value
+	}
+	return attributes, nil
+}
+
+// aggregateStockStatus derives the product's stock status from its variants:
+// the product is in stock while any variant is, and out of stock once all of them are
+func aggregateStockStatus(variants []Variant) StockStatus {
+	if len(variants) == 0 {
+		return StockStatusUnknown
+	}
+
+	var low, out int
+	for _, v := range variants {
+		switch v.stockStatus {
+		case StockStatusInStock:
+			return StockStatusInStock
+		case StockStatusLowStock:
+			low++
+		case StockStatusOutOfStock:
+			out++
+		}
+	}
+
+	switch {
+	case low > 0:
+		return StockStatusLowStock
+	case out == len(variants):
+		return StockStatusOutOfStock
+	default:
+		return StockStatusUnknown
+	}
+}
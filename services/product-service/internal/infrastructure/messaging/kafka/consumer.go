@@ -4,9 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/config"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -15,15 +23,22 @@ type EventHandler interface {
 	Handle(ctx context.Context, msg *EventMessage) error
 }
 
-// Consumer wraps Kafka consumer for consuming events
+// Consumer wraps Kafka consumer for consuming events.
+// A message whose handler keeps failing is retried with exponential backoff
+// and then moved to the dead-letter queue before its offset is committed.
 type Consumer struct {
-	reader  *kafka.Reader
-	handler EventHandler
-	topic   string
+	reader          *kafka.Reader
+	handler         EventHandler
+	deadLetters     *DeadLetterQueue
+	topic           string
+	groupID         string
+	maxAttempts     int
+	retryBackoff    time.Duration
+	retryMaxBackoff time.Duration
 }
 
 // NewConsumer creates a new Kafka consumer
-func NewConsumer(cfg *config.KafkaConfig, handler EventHandler) *Consumer {
+func NewConsumer(cfg *config.KafkaConfig, handler EventHandler, deadLetters *DeadLetterQueue) *Consumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:        cfg.Brokers,
 		Topic:          cfg.ConsumerTopic,
@@ -35,9 +50,14 @@ func NewConsumer(cfg *config.KafkaConfig, handler EventHandler) *Consumer {
 	})
 
 	return &Consumer{
-		reader:  reader,
-		handler: handler,
-		topic:   cfg.ConsumerTopic,
+		reader:          reader,
+		handler:         handler,
+		deadLetters:     deadLetters,
+		topic:           cfg.ConsumerTopic,
+		groupID:         cfg.ConsumerGroupID,
+		maxAttempts:     cfg.ConsumerMaxAttempts,
+		retryBackoff:    cfg.ConsumerRetryBackoff,
+		retryMaxBackoff: cfg.ConsumerRetryMaxBackoff,
 	}
 }
 
@@ -60,6 +80,7 @@ func (c *Consumer) Start(ctx context.Context) error {
 			)
 			continue
 		}
+		metrics.SetConsumerLag(c.topic, c.groupID, msg.Partition, msg.HighWaterMark-msg.Offset-1)
 
 		var eventMsg EventMessage
 		if err := json.Unmarshal(msg.Value, &eventMsg); err != nil {
@@ -67,6 +88,11 @@ func (c *Consumer) Start(ctx context.Context) error {
 				zap.String("topic", c.topic),
 				zap.Error(err),
 			)
+			// Malformed messages never succeed, so skip the retries
+			if !c.sendToDeadLetter(ctx, msg, 1, err) {
+				return nil
+			}
+			metrics.RecordConsumedMessage(c.topic, c.groupID, "dead_lettered")
 			if err := c.reader.CommitMessages(ctx, msg); err != nil {
 				zap.L().Error("failed to commit bad message",
 					zap.Error(err),
@@ -81,14 +107,25 @@ func (c *Consumer) Start(ctx context.Context) error {
 			zap.String("event_id", eventMsg.EventID),
 		)
 
-		if err := c.handler.Handle(ctx, &eventMsg); err != nil {
-			zap.L().Error("failed to handle kafka message",
+		if attempts, err := c.handleMessage(ctx, msg, &eventMsg); err != nil {
+			if ctx.Err() != nil {
+				// Leave the offset uncommitted so the message is redelivered
+				zap.L().Info("kafka consumer shutting down")
+				return nil
+			}
+			zap.L().Error("failed to handle kafka message, moving to dead-letter queue",
 				zap.String("topic", c.topic),
 				zap.String("event_type", eventMsg.EventType),
 				zap.String("event_id", eventMsg.EventID),
+				zap.Int("attempts", attempts),
 				zap.Error(err),
 			)
-			// TODO: Implement retry logic or dead letter queue
+			if !c.sendToDeadLetter(ctx, msg, attempts, err) {
+				return nil
+			}
+			metrics.RecordConsumedMessage(c.topic, c.groupID, "dead_lettered")
+		} else {
+			metrics.RecordConsumedMessage(c.topic, c.groupID, "processed")
 		}
 
 		if err := c.reader.CommitMessages(ctx, msg); err != nil {
@@ -101,6 +138,85 @@ func (c *Consumer) Start(ctx context.Context) error {
 	}
 }
 
+// handleMessage handles a message in a consumer span that continues the producer's trace
+func (c *Consumer) handleMessage(ctx context.Context, msg kafka.Message, eventMsg *EventMessage) (int, error) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{headers: &msg.Headers})
+	ctx, span := tracing.Tracer().Start(ctx, c.topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(messagingAttributes(c.topic, eventMsg)...),
+		trace.WithAttributes(attribute.String("messaging.consumer.group.name", c.groupID)),
+	)
+	defer span.End()
+	ctx = logger.WithTraceID(ctx, tracing.TraceID(ctx))
+
+	attempts, err := c.handleWithRetry(ctx, eventMsg)
+	span.SetAttributes(attribute.Int("messaging.attempts", attempts))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to handle message")
+	}
+	return attempts, err
+}
+
+// handleWithRetry handles a message, retrying failures with exponential backoff.
+// It returns the number of attempts made and the last error.
+func (c *Consumer) handleWithRetry(ctx context.Context, eventMsg *EventMessage) (int, error) {
+	backoff := c.retryBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := c.handler.Handle(ctx, eventMsg)
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt >= c.maxAttempts {
+			return attempt, err
+		}
+
+		zap.L().Warn("failed to handle kafka message, retrying",
+			zap.String("topic", c.topic),
+			zap.String("event_type", eventMsg.EventType),
+			zap.String("event_id", eventMsg.EventID),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, c.retryMaxBackoff)
+	}
+}
+
+// sendToDeadLetter keeps trying until the message is on the dead-letter topic,
+// since committing it otherwise would lose the event.
+// It returns false if the consumer is shutting down.
+func (c *Consumer) sendToDeadLetter(ctx context.Context, msg kafka.Message, attempts int, cause error) bool {
+	backoff := c.retryBackoff
+
+	for {
+		err := c.deadLetters.Publish(ctx, msg, c.groupID, attempts, cause)
+		if err == nil {
+			return true
+		}
+
+		zap.L().Error("failed to publish dead letter",
+			zap.String("topic", c.topic),
+			zap.Int64("offset", msg.Offset),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, c.retryMaxBackoff)
+	}
+}
+
 // Close closes the consumer
 func (c *Consumer) Close() error {
 	if err := c.reader.Close(); err != nil {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/application/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/domain/product"
 	"go.uber.org/zap"
 )
 
@@ -26,6 +27,12 @@ func (h *StockEventHandler) Handle(ctx context.Context, msg *EventMessage) error
 	switch msg.EventType {
 	case "stock.depleted":
 		return h.handleStockDepleted(ctx, msg)
+	case "stock.set", "stock.adjusted":
+		return h.handleStockSet(ctx, msg)
+	case "stock.released":
+		return h.handleStockReleased(ctx, msg)
+	case "stock.low":
+		return h.handleStockStatus(ctx, msg, product.StockStatusLowStock)
 	default:
 		logger.DebugContext(ctx, "unknown stock event type",
 			zap.String("event_type", msg.EventType),
@@ -45,6 +52,11 @@ func (h *StockEventHandler) handleStockDepleted(ctx context.Context, msg *EventM
 		return fmt.Errorf("missing or invalid product_id in event data")
 	}
 
+	// A depleted variant only sells out the product once every variant has run out
+	if variantID, _ := msg.Data["variant_id"].(string); variantID != "" {
+		return h.handleStockStatus(ctx, msg, product.StockStatusOutOfStock)
+	}
+
 	logger.InfoContext(ctx, "handling stock.depleted event",
 		zap.String("product_id", productID),
 		zap.String("event_id", msg.EventID),
@@ -66,3 +78,57 @@ func (h *StockEventHandler) handleStockDepleted(ctx context.Context, msg *EventM
 
 	return nil
 }
+
+// handleStockSet handles stock.set and stock.adjusted events, whose quantity is the stock
+// after the change
+func (h *StockEventHandler) handleStockSet(ctx context.Context, msg *EventMessage) error {
+	quantity, _ := msg.Data["quantity"].(float64) // JSON numbers are float64
+	if quantity > 0 {
+		return h.handleStockStatus(ctx, msg, product.StockStatusInStock)
+	}
+	return h.handleStockStatus(ctx, msg, product.StockStatusOutOfStock)
+}
+
+// handleStockReleased handles stock.released, which carries the stock after the release and
+// the low-stock threshold. Released stock is back on sale, and still low if below the threshold.
+func (h *StockEventHandler) handleStockReleased(ctx context.Context, msg *EventMessage) error {
+	quantity, hasQuantity := msg.Data["stock_quantity"].(float64) // JSON numbers are float64
+	threshold, hasThreshold := msg.Data["low_stock_threshold"].(float64)
+	if hasQuantity && hasThreshold && quantity < threshold {
+		return h.handleStockStatus(ctx, msg, product.StockStatusLowStock)
+	}
+	return h.handleStockStatus(ctx, msg, product.StockStatusInStock)
+}
+
+// handleStockStatus records the stock status of a product or, when the event has a variant_id, of a variant
+func (h *StockEventHandler) handleStockStatus(ctx context.Context, msg *EventMessage, status product.StockStatus) error {
+	productID, ok := msg.Data["product_id"].(string)
+	if !ok {
+		logger.ErrorContext(ctx, "missing or invalid product_id in stock event",
+			zap.String("event_type", msg.EventType),
+			zap.String("event_id", msg.EventID),
+		)
+		return fmt.Errorf("missing or invalid product_id in event data")
+	}
+	variantID, _ := msg.Data["variant_id"].(string)
+
+	logger.InfoContext(ctx, "handling stock status event",
+		zap.String("event_type", msg.EventType),
+		zap.String("product_id", productID),
+		zap.String("variant_id", variantID),
+		zap.String("stock_status", string(status)),
+		zap.String("event_id", msg.EventID),
+	)
+
+	if err := h.productService.UpdateStockStatus(ctx, productID, variantID, status); err != nil {
+		logger.ErrorContext(ctx, "failed to update stock status",
+			zap.String("product_id", productID),
+			zap.String("variant_id", variantID),
+			zap.String("event_id", msg.EventID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to update stock status: %w", err)
+	}
+
+	return nil
+}
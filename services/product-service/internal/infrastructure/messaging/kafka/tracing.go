@@ -0,0 +1,49 @@
+package kafka
+
+import (
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// headerCarrier adapts Kafka message headers to propagation.TextMapCarrier,
+// so the trace context travels with the message as a traceparent header
+type headerCarrier struct {
+	headers *[]kafka.Header
+}
+
+func (c headerCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c headerCarrier) Set(key, value string) {
+	for i, h := range *c.headers {
+		if h.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, h := range *c.headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// messagingAttributes describes a message on a span
+func messagingAttributes(topic string, msg *EventMessage) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.destination.name", topic),
+		attribute.String("messaging.message.id", msg.EventID),
+		attribute.String("messaging.event_type", msg.EventType),
+	}
+}
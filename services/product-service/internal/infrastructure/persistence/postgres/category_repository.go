@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/domain/product"
+	"github.com/jmoiron/sqlx"
+)
+
+// CategoryRepository implements product.CategoryRepository interface
+//
+//	CREATE TABLE categories (
+//	    id         UUID PRIMARY KEY,
+//	    parent_id  UUID REFERENCES categories (id),
+//	    name       VARCHAR(100) NOT NULL,
+//	    created_at TIMESTAMPTZ NOT NULL
+//	);
+//	CREATE INDEX categories_parent_idx ON categories (parent_id);
+//
+//	ALTER TABLE products
+//	    ADD COLUMN category_id UUID REFERENCES categories (id),
+//	    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+//	    ADD COLUMN images JSONB NOT NULL DEFAULT '[]';
+type CategoryRepository struct {
+	db *sqlx.DB
+}
+
+// NewCategoryRepository creates a new CategoryRepository
+func NewCategoryRepository(db *sqlx.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+// Save inserts a new category
+func (r *CategoryRepository) Save(ctx context.Context, c *product.Category) error {
+	model := CategoryModel{
+		ID:        c.ID().String(),
+		Name:      c.Name(),
+		CreatedAt: c.CreatedAt(),
+	}
+	if parentID := c.ParentID(); parentID != nil {
+		model.ParentID = sql.NullString{String: parentID.String(), Valid: true}
+	}
+
+	query := `
+		INSERT INTO categories (id, parent_id, name, created_at)
+		VALUES (:id, :parent_id, :name, :created_at)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save category: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a category by ID
+func (r *CategoryRepository) FindByID(ctx context.Context, id product.CategoryID) (*product.Category, error) {
+	query := `
+		SELECT id, parent_id, name, created_at
+		FROM categories
+		WHERE id = $1
+	`
+
+	var model CategoryModel
+	if err := r.db.GetContext(ctx, &model, query, id.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, product.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+
+	return categoryModelToDomain(&model)
+}
+
+// FindAll finds all categories ordered by name
+func (r *CategoryRepository) FindAll(ctx context.Context) ([]*product.Category, error) {
+	query := `
+		SELECT id, parent_id, name, created_at
+		FROM categories
+		ORDER BY name
+	`
+
+	var models []CategoryModel
+	if err := r.db.SelectContext(ctx, &models, query); err != nil {
+		return nil, fmt.Errorf("failed to find categories: %w", err)
+	}
+
+	categories := make([]*product.Category, 0, len(models))
+	for _, model := range models {
+		c, err := categoryModelToDomain(&model)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+
+	return categories, nil
+}
+
+func categoryModelToDomain(model *CategoryModel) (*product.Category, error) {
+	id, err := product.ParseCategoryID(model.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *product.CategoryID
+	if model.ParentID.Valid {
+		pid, err := product.ParseCategoryID(model.ParentID.String)
+		if err != nil {
+			return nil, err
+		}
+		parentID = &pid
+	}
+
+	return product.ReconstructCategory(id, parentID, model.Name, model.CreatedAt), nil
+}
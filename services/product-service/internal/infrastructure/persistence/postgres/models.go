@@ -3,19 +3,54 @@ package postgres
 import (
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // ProductModel represents the database model for products
 type ProductModel struct {
-	ID             string        `db:"id"`
-	SellerID       string        `db:"seller_id"`
-	Name           string        `db:"name"`
-	Description    string        `db:"description"`
-	RegularPrice   int64         `db:"regular_price"`
-	FlashSalePrice sql.NullInt64 `db:"flash_sale_price"`
-	Currency       string        `db:"currency"`
-	Status         string        `db:"status"`
-	StockStatus    string        `db:"stock_status"`
-	CreatedAt      time.Time     `db:"created_at"`
-	UpdatedAt      time.Time     `db:"updated_at"`
+	ID             string         `db:"id"`
+	SellerID       string         `db:"seller_id"`
+	Name           string         `db:"name"`
+	Description    string         `db:"description"`
+	RegularPrice   int64          `db:"regular_price"`
+	FlashSalePrice sql.NullInt64  `db:"flash_sale_price"`
+	Currency       string         `db:"currency"`
+	PriceType      string         `db:"price_type"`
+	Status         string         `db:"status"`
+	StockStatus    string         `db:"stock_status"`
+	PurchaseLimit  int            `db:"purchase_limit"`
+	SaleStartsAt   sql.NullTime   `db:"sale_starts_at"`
+	SaleEndsAt     sql.NullTime   `db:"sale_ends_at"`
+	CategoryID     sql.NullString `db:"category_id"`
+	Tags           pq.StringArray `db:"tags"`
+	Images         []byte         `db:"images"`   // JSONB array of ImageModel, in display order
+	Variants       []byte         `db:"variants"` // JSONB array of VariantModel
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+// ImageModel is one element of the products.images JSONB column
+type ImageModel struct {
+	ID          string `json:"id"`
+	StorageKey  string `json:"storage_key"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+}
+
+// VariantModel is one element of the products.variants JSONB column
+type VariantModel struct {
+	ID            string            `json:"id"`
+	SKU           string            `json:"sku"`
+	Attributes    map[string]string `json:"attributes"`
+	PriceOverride *int64            `json:"price_override,omitempty"` // in the product's currency
+	StockStatus   string            `json:"stock_status"`
+}
+
+// CategoryModel represents the database model for categories
+type CategoryModel struct {
+	ID        string         `db:"id"`
+	ParentID  sql.NullString `db:"parent_id"`
+	Name      string         `db:"name"`
+	CreatedAt time.Time      `db:"created_at"`
 }
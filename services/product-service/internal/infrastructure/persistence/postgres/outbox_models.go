@@ -23,6 +23,7 @@ type OutboxEvent struct {
 	RetryCount    int
 	LastError     *string
 	NextRetryAt   *time.Time
+	TraceContext  map[string]string // W3C trace context of the request that produced the event
 }
 
 // OutboxEventModel represents the database model
@@ -39,6 +40,7 @@ type OutboxEventModel struct {
 	RetryCount    int            `db:"retry_count"`
 	LastError     sql.NullString `db:"last_error"`
 	NextRetryAt   sql.NullTime   `db:"next_retry_at"`
+	TraceContext  []byte         `db:"trace_context"`
 }
 
 // NewOutboxEvent creates a new OutboxEvent
@@ -68,6 +70,11 @@ func (e *OutboxEvent) toModel() (*OutboxEventModel, error) {
 		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
+	traceContextJSON, err := marshalTraceContext(e.TraceContext)
+	if err != nil {
+		return nil, err
+	}
+
 	model := &OutboxEventModel{
 		ID:            e.ID,
 		AggregateType: e.AggregateType,
@@ -78,6 +85,7 @@ func (e *OutboxEvent) toModel() (*OutboxEventModel, error) {
 		Status:        e.Status,
 		CreatedAt:     e.CreatedAt,
 		RetryCount:    e.RetryCount,
+		TraceContext:  traceContextJSON,
 	}
 
 	if e.ProcessedAt != nil {
@@ -121,6 +129,23 @@ func fromModel(model *OutboxEventModel) (*OutboxEvent, error) {
 	if model.NextRetryAt.Valid {
 		event.NextRetryAt = &model.NextRetryAt.Time
 	}
+	if len(model.TraceContext) > 0 {
+		if err := json.Unmarshal(model.TraceContext, &event.TraceContext); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal trace context: %w", err)
+		}
+	}
 
 	return event, nil
 }
+
+// marshalTraceContext encodes a trace context for the trace_context column, NULL when empty
+func marshalTraceContext(traceContext map[string]string) ([]byte, error) {
+	if len(traceContext) == 0 {
+		return nil, nil
+	}
+	traceContextJSON, err := json.Marshal(traceContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal trace context: %w", err)
+	}
+	return traceContextJSON, nil
+}
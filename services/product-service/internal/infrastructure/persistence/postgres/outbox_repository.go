@@ -2,15 +2,25 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/tracing"
 	"github.com/jmoiron/sqlx"
 	"github.com/samborkent/uuidv7"
 )
 
+// ErrOutboxClaimLost is returned when an event's claim expired and was taken over by another relay
+var ErrOutboxClaimLost = errors.New("outbox event claim lost")
+
 // OutboxRepository manages outbox events
+//
+//	CREATE INDEX outbox_events_unpublished_aggregate_idx ON outbox_events (aggregate_id, created_at, id)
+//		WHERE status IN ('PENDING', 'RETRY');
 type OutboxRepository struct {
 	db *sqlx.DB
 }
@@ -25,8 +35,8 @@ func (r *OutboxRepository) Insert(ctx context.Context, event *OutboxEvent) error
 	query := `
 		INSERT INTO outbox_events (
 			id, aggregate_type, aggregate_id, event_type, event_id,
-			payload, status, created_at, retry_count
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			payload, status, created_at, retry_count, trace_context
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	payloadJSON, err := json.Marshal(event.Payload)
@@ -34,6 +44,15 @@ func (r *OutboxRepository) Insert(ctx context.Context, event *OutboxEvent) error
 		return err
 	}
 
+	// Keep the caller's trace so the relay can continue it
+	if event.TraceContext == nil {
+		event.TraceContext = tracing.Inject(ctx)
+	}
+	traceContextJSON, err := marshalTraceContext(event.TraceContext)
+	if err != nil {
+		return err
+	}
+
 	_, err = r.db.ExecContext(
 		ctx,
 		query,
@@ -46,30 +65,57 @@ func (r *OutboxRepository) Insert(ctx context.Context, event *OutboxEvent) error
 		event.Status,
 		event.CreatedAt,
 		event.RetryCount,
+		traceContextJSON,
 	)
 
 	return err
 }
 
-// FindPending finds pending events (for outbox relay worker)
-func (r *OutboxRepository) FindPending(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+// ClaimPending claims up to limit due events for relayID until the lease expires (for outbox relay worker).
+// Rows locked or claimed by another relay are skipped, and claims with an expired lease are taken over.
+// Only the oldest unpublished event of each product is claimable, so an event backing off for a
+// retry holds back the product's later events instead of being overtaken by them.
+func (r *OutboxRepository) ClaimPending(ctx context.Context, relayID string, lease time.Duration, limit int) ([]*OutboxEvent, error) {
 	query := `
-		SELECT id, aggregate_type, aggregate_id, event_type, event_id,
+		UPDATE outbox_events
+		SET claimed_by = $1,
+		    claimed_until = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT candidate.id
+			FROM outbox_events candidate
+			WHERE candidate.status IN ('PENDING', 'RETRY')
+			  AND (candidate.next_retry_at IS NULL OR candidate.next_retry_at <= NOW())
+			  AND (candidate.claimed_until IS NULL OR candidate.claimed_until < NOW())
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM outbox_events earlier
+				WHERE earlier.aggregate_id = candidate.aggregate_id
+				  AND earlier.status IN ('PENDING', 'RETRY')
+				  AND (earlier.created_at, earlier.id) < (candidate.created_at, candidate.id)
+			  )
+			ORDER BY candidate.created_at ASC, candidate.id ASC
+			LIMIT $3
+			FOR UPDATE OF candidate SKIP LOCKED
+		)
+		RETURNING id, aggregate_type, aggregate_id, event_type, event_id,
 			   payload, status, created_at, processed_at,
-			   retry_count, last_error, next_retry_at
-		FROM outbox_events
-		WHERE status IN ('PENDING', 'RETRY')
-		  AND (next_retry_at IS NULL OR next_retry_at <= NOW())
-		ORDER BY created_at ASC
-		LIMIT $1
+			   retry_count, last_error, next_retry_at, trace_context
 	`
 
 	var models []OutboxEventModel
-	err := r.db.SelectContext(ctx, &models, query, limit)
+	err := r.db.SelectContext(ctx, &models, query, relayID, lease.Milliseconds(), limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find pending events: %w", err)
+		return nil, fmt.Errorf("failed to claim pending events: %w", err)
 	}
 
+	// RETURNING does not keep the subquery order
+	sort.Slice(models, func(i, j int) bool {
+		if !models[i].CreatedAt.Equal(models[j].CreatedAt) {
+			return models[i].CreatedAt.Before(models[j].CreatedAt)
+		}
+		return models[i].ID < models[j].ID
+	})
+
 	events := make([]*OutboxEvent, 0, len(models))
 	for _, model := range models {
 		event, err := fromModel(&model)
@@ -82,28 +128,39 @@ func (r *OutboxRepository) FindPending(ctx context.Context, limit int) ([]*Outbo
 	return events, nil
 }
 
-// MarkAsProcessed marks event as processed
-func (r *OutboxRepository) MarkAsProcessed(ctx context.Context, id string) error {
+// MarkAsProcessed marks a claimed event as processed and releases the claim
+func (r *OutboxRepository) MarkAsProcessed(ctx context.Context, id string, relayID string) error {
 	if !uuidv7.IsValidString(id) {
 		return fmt.Errorf("invalid uuid: %s", id)
 	}
 
 	query := `
 		UPDATE outbox_events
-		SET status = 'SENT', processed_at = NOW()
-		WHERE id = $1
+		SET status = 'SENT', processed_at = NOW(),
+		    claimed_by = NULL, claimed_until = NULL
+		WHERE id = $1 AND claimed_by = $2
 	`
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, id, relayID)
 	if err != nil {
 		return fmt.Errorf("failed to mark as processed: %w", err)
 	}
 
-	return nil
+	return claimResult(result)
 }
 
-// IncrementRetry increments retry count
-func (r *OutboxRepository) IncrementRetry(ctx context.Context, id string, errorMsg string) error {
+// IncrementRetry records a failed attempt of a claimed event and releases the claim.
+// The next attempt waits baseDelay * 2^retry_count, capped at maxDelay;
+// after maxRetries failed attempts the event is marked FAILED.
+func (r *OutboxRepository) IncrementRetry(
+	ctx context.Context,
+	id string,
+	relayID string,
+	errorMsg string,
+	baseDelay time.Duration,
+	maxDelay time.Duration,
+	maxRetries int,
+) error {
 	if !uuidv7.IsValidString(id) {
 		return fmt.Errorf("invalid uuid: %s", id)
 	}
@@ -112,16 +169,68 @@ func (r *OutboxRepository) IncrementRetry(ctx context.Context, id string, errorM
 		UPDATE outbox_events
 		SET retry_count = retry_count + 1,
 		    last_error = $1,
-		    next_retry_at = NOW() + INTERVAL '1 minute' * POW(2, retry_count),
-		    status = CASE WHEN retry_count >= 5 THEN 'FAILED' ELSE 'RETRY' END
-		WHERE id = $2
+		    next_retry_at = NOW() + LEAST($2 * POW(2, retry_count), $3) * INTERVAL '1 millisecond',
+		    status = CASE WHEN retry_count + 1 >= $4 THEN 'FAILED' ELSE 'RETRY' END,
+		    claimed_by = NULL, claimed_until = NULL
+		WHERE id = $5 AND claimed_by = $6
 	`
 
-	_, err := r.db.ExecContext(ctx, query, errorMsg, id)
+	result, err := r.db.ExecContext(ctx, query,
+		errorMsg,
+		baseDelay.Milliseconds(),
+		maxDelay.Milliseconds(),
+		maxRetries,
+		id,
+		relayID,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to increment retry: %w", err)
 	}
 
+	return claimResult(result)
+}
+
+// Backlog returns the number of events waiting to be relayed and the age of the oldest one
+func (r *OutboxRepository) Backlog(ctx context.Context) (int64, time.Duration, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(created_at)), 0)
+		FROM outbox_events
+		WHERE status IN ('PENDING', 'RETRY')
+	`
+
+	var count int64
+	var oldestAgeSeconds float64
+	if err := r.db.QueryRowContext(ctx, query).Scan(&count, &oldestAgeSeconds); err != nil {
+		return 0, 0, fmt.Errorf("failed to query outbox backlog: %w", err)
+	}
+
+	return count, time.Duration(oldestAgeSeconds * float64(time.Second)), nil
+}
+
+// ReleaseClaims releases every unfinished claim held by relayID
+func (r *OutboxRepository) ReleaseClaims(ctx context.Context, relayID string) error {
+	query := `
+		UPDATE outbox_events
+		SET claimed_by = NULL, claimed_until = NULL
+		WHERE claimed_by = $1 AND status IN ('PENDING', 'RETRY')
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, relayID); err != nil {
+		return fmt.Errorf("failed to release outbox claims: %w", err)
+	}
+
+	return nil
+}
+
+// claimResult reports ErrOutboxClaimLost when an update guarded by claimed_by matched no row
+func claimResult(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrOutboxClaimLost
+	}
 	return nil
 }
 
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/domain/product"
 )
@@ -9,16 +11,19 @@ import (
 // DomainToModel converts domain Product to database ProductModel
 func DomainToModel(p *product.Product) *ProductModel {
 	model := &ProductModel{
-		ID:           p.ID().String(),
-		SellerID:     p.SellerID().String(),
-		Name:         p.Name(),
-		Description:  p.Description(),
-		RegularPrice: p.Pricing().RegularPrice().Amount(),
-		Currency:     p.Pricing().RegularPrice().Currency(),
-		Status:       string(p.Status()),
-		StockStatus:  string(p.StockStatus()),
-		CreatedAt:    p.CreatedAt(),
-		UpdatedAt:    p.UpdatedAt(),
+		ID:            p.ID().String(),
+		SellerID:      p.SellerID().String(),
+		Name:          p.Name(),
+		Description:   p.Description(),
+		RegularPrice:  p.Pricing().RegularPrice().Amount(),
+		Currency:      p.Pricing().RegularPrice().Currency(),
+		PriceType:     string(p.Pricing().PriceType()),
+		Status:        string(p.Status()),
+		StockStatus:   string(p.StockStatus()),
+		PurchaseLimit: p.PurchaseLimit(),
+		Tags:          p.Tags(),
+		CreatedAt:     p.CreatedAt(),
+		UpdatedAt:     p.UpdatedAt(),
 	}
 
 	// Handle optional flash sale price
@@ -30,6 +35,46 @@ func DomainToModel(p *product.Product) *ProductModel {
 		}
 	}
 
+	// Handle optional sale window
+	if startsAt := p.SaleWindow().StartsAt(); startsAt != nil {
+		model.SaleStartsAt = sql.NullTime{Time: *startsAt, Valid: true}
+	}
+	if endsAt := p.SaleWindow().EndsAt(); endsAt != nil {
+		model.SaleEndsAt = sql.NullTime{Time: *endsAt, Valid: true}
+	}
+
+	if categoryID := p.CategoryID(); categoryID != nil {
+		model.CategoryID = sql.NullString{String: categoryID.String(), Valid: true}
+	}
+
+	images := make([]ImageModel, 0, len(p.Images()))
+	for _, image := range p.Images() {
+		images = append(images, ImageModel{
+			ID:          image.ID().String(),
+			StorageKey:  image.StorageKey(),
+			URL:         image.URL(),
+			ContentType: image.ContentType(),
+		})
+	}
+	// Marshalling plain strings cannot fail
+	model.Images, _ = json.Marshal(images)
+
+	variants := make([]VariantModel, 0, len(p.Variants()))
+	for _, v := range p.Variants() {
+		vm := VariantModel{
+			ID:          v.ID().String(),
+			SKU:         v.SKU(),
+			Attributes:  v.Attributes(),
+			StockStatus: string(v.StockStatus()),
+		}
+		if override := v.PriceOverride(); override != nil {
+			amount := override.Amount()
+			vm.PriceOverride = &amount
+		}
+		variants = append(variants, vm)
+	}
+	model.Variants, _ = json.Marshal(variants)
+
 	return model
 }
 
@@ -53,7 +98,12 @@ func ModelToDomain(model *ProductModel) (*product.Product, error) {
 	}
 
 	var pricing product.Pricing
-	if model.FlashSalePrice.Valid {
+	if product.PriceType(model.PriceType) == product.PriceTypeAuction {
+		pricing, err = product.NewAuctionPricing(regularPrice)
+		if err != nil {
+			return nil, err
+		}
+	} else if model.FlashSalePrice.Valid {
 		flashPrice, err := product.NewMoney(model.FlashSalePrice.Int64, model.Currency)
 		if err != nil {
 			return nil, err
@@ -69,6 +119,59 @@ func ModelToDomain(model *ProductModel) (*product.Product, error) {
 		}
 	}
 
+	saleWindow, err := product.NewSaleWindow(
+		nullTimeToPtr(model.SaleStartsAt),
+		nullTimeToPtr(model.SaleEndsAt),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var categoryID *product.CategoryID
+	if model.CategoryID.Valid {
+		id, err := product.ParseCategoryID(model.CategoryID.String)
+		if err != nil {
+			return nil, err
+		}
+		categoryID = &id
+	}
+
+	var imageModels []ImageModel
+	if len(model.Images) > 0 {
+		if err := json.Unmarshal(model.Images, &imageModels); err != nil {
+			return nil, err
+		}
+	}
+	images := make([]product.Image, 0, len(imageModels))
+	for _, m := range imageModels {
+		images = append(images, product.NewImage(product.ImageID(m.ID), m.StorageKey, m.URL, m.ContentType))
+	}
+
+	var variantModels []VariantModel
+	if len(model.Variants) > 0 {
+		if err := json.Unmarshal(model.Variants, &variantModels); err != nil {
+			return nil, err
+		}
+	}
+	variants := make([]product.Variant, 0, len(variantModels))
+	for _, m := range variantModels {
+		var priceOverride *product.Money
+		if m.PriceOverride != nil {
+			money, err := product.NewMoney(*m.PriceOverride, model.Currency)
+			if err != nil {
+				return nil, err
+			}
+			priceOverride = &money
+		}
+		variants = append(variants, product.ReconstructVariant(
+			product.VariantID(m.ID),
+			m.SKU,
+			m.Attributes,
+			priceOverride,
+			product.StockStatus(m.StockStatus),
+		))
+	}
+
 	// Reconstruct product
 	return product.ReconstructProduct(
 		productID,
@@ -78,7 +181,20 @@ func ModelToDomain(model *ProductModel) (*product.Product, error) {
 		pricing,
 		product.ProductStatus(model.Status),
 		product.StockStatus(model.StockStatus),
+		model.PurchaseLimit,
+		saleWindow,
+		categoryID,
+		[]string(model.Tags),
+		images,
+		variants,
 		model.CreatedAt,
 		model.UpdatedAt,
 	), nil
 }
+
+func nullTimeToPtr(nt sql.NullTime) *time.Time {
+	if nt.Valid {
+		return &nt.Time
+	}
+	return nil
+}
@@ -5,14 +5,31 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/logger"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/domain/product"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
 // ProductRepository implements product.Repository interface (read + simple write)
+//
+//	ALTER TABLE products ADD COLUMN variants JSONB NOT NULL DEFAULT '[]';
+//	ALTER TABLE products ADD COLUMN schedule_claimed_until TIMESTAMPTZ;
+//
+// Search needs the full-text index and one keyset index per sort order:
+//
+//	CREATE INDEX products_search_idx ON products
+//	    USING GIN (to_tsvector('english', name || ' ' || description));
+//	CREATE INDEX products_active_created_idx ON products (created_at DESC, id DESC)
+//	    WHERE status = 'ACTIVE';
+//	CREATE INDEX products_active_price_idx ON products ((COALESCE(flash_sale_price, regular_price)), id)
+//	    WHERE status = 'ACTIVE';
+//	CREATE INDEX products_tags_idx ON products USING GIN (tags);
+//	CREATE INDEX products_category_idx ON products (category_id);
 type ProductRepository struct {
 	db *sqlx.DB
 }
@@ -29,12 +46,16 @@ func (r *ProductRepository) Save(ctx context.Context, p *product.Product) error
 	query := `
 		INSERT INTO products (
 			id, seller_id, name, description,
-			regular_price, flash_sale_price, currency,
-			status, stock_status, created_at, updated_at
+			regular_price, flash_sale_price, currency, price_type,
+			status, stock_status, purchase_limit,
+			sale_starts_at, sale_ends_at, category_id, tags, images, variants,
+			created_at, updated_at
 		) VALUES (
 			:id, :seller_id, :name, :description,
-			:regular_price, :flash_sale_price, :currency,
-			:status, :stock_status, :created_at, :updated_at
+			:regular_price, :flash_sale_price, :currency, :price_type,
+			:status, :stock_status, :purchase_limit,
+			:sale_starts_at, :sale_ends_at, :category_id, :tags, :images, :variants,
+			:created_at, :updated_at
 		)
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
@@ -42,8 +63,16 @@ func (r *ProductRepository) Save(ctx context.Context, p *product.Product) error
 			regular_price = EXCLUDED.regular_price,
 			flash_sale_price = EXCLUDED.flash_sale_price,
 			currency = EXCLUDED.currency,
+			price_type = EXCLUDED.price_type,
 			status = EXCLUDED.status,
 			stock_status = EXCLUDED.stock_status,
+			purchase_limit = EXCLUDED.purchase_limit,
+			sale_starts_at = EXCLUDED.sale_starts_at,
+			sale_ends_at = EXCLUDED.sale_ends_at,
+			category_id = EXCLUDED.category_id,
+			tags = EXCLUDED.tags,
+			images = EXCLUDED.images,
+			variants = EXCLUDED.variants,
 			updated_at = EXCLUDED.updated_at
 	`
 
@@ -59,8 +88,10 @@ func (r *ProductRepository) Save(ctx context.Context, p *product.Product) error
 func (r *ProductRepository) FindByID(ctx context.Context, id product.ProductID) (*product.Product, error) {
 	query := `
 		SELECT id, seller_id, name, description,
-			   regular_price, flash_sale_price, currency,
-			   status, stock_status, created_at, updated_at
+			   regular_price, flash_sale_price, currency, price_type,
+			   status, stock_status, purchase_limit,
+			   sale_starts_at, sale_ends_at, category_id, tags, images, variants,
+			   created_at, updated_at
 		FROM products
 		WHERE id = $1
 	`
@@ -96,8 +127,10 @@ func (r *ProductRepository) FindBySeller(
 ) ([]*product.Product, error) {
 	query := `
 		SELECT id, seller_id, name, description,
-			   regular_price, flash_sale_price, currency,
-			   status, stock_status, created_at, updated_at
+			   regular_price, flash_sale_price, currency, price_type,
+			   status, stock_status, purchase_limit,
+			   sale_starts_at, sale_ends_at, category_id, tags, images, variants,
+			   created_at, updated_at
 		FROM products
 		WHERE seller_id = $1
 		ORDER BY created_at DESC
@@ -130,8 +163,10 @@ func (r *ProductRepository) FindByStatus(
 ) ([]*product.Product, error) {
 	query := `
 		SELECT id, seller_id, name, description,
-			   regular_price, flash_sale_price, currency,
-			   status, stock_status, created_at, updated_at
+			   regular_price, flash_sale_price, currency, price_type,
+			   status, stock_status, purchase_limit,
+			   sale_starts_at, sale_ends_at, category_id, tags, images, variants,
+			   created_at, updated_at
 		FROM products
 		WHERE status = $1
 		ORDER BY created_at DESC
@@ -156,14 +191,109 @@ func (r *ProductRepository) FindByStatus(
 	return products, nil
 }
 
+// searchDocument and currentPrice must match the expressions of products_search_idx and
+// products_active_price_idx for the planner to use them
+const (
+	searchDocument = `to_tsvector('english', name || ' ' || description)`
+	currentPrice   = `COALESCE(flash_sale_price, regular_price)`
+)
+
+// Search finds active products matching the criteria with keyset pagination
+func (r *ProductRepository) Search(
+	ctx context.Context,
+	criteria product.SearchCriteria,
+) ([]*product.Product, error) {
+	var (
+		conds = []string{"status = $1"}
+		args  = []interface{}{string(product.ProductStatusActive)}
+	)
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if criteria.Query != "" {
+		conds = append(conds, fmt.Sprintf("%s @@ websearch_to_tsquery('english', %s)", searchDocument, arg(criteria.Query)))
+	}
+	if criteria.Currency != "" {
+		conds = append(conds, "currency = "+arg(criteria.Currency))
+	}
+	if criteria.MinPrice != nil {
+		conds = append(conds, fmt.Sprintf("%s >= %s", currentPrice, arg(*criteria.MinPrice)))
+	}
+	if criteria.MaxPrice != nil {
+		conds = append(conds, fmt.Sprintf("%s <= %s", currentPrice, arg(*criteria.MaxPrice)))
+	}
+	if len(criteria.StockStatuses) > 0 {
+		placeholders := make([]string, 0, len(criteria.StockStatuses))
+		for _, s := range criteria.StockStatuses {
+			placeholders = append(placeholders, arg(string(s)))
+		}
+		conds = append(conds, fmt.Sprintf("stock_status IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if criteria.CategoryID != nil {
+		conds = append(conds, fmt.Sprintf(`category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = %s
+				UNION ALL
+				SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT id FROM subtree
+		)`, arg(criteria.CategoryID.String())))
+	}
+	if len(criteria.Tags) > 0 {
+		conds = append(conds, "tags @> "+arg(pq.Array(criteria.Tags)))
+	}
+
+	var orderBy string
+	switch criteria.Sort {
+	case product.SearchSortPriceAsc:
+		orderBy = currentPrice + " ASC, id ASC"
+		if after := criteria.After; after != nil {
+			conds = append(conds, fmt.Sprintf("(%s, id) > (%s, %s)", currentPrice, arg(after.Price), arg(after.ID.String())))
+		}
+	case product.SearchSortPriceDesc:
+		orderBy = currentPrice + " DESC, id DESC"
+		if after := criteria.After; after != nil {
+			conds = append(conds, fmt.Sprintf("(%s, id) < (%s, %s)", currentPrice, arg(after.Price), arg(after.ID.String())))
+		}
+	default:
+		orderBy = "created_at DESC, id DESC"
+		if after := criteria.After; after != nil {
+			conds = append(conds, fmt.Sprintf("(created_at, id) < (%s, %s)", arg(after.CreatedAt), arg(after.ID.String())))
+		}
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, seller_id, name, description,
+			   regular_price, flash_sale_price, currency, price_type,
+			   status, stock_status, purchase_limit,
+			   sale_starts_at, sale_ends_at, category_id, tags, images, variants,
+			   created_at, updated_at
+		FROM products
+		WHERE %s
+		ORDER BY %s
+		LIMIT %s
+	`, strings.Join(conds, " AND "), orderBy, arg(criteria.Limit))
+
+	var models []ProductModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+
+	return modelsToDomain(models)
+}
+
 // FindActiveProducts finds all active products with pagination
 func (r *ProductRepository) FindAllActiveProducts(
 	ctx context.Context,
 ) ([]*product.Product, error) {
 	query := `
 		SELECT id, seller_id, name, description,
-			   regular_price, flash_sale_price, currency,
-			   status, stock_status, created_at, updated_at
+			   regular_price, flash_sale_price, currency, price_type,
+			   status, stock_status, purchase_limit,
+			   sale_starts_at, sale_ends_at, category_id, tags, images, variants,
+			   created_at, updated_at
 		FROM products
 		WHERE status = $1
 		ORDER BY created_at
@@ -187,6 +317,96 @@ func (r *ProductRepository) FindAllActiveProducts(
 	return products, nil
 }
 
+// ClaimDueToStart claims draft/inactive products whose sale window has opened and not yet
+// closed. SKIP LOCKED keeps concurrent scheduler replicas from waiting on, or claiming, each
+// other's rows; a claim that lapses before the product is saved lets another scan pick it up.
+func (r *ProductRepository) ClaimDueToStart(
+	ctx context.Context,
+	now time.Time,
+	claimedUntil time.Time,
+	limit int,
+) ([]*product.Product, error) {
+	query := `
+		UPDATE products
+		SET schedule_claimed_until = $4
+		WHERE id IN (
+			SELECT id
+			FROM products
+			WHERE status IN ($1, $2)
+			  AND sale_starts_at <= $3
+			  AND (sale_ends_at IS NULL OR sale_ends_at > $3)
+			  AND (schedule_claimed_until IS NULL OR schedule_claimed_until <= $3)
+			ORDER BY sale_starts_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, seller_id, name, description,
+			   regular_price, flash_sale_price, currency, price_type,
+			   status, stock_status, purchase_limit,
+			   sale_starts_at, sale_ends_at, category_id, tags, images, variants,
+			   created_at, updated_at
+	`
+
+	var models []ProductModel
+	err := r.db.SelectContext(ctx, &models, query,
+		string(product.ProductStatusDraft), string(product.ProductStatusInactive), now, claimedUntil, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim products due to start: %w", err)
+	}
+
+	return modelsToDomain(models)
+}
+
+// ClaimDueToEnd claims active products whose sale window has closed, like ClaimDueToStart
+func (r *ProductRepository) ClaimDueToEnd(
+	ctx context.Context,
+	now time.Time,
+	claimedUntil time.Time,
+	limit int,
+) ([]*product.Product, error) {
+	query := `
+		UPDATE products
+		SET schedule_claimed_until = $3
+		WHERE id IN (
+			SELECT id
+			FROM products
+			WHERE status = $1
+			  AND sale_ends_at <= $2
+			  AND (schedule_claimed_until IS NULL OR schedule_claimed_until <= $2)
+			ORDER BY sale_ends_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, seller_id, name, description,
+			   regular_price, flash_sale_price, currency, price_type,
+			   status, stock_status, purchase_limit,
+			   sale_starts_at, sale_ends_at, category_id, tags, images, variants,
+			   created_at, updated_at
+	`
+
+	var models []ProductModel
+	err := r.db.SelectContext(ctx, &models, query, string(product.ProductStatusActive), now, claimedUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim products due to end: %w", err)
+	}
+
+	return modelsToDomain(models)
+}
+
+func modelsToDomain(models []ProductModel) ([]*product.Product, error) {
+	products := make([]*product.Product, 0, len(models))
+	for _, model := range models {
+		p, err := ModelToDomain(&model)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+
+	return products, nil
+}
+
 // Delete deletes a product
 func (r *ProductRepository) Delete(ctx context.Context, id product.ProductID) error {
 	query := `DELETE FROM products WHERE id = $1`
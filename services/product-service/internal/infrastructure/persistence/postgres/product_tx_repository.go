@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/domain/product"
 	"github.com/jmoiron/sqlx"
 	"github.com/samborkent/uuidv7"
@@ -36,12 +37,16 @@ func (w *ProductTxRepository) Save(ctx context.Context, p *product.Product) erro
 	productQuery := `
 		INSERT INTO products (
 			id, seller_id, name, description,
-			regular_price, flash_sale_price, currency,
-			status, stock_status, created_at, updated_at
+			regular_price, flash_sale_price, currency, price_type,
+			status, stock_status, purchase_limit,
+			sale_starts_at, sale_ends_at, category_id, tags, images, variants,
+			created_at, updated_at
 		) VALUES (
 			:id, :seller_id, :name, :description,
-			:regular_price, :flash_sale_price, :currency,
-			:status, :stock_status, :created_at, :updated_at
+			:regular_price, :flash_sale_price, :currency, :price_type,
+			:status, :stock_status, :purchase_limit,
+			:sale_starts_at, :sale_ends_at, :category_id, :tags, :images, :variants,
+			:created_at, :updated_at
 		)
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
@@ -49,8 +54,16 @@ func (w *ProductTxRepository) Save(ctx context.Context, p *product.Product) erro
 			regular_price = EXCLUDED.regular_price,
 			flash_sale_price = EXCLUDED.flash_sale_price,
 			currency = EXCLUDED.currency,
+			price_type = EXCLUDED.price_type,
 			status = EXCLUDED.status,
 			stock_status = EXCLUDED.stock_status,
+			purchase_limit = EXCLUDED.purchase_limit,
+			sale_starts_at = EXCLUDED.sale_starts_at,
+			sale_ends_at = EXCLUDED.sale_ends_at,
+			category_id = EXCLUDED.category_id,
+			tags = EXCLUDED.tags,
+			images = EXCLUDED.images,
+			variants = EXCLUDED.variants,
 			updated_at = EXCLUDED.updated_at
 	`
 
@@ -95,12 +108,17 @@ func (w *ProductTxRepository) insertOutboxEvent(
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
+	traceContextJSON, err := marshalTraceContext(tracing.Inject(ctx))
+	if err != nil {
+		return err
+	}
+
 	// Insert to outbox_events table
 	query := `
 		INSERT INTO outbox_events (
 			id, aggregate_type, aggregate_id, event_type, event_id,
-			payload, status, created_at, retry_count
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			payload, status, created_at, retry_count, trace_context
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	_, err = tx.ExecContext(
@@ -114,6 +132,7 @@ func (w *ProductTxRepository) insertOutboxEvent(
 		"PENDING",
 		time.Now(),
 		0,
+		traceContextJSON,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert outbox event: %w", err)
@@ -137,6 +156,29 @@ func (w *ProductTxRepository) domainEventToPayload(event product.DomainEvent) ma
 		payload["product_id"] = e.ProductID.String()
 		payload["price"] = e.Money.Amount()
 		payload["currency"] = e.Money.Currency()
+		payload["price_type"] = string(e.PriceType)
+		payload["purchase_limit"] = e.PurchaseLimit
+		if startsAt := e.SaleWindow.StartsAt(); startsAt != nil {
+			payload["sale_starts_at"] = startsAt.Format(time.RFC3339)
+		}
+		if endsAt := e.SaleWindow.EndsAt(); endsAt != nil {
+			payload["sale_ends_at"] = endsAt.Format(time.RFC3339)
+		}
+		payload["effective_from"] = e.OccurredAt().Format(time.RFC3339Nano)
+		if len(e.Variants) > 0 {
+			payload["variants"] = VariantsToPayload(e.Variants)
+		}
+
+	case product.ProductPriceUpdatedEvent:
+		payload["product_id"] = e.ProductID.String()
+		payload["price"] = e.CurrentPrice().Amount()
+		payload["currency"] = e.CurrentPrice().Currency()
+		payload["regular_price"] = e.RegularPrice.Amount()
+		if e.FlashSalePrice != nil {
+			payload["flash_sale_price"] = e.FlashSalePrice.Amount()
+		}
+		payload["price_type"] = string(e.PriceType)
+		payload["effective_from"] = e.EffectiveFrom.Format(time.RFC3339Nano)
 
 	case product.ProductDeactivatedEvent:
 		payload["product_id"] = e.ProductID.String()
@@ -147,3 +189,21 @@ func (w *ProductTxRepository) domainEventToPayload(event product.DomainEvent) ma
 
 	return payload
 }
+
+// VariantsToPayload converts variants to event payload; stock-service keeps a stock counter per variant.
+// Only variants with a price override carry a price.
+func VariantsToPayload(variants []product.Variant) []map[string]interface{} {
+	payload := make([]map[string]interface{}, 0, len(variants))
+	for _, v := range variants {
+		entry := map[string]interface{}{
+			"variant_id": v.ID().String(),
+			"sku":        v.SKU(),
+		}
+		if override := v.PriceOverride(); override != nil {
+			entry["price"] = override.Amount()
+			entry["currency"] = override.Currency()
+		}
+		payload = append(payload, entry)
+	}
+	return payload
+}
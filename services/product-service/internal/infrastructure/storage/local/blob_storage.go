@@ -0,0 +1,90 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/config"
+)
+
+// BlobStorage implements product.BlobStorage on the local filesystem (development use)
+type BlobStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewBlobStorage creates a new BlobStorage rooted at the configured directory
+func NewBlobStorage(cfg *config.StorageConfig) (*BlobStorage, error) {
+	if err := os.MkdirAll(cfg.LocalDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	return &BlobStorage{
+		dir:     cfg.LocalDir,
+		baseURL: cfg.PublicBaseURL,
+	}, nil
+}
+
+// Put writes the blob to a temp file and renames it, so readers never see a partial file
+func (s *BlobStorage) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the blob; a missing blob is not an error
+func (s *BlobStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+
+	return nil
+}
+
+// URL returns the public URL of the blob
+func (s *BlobStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path resolves key inside the storage directory, rejecting keys that escape it
+func (s *BlobStorage) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, clean), nil
+}
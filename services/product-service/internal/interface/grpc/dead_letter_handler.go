@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/infrastructure/messaging/kafka"
+	deadletterv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/deadletter/v1"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultDeadLetterPageSize = 50
+	maxDeadLetterPageSize     = 500
+)
+
+// DeadLetterHandler implements the DeadLetterService admin gRPC server
+type DeadLetterHandler struct {
+	deadletterv1.UnimplementedDeadLetterServiceServer
+	deadLetters *kafka.DeadLetterQueue
+}
+
+// NewDeadLetterHandler creates a new DeadLetterHandler
+func NewDeadLetterHandler(deadLetters *kafka.DeadLetterQueue) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetters: deadLetters,
+	}
+}
+
+// ListDeadLetters lists dead letters in offset order (admin operation)
+func (h *DeadLetterHandler) ListDeadLetters(
+	ctx context.Context,
+	req *deadletterv1.ListDeadLettersRequest,
+) (*deadletterv1.ListDeadLettersResponse, error) {
+	if req.FromOffset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "from_offset must not be negative")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultDeadLetterPageSize
+	}
+	limit = min(limit, maxDeadLetterPageSize)
+
+	deadLetters, next, err := h.deadLetters.List(ctx, req.FromOffset, limit)
+	if err != nil {
+		logger.ErrorContext(ctx, "list dead letters failed",
+			zap.Int64("from_offset", req.FromOffset),
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Unavailable, "failed to read dead-letter topic")
+	}
+
+	protoDeadLetters := make([]*deadletterv1.DeadLetter, 0, len(deadLetters))
+	for _, d := range deadLetters {
+		protoDeadLetters = append(protoDeadLetters, deadLetterToProto(d))
+	}
+
+	return &deadletterv1.ListDeadLettersResponse{
+		DeadLetters: protoDeadLetters,
+		NextOffset:  next,
+	}, nil
+}
+
+// ReplayDeadLetter republishes a dead letter into its original topic (admin operation)
+func (h *DeadLetterHandler) ReplayDeadLetter(
+	ctx context.Context,
+	req *deadletterv1.ReplayDeadLetterRequest,
+) (*deadletterv1.ReplayDeadLetterResponse, error) {
+	logger.InfoContext(ctx, "admin: replaying dead letter",
+		zap.Int64("offset", req.Offset),
+	)
+
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+
+	deadLetter, err := h.deadLetters.Replay(ctx, req.Offset)
+	if err != nil {
+		if errors.Is(err, kafka.ErrDeadLetterNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		logger.ErrorContext(ctx, "replay dead letter failed",
+			zap.Int64("offset", req.Offset),
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Unavailable, "failed to replay dead letter")
+	}
+
+	logger.InfoContext(ctx, "dead letter replayed",
+		zap.Int64("offset", req.Offset),
+		zap.String("original_topic", deadLetter.OriginalTopic),
+		zap.String("event_id", deadLetter.EventID),
+	)
+
+	return &deadletterv1.ReplayDeadLetterResponse{
+		DeadLetter: deadLetterToProto(deadLetter),
+	}, nil
+}
+
+func deadLetterToProto(d *kafka.DeadLetter) *deadletterv1.DeadLetter {
+	pb := &deadletterv1.DeadLetter{
+		Offset:            d.Offset,
+		OriginalTopic:     d.OriginalTopic,
+		OriginalPartition: int32(d.OriginalPartition),
+		OriginalOffset:    d.OriginalOffset,
+		ConsumerGroup:     d.ConsumerGroup,
+		EventId:           d.EventID,
+		EventType:         d.EventType,
+		Key:               string(d.Key),
+		Payload:           string(d.Value),
+		Error:             d.Error,
+		Attempts:          int32(d.Attempts),
+	}
+	if !d.FailedAt.IsZero() {
+		pb.FailedAt = timestamppb.New(d.FailedAt)
+	}
+	return pb
+}
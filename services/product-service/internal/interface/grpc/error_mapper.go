@@ -42,6 +42,122 @@ func mapDomainErrorToGRPC(err error) error {
 		return status.Error(codes.FailedPrecondition,
 			"cannot update pricing for active product")
 	}
+	if errors.Is(err, product.ErrCannotChangePriceType) {
+		return status.Error(codes.FailedPrecondition,
+			"cannot change price type of an existing product")
+	}
+	if errors.Is(err, product.ErrInvalidPurchaseLimit) {
+		return status.Error(codes.InvalidArgument,
+			"purchase limit cannot be negative")
+	}
+	if errors.Is(err, product.ErrAuctionPurchaseLimitNotAllowed) {
+		return status.Error(codes.InvalidArgument,
+			"auction products cannot have a purchase limit")
+	}
+	if errors.Is(err, product.ErrSaleWindowEnded) {
+		return status.Error(codes.FailedPrecondition,
+			"sale window has already ended")
+	}
+	if errors.Is(err, product.ErrInvalidSaleWindow) {
+		return status.Error(codes.InvalidArgument,
+			"sale end must be after sale start")
+	}
+	if errors.Is(err, product.ErrAuctionFlashSaleNotAllowed) {
+		return status.Error(codes.InvalidArgument,
+			"auction products cannot have a flash sale price")
+	}
+
+	// Search errors
+	if errors.Is(err, product.ErrInvalidSearchSort) {
+		return status.Error(codes.InvalidArgument,
+			"sort must be NEWEST, PRICE_ASC or PRICE_DESC")
+	}
+	if errors.Is(err, product.ErrInvalidSearchCursor) {
+		return status.Error(codes.InvalidArgument,
+			"invalid cursor, restart the search without one")
+	}
+	if errors.Is(err, product.ErrInvalidSearchStockStatus) {
+		return status.Error(codes.InvalidArgument,
+			"stock status filter must be IN_STOCK or LOW_STOCK")
+	}
+	if errors.Is(err, product.ErrInvalidPriceRange) {
+		return status.Error(codes.InvalidArgument,
+			"price bounds must be non-negative with min_price <= max_price")
+	}
+
+	// Catalog errors
+	if errors.Is(err, product.ErrCategoryNotFound) {
+		return status.Error(codes.NotFound, "category not found")
+	}
+	if errors.Is(err, product.ErrInvalidCategoryID) {
+		return status.Error(codes.InvalidArgument, "invalid category id format")
+	}
+	if errors.Is(err, product.ErrInvalidCategoryName) {
+		return status.Error(codes.InvalidArgument,
+			"category name must be 1 to 100 characters")
+	}
+	if errors.Is(err, product.ErrInvalidTag) {
+		return status.Error(codes.InvalidArgument,
+			"tags must be up to 32 lowercase letters, digits or single hyphens")
+	}
+	if errors.Is(err, product.ErrTooManyTags) {
+		return status.Errorf(codes.InvalidArgument,
+			"a product can have at most %d tags", product.MaxTags)
+	}
+	if errors.Is(err, product.ErrImageNotFound) {
+		return status.Error(codes.NotFound, "image not found")
+	}
+	if errors.Is(err, product.ErrTooManyImages) {
+		return status.Errorf(codes.FailedPrecondition,
+			"a product can have at most %d images", product.MaxImages)
+	}
+	if errors.Is(err, product.ErrUnsupportedImageType) {
+		return status.Error(codes.InvalidArgument,
+			"image must be image/jpeg, image/png or image/webp")
+	}
+	if errors.Is(err, product.ErrImageTooLarge) {
+		return status.Errorf(codes.InvalidArgument,
+			"image must be at most %d bytes", product.MaxImageSize)
+	}
+	if errors.Is(err, product.ErrInvalidImageOrder) {
+		return status.Error(codes.InvalidArgument,
+			"image order must list every image exactly once")
+	}
+
+	// Variant errors
+	if errors.Is(err, product.ErrVariantNotFound) {
+		return status.Error(codes.NotFound, "variant not found")
+	}
+	if errors.Is(err, product.ErrInvalidVariantID) {
+		return status.Error(codes.InvalidArgument, "invalid variant id format")
+	}
+	if errors.Is(err, product.ErrInvalidSKU) {
+		return status.Error(codes.InvalidArgument,
+			"sku must be 1 to 64 letters, digits, dots, underscores or hyphens")
+	}
+	if errors.Is(err, product.ErrDuplicateSKU) {
+		return status.Error(codes.AlreadyExists, "sku is already used by another variant")
+	}
+	if errors.Is(err, product.ErrTooManyVariants) {
+		return status.Errorf(codes.FailedPrecondition,
+			"a product can have at most %d variants", product.MaxVariants)
+	}
+	if errors.Is(err, product.ErrInvalidVariantAttributes) {
+		return status.Errorf(codes.InvalidArgument,
+			"a variant can have at most %d attributes with non-empty names and values", product.MaxVariantAttributes)
+	}
+	if errors.Is(err, product.ErrInvalidVariantPrice) {
+		return status.Error(codes.InvalidArgument,
+			"variant price override must be greater than zero")
+	}
+	if errors.Is(err, product.ErrAuctionVariantsNotAllowed) {
+		return status.Error(codes.InvalidArgument,
+			"auction products cannot have variants")
+	}
+	if errors.Is(err, product.ErrVariantCurrencyMismatch) {
+		return status.Error(codes.FailedPrecondition,
+			"variant price overrides must be in the product's currency")
+	}
 
 	// Authorization errors
 	if errors.Is(err, product.ErrUnauthorizedDelete) {
@@ -91,6 +207,7 @@ func isDomainError(err error) bool {
 		product.ErrCannotMarkAsSoldOut,
 		product.ErrCannotUpdateActiveProduct,
 		product.ErrCannotUpdatePricingForActiveProduct,
+		product.ErrSaleWindowEnded,
 		product.ErrUnauthorizedDelete,
 	}
 
@@ -3,9 +3,11 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/application/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/domain/product"
 	productv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/product/v1"
 
 	"go.uber.org/zap"
@@ -16,13 +18,15 @@ import (
 // ProductHandler implements ProductService gRPC server
 type ProductHandler struct {
 	productv1.UnimplementedProductServiceServer
-	productService *service.ProductService
+	productService  *service.ProductService
+	categoryService *service.CategoryService
 }
 
 // NewProductHandler creates a new ProductHandler
-func NewProductHandler(productService *service.ProductService) *ProductHandler {
+func NewProductHandler(productService *service.ProductService, categoryService *service.CategoryService) *ProductHandler {
 	return &ProductHandler{
-		productService: productService,
+		productService:  productService,
+		categoryService: categoryService,
 	}
 }
 
@@ -48,6 +52,11 @@ func (h *ProductHandler) CreateProduct(
 		flashSalePrice = req.FlashSalePrice
 	}
 
+	priceType := product.PriceTypeFixed
+	if req.PriceType != "" {
+		priceType = product.PriceType(req.PriceType)
+	}
+
 	p, err := h.productService.CreateProduct(
 		ctx,
 		req.SellerId,
@@ -56,6 +65,8 @@ func (h *ProductHandler) CreateProduct(
 		req.RegularPrice,
 		flashSalePrice,
 		req.Currency,
+		priceType,
+		int(req.PurchaseLimit),
 	)
 	if err != nil {
 		grpcErr := mapDomainErrorToGRPC(err)
@@ -217,6 +228,129 @@ func (h *ProductHandler) UpdateProductPricing(
 	}, nil
 }
 
+// UpdateProductPurchaseLimit updates the per-user purchase limit of a product
+func (h *ProductHandler) UpdateProductPurchaseLimit(
+	ctx context.Context,
+	req *productv1.UpdateProductPurchaseLimitRequest,
+) (*productv1.UpdateProductPurchaseLimitResponse, error) {
+	logger.InfoContext(ctx, "handling UpdateProductPurchaseLimit request",
+		zap.String("product_id", req.ProductId),
+		zap.Int32("purchase_limit", req.PurchaseLimit),
+	)
+
+	if err := validateUpdateProductPurchaseLimitRequest(req); err != nil {
+		logger.DebugContext(ctx, "invalid update purchase limit request",
+			zap.String("error", err.Error()),
+		)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	if err := h.productService.UpdateProductPurchaseLimit(ctx, req.ProductId, int(req.PurchaseLimit)); err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "failed to update purchase limit",
+				zap.String("product_id", req.ProductId),
+				zap.String("error", err.Error()),
+				zap.String("grpc_code", code.String()),
+			)
+		} else if isBusinessError(code) {
+			logger.WarnContext(ctx, "update purchase limit failed",
+				zap.String("product_id", req.ProductId),
+				zap.String("error", err.Error()),
+				zap.String("grpc_code", code.String()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	p, err := h.productService.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logger.ErrorContext(ctx, "failed to get product after purchase limit update",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, grpcErr
+	}
+
+	logger.InfoContext(ctx, "product purchase limit updated successfully",
+		zap.String("product_id", req.ProductId),
+	)
+
+	return &productv1.UpdateProductPurchaseLimitResponse{
+		Product: domainToProto(p),
+	}, nil
+}
+
+// ScheduleProductSale sets the sale window of a product
+func (h *ProductHandler) ScheduleProductSale(
+	ctx context.Context,
+	req *productv1.ScheduleProductSaleRequest,
+) (*productv1.ScheduleProductSaleResponse, error) {
+	logger.InfoContext(ctx, "handling ScheduleProductSale request",
+		zap.String("product_id", req.ProductId),
+	)
+
+	if err := validateScheduleProductSaleRequest(req); err != nil {
+		logger.DebugContext(ctx, "invalid schedule product sale request",
+			zap.String("error", err.Error()),
+		)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	var startsAt, endsAt *time.Time
+	if req.SaleStartsAt != nil {
+		t := req.SaleStartsAt.AsTime()
+		startsAt = &t
+	}
+	if req.SaleEndsAt != nil {
+		t := req.SaleEndsAt.AsTime()
+		endsAt = &t
+	}
+
+	if err := h.productService.ScheduleProductSale(ctx, req.ProductId, startsAt, endsAt); err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		code := status.Code(grpcErr)
+
+		if isSystemError(code) {
+			logger.ErrorContext(ctx, "failed to schedule product sale",
+				zap.String("product_id", req.ProductId),
+				zap.String("error", err.Error()),
+				zap.String("grpc_code", code.String()),
+			)
+		} else if isBusinessError(code) {
+			logger.WarnContext(ctx, "schedule product sale failed",
+				zap.String("product_id", req.ProductId),
+				zap.String("error", err.Error()),
+				zap.String("grpc_code", code.String()),
+			)
+		}
+
+		return nil, grpcErr
+	}
+
+	p, err := h.productService.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logger.ErrorContext(ctx, "failed to get product after sale scheduling",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, grpcErr
+	}
+
+	logger.InfoContext(ctx, "product sale scheduled successfully",
+		zap.String("product_id", req.ProductId),
+	)
+
+	return &productv1.ScheduleProductSaleResponse{
+		Product: domainToProto(p),
+	}, nil
+}
+
 // PublishProduct publishes a product
 func (h *ProductHandler) PublishProduct(
 	ctx context.Context,
@@ -458,6 +592,84 @@ func (h *ProductHandler) GetActiveProducts(
 	}, nil
 }
 
+// SearchProducts searches active products with cursor pagination
+func (h *ProductHandler) SearchProducts(
+	ctx context.Context,
+	req *productv1.SearchProductsRequest,
+) (*productv1.SearchProductsResponse, error) {
+	logger.DebugContext(ctx, "handling SearchProducts request",
+		zap.String("query", req.Query),
+		zap.String("sort", req.Sort),
+		zap.Int32("page_size", req.PageSize),
+	)
+
+	var after *product.SearchCursor
+	if req.Cursor != "" {
+		cursor, err := product.DecodeSearchCursor(req.Cursor)
+		if err != nil {
+			return nil, mapDomainErrorToGRPC(err)
+		}
+		after = cursor
+	}
+
+	stockStatuses := make([]product.StockStatus, 0, len(req.StockStatuses))
+	for _, s := range req.StockStatuses {
+		stockStatuses = append(stockStatuses, product.StockStatus(s))
+	}
+
+	var categoryID *product.CategoryID
+	if req.CategoryId != "" {
+		id, err := product.ParseCategoryID(req.CategoryId)
+		if err != nil {
+			return nil, mapDomainErrorToGRPC(err)
+		}
+		categoryID = &id
+	}
+
+	criteria, err := product.NewSearchCriteria(
+		req.Query,
+		req.MinPrice,
+		req.MaxPrice,
+		req.Currency,
+		stockStatuses,
+		categoryID,
+		req.Tags,
+		product.SearchSort(req.Sort),
+		after,
+		int(req.PageSize),
+	)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	products, next, err := h.productService.SearchProducts(ctx, criteria)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to search products",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to search products")
+	}
+
+	protoProducts := make([]*productv1.Product, 0, len(products))
+	for _, p := range products {
+		protoProducts = append(protoProducts, domainToProto(p))
+	}
+
+	resp := &productv1.SearchProductsResponse{
+		Products: protoProducts,
+	}
+	if next != nil {
+		resp.NextCursor = next.Encode()
+	}
+
+	logger.DebugContext(ctx, "products searched",
+		zap.Int("count", len(products)),
+		zap.Bool("has_more", next != nil),
+	)
+
+	return resp, nil
+}
+
 // GetProductsBySeller retrieves products by seller
 func (h *ProductHandler) GetProductsBySeller(
 	ctx context.Context,
@@ -518,6 +730,347 @@ func (h *ProductHandler) GetProductsBySeller(
 	}, nil
 }
 
+// UpdateProductClassification sets the category and tags of a product
+func (h *ProductHandler) UpdateProductClassification(
+	ctx context.Context,
+	req *productv1.UpdateProductClassificationRequest,
+) (*productv1.UpdateProductClassificationResponse, error) {
+	logger.InfoContext(ctx, "handling UpdateProductClassification request",
+		zap.String("product_id", req.ProductId),
+		zap.String("category_id", req.CategoryId),
+	)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: product_id is required")
+	}
+
+	if err := h.productService.UpdateProductClassification(ctx, req.ProductId, req.CategoryId, req.Tags); err != nil {
+		return nil, h.productUpdateError(ctx, "update product classification", req.ProductId, err)
+	}
+
+	p, err := h.productService.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get product after classification",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	logger.InfoContext(ctx, "product classification updated successfully",
+		zap.String("product_id", req.ProductId),
+	)
+
+	return &productv1.UpdateProductClassificationResponse{
+		Product: domainToProto(p),
+	}, nil
+}
+
+// AddProductImage uploads an image and appends it to a product
+func (h *ProductHandler) AddProductImage(
+	ctx context.Context,
+	req *productv1.AddProductImageRequest,
+) (*productv1.AddProductImageResponse, error) {
+	logger.InfoContext(ctx, "handling AddProductImage request",
+		zap.String("product_id", req.ProductId),
+		zap.String("content_type", req.ContentType),
+		zap.Int("size", len(req.Data)),
+	)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: product_id is required")
+	}
+	if len(req.Data) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: data is required")
+	}
+
+	image, err := h.productService.AddProductImage(ctx, req.ProductId, req.ContentType, req.Data)
+	if err != nil {
+		return nil, h.productUpdateError(ctx, "add product image", req.ProductId, err)
+	}
+
+	p, err := h.productService.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get product after adding image",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	logger.InfoContext(ctx, "product image added successfully",
+		zap.String("product_id", req.ProductId),
+		zap.String("image_id", image.ID().String()),
+	)
+
+	return &productv1.AddProductImageResponse{
+		Product: domainToProto(p),
+		Image:   imageToProto(image),
+	}, nil
+}
+
+// RemoveProductImage removes an image from a product
+func (h *ProductHandler) RemoveProductImage(
+	ctx context.Context,
+	req *productv1.RemoveProductImageRequest,
+) (*productv1.RemoveProductImageResponse, error) {
+	logger.InfoContext(ctx, "handling RemoveProductImage request",
+		zap.String("product_id", req.ProductId),
+		zap.String("image_id", req.ImageId),
+	)
+
+	if req.ProductId == "" || req.ImageId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: product_id and image_id are required")
+	}
+
+	if err := h.productService.RemoveProductImage(ctx, req.ProductId, req.ImageId); err != nil {
+		return nil, h.productUpdateError(ctx, "remove product image", req.ProductId, err)
+	}
+
+	p, err := h.productService.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get product after removing image",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	logger.InfoContext(ctx, "product image removed successfully",
+		zap.String("product_id", req.ProductId),
+		zap.String("image_id", req.ImageId),
+	)
+
+	return &productv1.RemoveProductImageResponse{
+		Product: domainToProto(p),
+	}, nil
+}
+
+// ReorderProductImages sets the display order of a product's images
+func (h *ProductHandler) ReorderProductImages(
+	ctx context.Context,
+	req *productv1.ReorderProductImagesRequest,
+) (*productv1.ReorderProductImagesResponse, error) {
+	logger.InfoContext(ctx, "handling ReorderProductImages request",
+		zap.String("product_id", req.ProductId),
+		zap.Strings("image_ids", req.ImageIds),
+	)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: product_id is required")
+	}
+
+	if err := h.productService.ReorderProductImages(ctx, req.ProductId, req.ImageIds); err != nil {
+		return nil, h.productUpdateError(ctx, "reorder product images", req.ProductId, err)
+	}
+
+	p, err := h.productService.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get product after reordering images",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	logger.InfoContext(ctx, "product images reordered successfully",
+		zap.String("product_id", req.ProductId),
+	)
+
+	return &productv1.ReorderProductImagesResponse{
+		Product: domainToProto(p),
+	}, nil
+}
+
+// AddProductVariant adds a variant to a product
+func (h *ProductHandler) AddProductVariant(
+	ctx context.Context,
+	req *productv1.AddProductVariantRequest,
+) (*productv1.AddProductVariantResponse, error) {
+	logger.InfoContext(ctx, "handling AddProductVariant request",
+		zap.String("product_id", req.ProductId),
+		zap.String("sku", req.Sku),
+	)
+
+	if req.ProductId == "" || req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: product_id and sku are required")
+	}
+
+	variant, err := h.productService.AddProductVariant(ctx, req.ProductId, req.Sku, req.Attributes, req.PriceOverride)
+	if err != nil {
+		return nil, h.productUpdateError(ctx, "add product variant", req.ProductId, err)
+	}
+
+	p, err := h.productService.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get product after adding variant",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	logger.InfoContext(ctx, "product variant added successfully",
+		zap.String("product_id", req.ProductId),
+		zap.String("variant_id", variant.ID().String()),
+	)
+
+	return &productv1.AddProductVariantResponse{
+		Product: domainToProto(p),
+		Variant: variantToProto(variant),
+	}, nil
+}
+
+// UpdateProductVariant updates the attributes and price override of a variant
+func (h *ProductHandler) UpdateProductVariant(
+	ctx context.Context,
+	req *productv1.UpdateProductVariantRequest,
+) (*productv1.UpdateProductVariantResponse, error) {
+	logger.InfoContext(ctx, "handling UpdateProductVariant request",
+		zap.String("product_id", req.ProductId),
+		zap.String("variant_id", req.VariantId),
+	)
+
+	if req.ProductId == "" || req.VariantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: product_id and variant_id are required")
+	}
+
+	if err := h.productService.UpdateProductVariant(ctx, req.ProductId, req.VariantId, req.Attributes, req.PriceOverride); err != nil {
+		return nil, h.productUpdateError(ctx, "update product variant", req.ProductId, err)
+	}
+
+	p, err := h.productService.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get product after updating variant",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	logger.InfoContext(ctx, "product variant updated successfully",
+		zap.String("product_id", req.ProductId),
+		zap.String("variant_id", req.VariantId),
+	)
+
+	return &productv1.UpdateProductVariantResponse{
+		Product: domainToProto(p),
+	}, nil
+}
+
+// RemoveProductVariant removes a variant from a product
+func (h *ProductHandler) RemoveProductVariant(
+	ctx context.Context,
+	req *productv1.RemoveProductVariantRequest,
+) (*productv1.RemoveProductVariantResponse, error) {
+	logger.InfoContext(ctx, "handling RemoveProductVariant request",
+		zap.String("product_id", req.ProductId),
+		zap.String("variant_id", req.VariantId),
+	)
+
+	if req.ProductId == "" || req.VariantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: product_id and variant_id are required")
+	}
+
+	if err := h.productService.RemoveProductVariant(ctx, req.ProductId, req.VariantId); err != nil {
+		return nil, h.productUpdateError(ctx, "remove product variant", req.ProductId, err)
+	}
+
+	p, err := h.productService.GetProduct(ctx, req.ProductId)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get product after removing variant",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	logger.InfoContext(ctx, "product variant removed successfully",
+		zap.String("product_id", req.ProductId),
+		zap.String("variant_id", req.VariantId),
+	)
+
+	return &productv1.RemoveProductVariantResponse{
+		Product: domainToProto(p),
+	}, nil
+}
+
+// productUpdateError maps a failed product update to a gRPC error, logging by severity
+func (h *ProductHandler) productUpdateError(ctx context.Context, operation string, productID string, err error) error {
+	grpcErr := mapDomainErrorToGRPC(err)
+	code := status.Code(grpcErr)
+
+	if isSystemError(code) {
+		logger.ErrorContext(ctx, "failed to "+operation,
+			zap.String("product_id", productID),
+			zap.String("error", err.Error()),
+			zap.String("grpc_code", code.String()),
+		)
+	} else if isBusinessError(code) {
+		logger.WarnContext(ctx, operation+" failed",
+			zap.String("product_id", productID),
+			zap.String("error", err.Error()),
+			zap.String("grpc_code", code.String()),
+		)
+	}
+
+	return grpcErr
+}
+
+// CreateCategory creates a product category (admin only)
+func (h *ProductHandler) CreateCategory(
+	ctx context.Context,
+	req *productv1.CreateCategoryRequest,
+) (*productv1.CreateCategoryResponse, error) {
+	logger.InfoContext(ctx, "handling CreateCategory request",
+		zap.String("name", req.Name),
+		zap.String("parent_id", req.ParentId),
+	)
+
+	c, err := h.categoryService.CreateCategory(ctx, req.Name, req.ParentId)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		if isSystemError(status.Code(grpcErr)) {
+			logger.ErrorContext(ctx, "failed to create category",
+				zap.String("name", req.Name),
+				zap.Error(err),
+			)
+		}
+		return nil, grpcErr
+	}
+
+	logger.InfoContext(ctx, "category created successfully",
+		zap.String("category_id", c.ID().String()),
+	)
+
+	return &productv1.CreateCategoryResponse{
+		Category: categoryToProto(c),
+	}, nil
+}
+
+// ListCategories lists all product categories
+func (h *ProductHandler) ListCategories(
+	ctx context.Context,
+	req *productv1.ListCategoriesRequest,
+) (*productv1.ListCategoriesResponse, error) {
+	categories, err := h.categoryService.ListCategories(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list categories",
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to list categories")
+	}
+
+	protoCategories := make([]*productv1.Category, 0, len(categories))
+	for _, c := range categories {
+		protoCategories = append(protoCategories, categoryToProto(c))
+	}
+
+	return &productv1.ListCategoriesResponse{
+		Categories: protoCategories,
+	}, nil
+}
+
 // Validation helpers
 
 func validateCreateProductRequest(req *productv1.CreateProductRequest) error {
@@ -539,6 +1092,18 @@ func validateCreateProductRequest(req *productv1.CreateProductRequest) error {
 	if req.FlashSalePrice != nil && *req.FlashSalePrice >= req.RegularPrice {
 		return fmt.Errorf("flash_sale_price must be less than regular_price")
 	}
+	if req.PurchaseLimit < 0 {
+		return fmt.Errorf("purchase_limit cannot be negative")
+	}
+	switch product.PriceType(req.PriceType) {
+	case "", product.PriceTypeFixed:
+	case product.PriceTypeAuction:
+		if req.FlashSalePrice != nil {
+			return fmt.Errorf("flash_sale_price is not allowed for auction products")
+		}
+	default:
+		return fmt.Errorf("price_type must be FIXED or AUCTION")
+	}
 	return nil
 }
 
@@ -570,3 +1135,24 @@ func validateUpdateProductPricingRequest(req *productv1.UpdateProductPricingRequ
 	}
 	return nil
 }
+
+func validateUpdateProductPurchaseLimitRequest(req *productv1.UpdateProductPurchaseLimitRequest) error {
+	if req.ProductId == "" {
+		return fmt.Errorf("product_id is required")
+	}
+	if req.PurchaseLimit < 0 {
+		return fmt.Errorf("purchase_limit cannot be negative")
+	}
+	return nil
+}
+
+func validateScheduleProductSaleRequest(req *productv1.ScheduleProductSaleRequest) error {
+	if req.ProductId == "" {
+		return fmt.Errorf("product_id is required")
+	}
+	if req.SaleStartsAt != nil && req.SaleEndsAt != nil &&
+		!req.SaleEndsAt.AsTime().After(req.SaleStartsAt.AsTime()) {
+		return fmt.Errorf("sale_ends_at must be after sale_starts_at")
+	}
+	return nil
+}
@@ -5,10 +5,13 @@ import (
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/common/tracing"
 	"github.com/samborkent/uuidv7"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // UnaryServerInterceptor returns a gRPC unary interceptor for tracing and metrics
@@ -35,6 +38,7 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 
 		// Calculate latency
 		latency := time.Since(start)
+		metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err), latency)
 
 		// Log completion (Debug level)
 		if err != nil {
@@ -56,6 +60,11 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 
 // extractOrGenerateTraceID extracts trace ID from metadata or generates a new one
 func extractOrGenerateTraceID(ctx context.Context) string {
+	// Span started by the OpenTelemetry stats handler from the incoming traceparent
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		return traceID
+	}
+
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return uuidv7.New().String()
@@ -14,6 +14,7 @@ func domainToProto(p *product.Product) *productv1.Product {
 			Amount:   p.Pricing().RegularPrice().Amount(),
 			Currency: p.Pricing().RegularPrice().Currency(),
 		},
+		PriceType: string(p.Pricing().PriceType()),
 	}
 
 	if p.Pricing().HasFlashSale() {
@@ -24,15 +25,72 @@ func domainToProto(p *product.Product) *productv1.Product {
 		}
 	}
 
-	return &productv1.Product{
-		Id:          p.ID().String(),
-		SellerId:    p.SellerID().String(),
-		Name:        p.Name(),
-		Description: p.Description(),
-		Pricing:     pricing,
-		Status:      string(p.Status()),
-		StockStatus: string(p.StockStatus()),
-		CreatedAt:   timestamppb.New(p.CreatedAt()),
-		UpdatedAt:   timestamppb.New(p.UpdatedAt()),
+	pb := &productv1.Product{
+		Id:            p.ID().String(),
+		SellerId:      p.SellerID().String(),
+		Name:          p.Name(),
+		Description:   p.Description(),
+		Pricing:       pricing,
+		Status:        string(p.Status()),
+		StockStatus:   string(p.StockStatus()),
+		PurchaseLimit: int32(p.PurchaseLimit()),
+		CreatedAt:     timestamppb.New(p.CreatedAt()),
+		UpdatedAt:     timestamppb.New(p.UpdatedAt()),
 	}
+
+	if startsAt := p.SaleWindow().StartsAt(); startsAt != nil {
+		pb.SaleStartsAt = timestamppb.New(*startsAt)
+	}
+	if endsAt := p.SaleWindow().EndsAt(); endsAt != nil {
+		pb.SaleEndsAt = timestamppb.New(*endsAt)
+	}
+
+	if categoryID := p.CategoryID(); categoryID != nil {
+		pb.CategoryId = categoryID.String()
+	}
+	pb.Tags = p.Tags()
+	for _, image := range p.Images() {
+		pb.Images = append(pb.Images, imageToProto(image))
+	}
+	for _, variant := range p.Variants() {
+		pb.Variants = append(pb.Variants, variantToProto(variant))
+	}
+
+	return pb
+}
+
+func imageToProto(image product.Image) *productv1.ProductImage {
+	return &productv1.ProductImage{
+		Id:          image.ID().String(),
+		Url:         image.URL(),
+		ContentType: image.ContentType(),
+	}
+}
+
+func variantToProto(v product.Variant) *productv1.ProductVariant {
+	pb := &productv1.ProductVariant{
+		Id:          v.ID().String(),
+		Sku:         v.SKU(),
+		Attributes:  v.Attributes(),
+		StockStatus: string(v.StockStatus()),
+	}
+	if override := v.PriceOverride(); override != nil {
+		pb.PriceOverride = &productv1.Money{
+			Amount:   override.Amount(),
+			Currency: override.Currency(),
+		}
+	}
+	return pb
+}
+
+func categoryToProto(c *product.Category) *productv1.Category {
+	pb := &productv1.Category{
+		Id:        c.ID().String(),
+		Name:      c.Name(),
+		CreatedAt: timestamppb.New(c.CreatedAt()),
+	}
+	if parentID := c.ParentID(); parentID != nil {
+		pb.ParentId = parentID.String()
+	}
+	return pb
 }
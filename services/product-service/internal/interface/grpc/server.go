@@ -6,7 +6,9 @@ import (
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/application/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/product-service/internal/config"
+	deadletterv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/deadletter/v1"
 	productv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/product/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -22,19 +24,24 @@ type Server struct {
 func NewServer(
 	cfg *config.ServerConfig,
 	productService *service.ProductService,
+	categoryService *service.CategoryService,
+	deadLetterHandler *DeadLetterHandler,
 ) *Server {
 	grpcServer := grpc.NewServer(
 		grpc.MaxRecvMsgSize(10*1024*1024),
 		grpc.MaxSendMsgSize(10*1024*1024),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
 			UnaryServerInterceptor(), // Logging and tracing
+			AuthorizationInterceptor(cfg.IdentitySecret),
 		),
 	)
 
-	handler := NewProductHandler(productService)
+	handler := NewProductHandler(productService, categoryService)
 
 	// Register service
 	productv1.RegisterProductServiceServer(grpcServer, handler)
+	deadletterv1.RegisterDeadLetterServiceServer(grpcServer, deadLetterHandler)
 
 	// Register reflection
 	reflection.Register(grpcServer)
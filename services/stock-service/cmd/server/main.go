@@ -2,14 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/application/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/application/worker"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/messaging/kafka"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/outbox"
@@ -46,6 +51,16 @@ func main() {
 		zap.String("environment", cfg.Logger.Environment),
 	)
 
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(&cfg.Tracing, cfg.ServiceName)
+	if err != nil {
+		log.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	// Initialize metrics
+	metrics.Init(cfg.ServiceName)
+	metricsServer := metrics.NewServer(&cfg.Metrics)
+
 	// Initialize database
 	db := postgres.MustConnect(cfg.Database)
 	defer db.Close()
@@ -60,13 +75,18 @@ func main() {
 	reservationRedisRepo := redis.NewReservationRepository(redisClient)
 	stockReservationCoordinator := redis.NewStockReservationCoordinator(redisClient)
 	productStateRepo := redis.NewProductStateRepository(redisClient)
+	auctionBidCoordinator := redis.NewAuctionBidCoordinator(redisClient)
+	admissionQueueRepo := redis.NewAdmissionQueueRepository(redisClient)
 	// Postgres
 	reservationPostgresRepo := postgres.NewReservationRepository(db)
+	auctionRepo := postgres.NewAuctionRepository(db)
+	stockLedgerRepo := postgres.NewStockLedgerRepository(db)
+	stockAdjustmentRepo := postgres.NewStockAdjustmentRepository(db)
 
 	outboxRepo := postgres.NewOutboxRepository(db)
 
 	// recovery redis
-	redisRecovery := recovery.NewRedisRecovery(redisClient, reservationPostgresRepo, reservationRedisRepo)
+	redisRecovery := recovery.NewRedisRecovery(redisClient, reservationPostgresRepo, reservationRedisRepo, stockRepo, stockLedgerRepo)
 	ctx := context.Background()
 	needsRecovery, err := redisRecovery.CheckRedisHealth(ctx)
 	if err != nil {
@@ -87,11 +107,15 @@ func main() {
 
 	// Initialize application services
 	reservationPersistQueue := worker.NewReservationPersistQueue(&cfg.Service)
-	stockService := service.NewStockService(&cfg.Service, stockRepo, reservationRedisRepo, reservationPostgresRepo, stockReservationCoordinator, outboxRepo, reservationPersistQueue, productStateRepo)
+	admissionService := service.NewAdmissionService(&cfg.Admission, admissionQueueRepo)
+	stockService := service.NewStockService(&cfg.Service, stockRepo, reservationRedisRepo, reservationPostgresRepo, stockReservationCoordinator, outboxRepo, stockLedgerRepo, stockAdjustmentRepo, reservationPersistQueue, productStateRepo, admissionService, &cfg.PriceQuote)
+	auctionService := service.NewAuctionService(auctionRepo, auctionBidCoordinator, stockRepo, stockReservationCoordinator, productStateRepo, reservationPersistQueue)
 
 	// Initialize background worker
 	reservationPersistWorker := worker.NewReservationPersistWorker(&cfg.Service, reservationPostgresRepo, reservationPersistQueue)
 	reservation_expire_scanner := worker.NewExpiredReservationScanner(stockService, reservationPostgresRepo, &cfg.ExpiredReservationScanner)
+	auctionCloseWorker := worker.NewAuctionCloseWorker(auctionService, auctionRepo, &cfg.AuctionCloseWorker)
+	admissionWorker := worker.NewAdmissionWorker(admissionService, &cfg.Admission)
 
 	// Initialize Kafka producer
 	producer := kafka.NewProducer(&cfg.Kafka)
@@ -100,21 +124,25 @@ func main() {
 	// Initialize outbox relay worker
 	outboxRelay := outbox.NewOutboxRelay(outboxRepo, producer, &cfg.Outbox)
 
+	// Initialize dead-letter queue shared by all consumers
+	deadLetterQueue := kafka.NewDeadLetterQueue(&cfg.Kafka)
+	defer deadLetterQueue.Close()
+
 	// Initialize Kafka consumer
 	orderEventHandler := kafka.NewOrderEventHandler(stockService)
-	orderConsumer := kafka.NewConsumer(&cfg.Kafka, orderEventHandler)
+	orderConsumer := kafka.NewConsumer(&cfg.Kafka, orderEventHandler, deadLetterQueue)
 	defer orderConsumer.Close()
-	productKafkaConfig := &config.KafkaConfig{
-		Brokers:         cfg.Kafka.Brokers,
-		ConsumerTopic:   cfg.Kafka.ProductEventsTopic, // "product-events"
-		ConsumerGroupID: "stock-service-product-consumer",
-	}
+	// Same brokers and retry policy, different topic and group
+	productKafkaConfig := cfg.Kafka
+	productKafkaConfig.ConsumerTopic = cfg.Kafka.ProductEventsTopic // "product-events"
+	productKafkaConfig.ConsumerGroupID = "stock-service-product-consumer"
 	productEventHandler := kafka.NewProductEventHandler(productStateRepo)
-	productConsumer := kafka.NewConsumer(productKafkaConfig, productEventHandler)
+	productConsumer := kafka.NewConsumer(&productKafkaConfig, productEventHandler, deadLetterQueue)
 	defer productConsumer.Close()
 
 	// Initialize gRPC server
-	grpcServer := grpcserver.NewServer(&cfg.Server, stockService, redisRecovery)
+	deadLetterHandler := grpcserver.NewDeadLetterHandler(deadLetterQueue)
+	grpcServer := grpcserver.NewServer(&cfg.Server, stockService, auctionService, admissionService, redisRecovery, deadLetterHandler)
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -142,6 +170,20 @@ func main() {
 		}
 	}()
 
+	go func() {
+		zap.L().Info("starting auction close worker")
+		if err := auctionCloseWorker.Start(ctx); err != nil && ctx.Err() == nil {
+			zap.L().Error("auction close worker error", zap.Error(err))
+		}
+	}()
+
+	go func() {
+		zap.L().Info("starting admission worker")
+		if err := admissionWorker.Start(ctx); err != nil && ctx.Err() == nil {
+			zap.L().Error("admission worker error", zap.Error(err))
+		}
+	}()
+
 	go func() {
 		zap.L().Info("starting kafka order consumer")
 		if err := orderConsumer.Start(ctx); err != nil && ctx.Err() == nil {
@@ -161,6 +203,16 @@ func main() {
 		reservationPersistWorker.Start(ctx)
 	}()
 
+	// Start metrics server
+	go func() {
+		zap.L().Info("starting metrics server",
+			zap.Int("port", cfg.Metrics.Port),
+		)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Error("metrics server error", zap.Error(err))
+		}
+	}()
+
 	// Start gRPC server
 	go func() {
 		zap.L().Info("starting grpc server",
@@ -185,5 +237,19 @@ func main() {
 	// Stop gRPC server
 	grpcServer.Stop()
 
+	// Stop metrics server
+	metricsCtx, metricsCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer metricsCancel()
+	if err := metricsServer.Shutdown(metricsCtx); err != nil {
+		zap.L().Warn("failed to stop metrics server", zap.Error(err))
+	}
+
+	// Flush pending spans
+	tracingCtx, tracingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer tracingCancel()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		zap.L().Warn("failed to flush traces", zap.Error(err))
+	}
+
 	zap.L().Info("server stopped")
 }
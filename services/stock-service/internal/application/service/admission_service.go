@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/admission"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/persistence/redis"
+	"go.uber.org/zap"
+)
+
+// AdmissionService handles the per-product waiting room used for hot launches.
+// Users join a queue, poll their position and, once admitted, receive a signed
+// admission token that Reserve requires while the waiting room is enabled.
+type AdmissionService struct {
+	queueRepo    *redis.AdmissionQueueRepository
+	tokenSigner  *admission.TokenSigner
+	admissionTTL time.Duration
+}
+
+// NewAdmissionService creates a new AdmissionService
+func NewAdmissionService(
+	cfg *config.AdmissionConfig,
+	queueRepo *redis.AdmissionQueueRepository,
+) *AdmissionService {
+	return &AdmissionService{
+		queueRepo:    queueRepo,
+		tokenSigner:  admission.NewTokenSigner(cfg.TokenSecret),
+		admissionTTL: cfg.AdmissionTTL,
+	}
+}
+
+// EnableQueue enables the waiting room of a product, or updates its admission rate
+func (s *AdmissionService) EnableQueue(
+	ctx context.Context,
+	productID string,
+	admissionRate int,
+) (*admission.QueueStatus, error) {
+	if productID == "" {
+		return nil, admission.ErrInvalidProductID
+	}
+	if err := admission.ValidateAdmissionRate(admissionRate); err != nil {
+		return nil, err
+	}
+
+	if err := s.queueRepo.EnableQueue(ctx, productID, admissionRate); err != nil {
+		return nil, fmt.Errorf("failed to enable queue: %w", err)
+	}
+
+	logger.InfoContext(ctx, "waiting room enabled",
+		zap.String("product_id", productID),
+		zap.Int("admission_rate", admissionRate),
+	)
+
+	return s.GetQueueStatus(ctx, productID)
+}
+
+// DisableQueue disables the waiting room of a product; waiting users are dropped
+// and Reserve no longer requires an admission token
+func (s *AdmissionService) DisableQueue(ctx context.Context, productID string) error {
+	if productID == "" {
+		return admission.ErrInvalidProductID
+	}
+
+	if err := s.queueRepo.DisableQueue(ctx, productID); err != nil {
+		return fmt.Errorf("failed to disable queue: %w", err)
+	}
+
+	logger.InfoContext(ctx, "waiting room disabled",
+		zap.String("product_id", productID),
+	)
+
+	return nil
+}
+
+// GetQueueStatus returns whether the waiting room of a product is enabled and how many users wait
+func (s *AdmissionService) GetQueueStatus(ctx context.Context, productID string) (*admission.QueueStatus, error) {
+	if productID == "" {
+		return nil, admission.ErrInvalidProductID
+	}
+
+	rate, err := s.queueRepo.GetAdmissionRate(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admission rate: %w", err)
+	}
+
+	queueStatus := &admission.QueueStatus{
+		ProductID:     productID,
+		Enabled:       rate > 0,
+		AdmissionRate: rate,
+	}
+	if !queueStatus.Enabled {
+		return queueStatus, nil
+	}
+
+	waiting, err := s.queueRepo.Waiting(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count waiting users: %w", err)
+	}
+	queueStatus.Waiting = waiting
+
+	return queueStatus, nil
+}
+
+// JoinQueue puts the user in the waiting room of a product (idempotent)
+func (s *AdmissionService) JoinQueue(ctx context.Context, productID, userID string) (*admission.Ticket, error) {
+	rate, err := s.enabledRate(ctx, productID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	position, admittedUntil, err := s.queueRepo.Join(ctx, productID, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ticket(productID, userID, position, admittedUntil, rate), nil
+}
+
+// GetQueuePosition returns the user's place in the waiting room, with an admission token once admitted
+func (s *AdmissionService) GetQueuePosition(ctx context.Context, productID, userID string) (*admission.Ticket, error) {
+	rate, err := s.enabledRate(ctx, productID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	position, admittedUntil, err := s.queueRepo.Position(ctx, productID, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ticket(productID, userID, position, admittedUntil, rate), nil
+}
+
+// VerifyAdmission checks the admission token of a user when the product's waiting room is enabled
+func (s *AdmissionService) VerifyAdmission(ctx context.Context, productID, userID, token string) error {
+	rate, err := s.queueRepo.GetAdmissionRate(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to get admission rate: %w", err)
+	}
+	if rate == 0 {
+		return nil
+	}
+
+	if token == "" {
+		return admission.ErrAdmissionRequired
+	}
+
+	return s.tokenSigner.Verify(token, productID, userID, time.Now())
+}
+
+// AdmitWaiting admits the next users of every enabled waiting room at its admission rate.
+// Returns the number of users admitted.
+func (s *AdmissionService) AdmitWaiting(ctx context.Context) (int, error) {
+	rates, err := s.queueRepo.ListQueuedProducts(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list queued products: %w", err)
+	}
+
+	now := time.Now()
+	admittedUntil := now.Add(s.admissionTTL)
+
+	total := 0
+	for productID, rate := range rates {
+		admitted, err := s.queueRepo.Admit(ctx, productID, rate, now, admittedUntil)
+		if err != nil {
+			// Log but continue with other products
+			logger.ErrorContext(ctx, "failed to admit waiting users",
+				zap.String("product_id", productID),
+				zap.Error(err),
+			)
+			continue
+		}
+		total += admitted
+	}
+
+	return total, nil
+}
+
+// enabledRate validates the IDs and returns the admission rate of an enabled waiting room
+func (s *AdmissionService) enabledRate(ctx context.Context, productID, userID string) (int, error) {
+	if productID == "" {
+		return 0, admission.ErrInvalidProductID
+	}
+	if userID == "" {
+		return 0, admission.ErrInvalidUserID
+	}
+
+	rate, err := s.queueRepo.GetAdmissionRate(ctx, productID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get admission rate: %w", err)
+	}
+	if rate == 0 {
+		return 0, admission.ErrQueueNotEnabled
+	}
+
+	return rate, nil
+}
+
+func (s *AdmissionService) ticket(
+	productID, userID string,
+	position int64,
+	admittedUntil *time.Time,
+	rate int,
+) *admission.Ticket {
+	if admittedUntil != nil {
+		token := s.tokenSigner.Issue(productID, userID, *admittedUntil)
+		return admission.NewAdmittedTicket(productID, userID, token, *admittedUntil)
+	}
+	return admission.NewWaitingTicket(productID, userID, position, rate)
+}
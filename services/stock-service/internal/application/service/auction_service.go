@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/persistence/postgres"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/persistence/redis"
+	"go.uber.org/zap"
+)
+
+// AuctionService handles auction use cases
+type AuctionService struct {
+	auctionRepo                 *postgres.AuctionRepository
+	bidCoordinator              *redis.AuctionBidCoordinator
+	stockRepo                   stock.Repository
+	stockReservationCoordinator *redis.StockReservationCoordinator
+	productStateRepo            *redis.ProductStateRepository
+	persistQueue                chan *reservation.Reservation
+}
+
+// NewAuctionService creates a new AuctionService
+func NewAuctionService(
+	auctionRepo *postgres.AuctionRepository,
+	bidCoordinator *redis.AuctionBidCoordinator,
+	stockRepo stock.Repository,
+	stockReservationCoordinator *redis.StockReservationCoordinator,
+	productStateRepo *redis.ProductStateRepository,
+	persistQueue chan *reservation.Reservation,
+) *AuctionService {
+	return &AuctionService{
+		auctionRepo:                 auctionRepo,
+		bidCoordinator:              bidCoordinator,
+		stockRepo:                   stockRepo,
+		stockReservationCoordinator: stockReservationCoordinator,
+		productStateRepo:            productStateRepo,
+		persistQueue:                persistQueue,
+	}
+}
+
+// CreateAuction opens an auction for an auction-priced product
+func (s *AuctionService) CreateAuction(
+	ctx context.Context,
+	productID string,
+	sellerID string,
+	startPrice int64,
+	minIncrement int64,
+	reservePrice int64,
+	currency string,
+	quantity int,
+	endsAt time.Time,
+) (*auction.Auction, error) {
+	logger.InfoContext(ctx, "creating auction",
+		zap.String("product_id", productID),
+		zap.String("seller_id", sellerID),
+		zap.Int64("start_price", startPrice),
+		zap.Time("ends_at", endsAt),
+	)
+
+	pid, err := auction.ParseProductID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product id: %w", err)
+	}
+
+	sid, err := auction.ParseUserID(sellerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seller id: %w", err)
+	}
+
+	isActive, err := s.productStateRepo.IsActive(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check product state: %w", err)
+	}
+	if !isActive {
+		return nil, auction.ErrProductNotActive
+	}
+
+	isAuction, err := s.productStateRepo.IsAuction(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check product price type: %w", err)
+	}
+	if !isAuction {
+		return nil, auction.ErrNotAuctionProduct
+	}
+
+	// Fail early if the lot cannot be covered by current stock; auction products have no variants
+	stk, err := s.stockRepo.FindByProductID(ctx, stock.ProductID(productID), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock: %w", err)
+	}
+	if stk.Quantity() < quantity {
+		return nil, stock.ErrInsufficientStock
+	}
+
+	a, err := auction.NewAuction(pid, sid, startPrice, minIncrement, reservePrice, currency, quantity, endsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the product's auction slot in Redis first (one open auction per product)
+	if err := s.bidCoordinator.Create(ctx, a); err != nil {
+		return nil, err
+	}
+
+	if err := s.auctionRepo.Save(ctx, a); err != nil {
+		// Release the claimed slot so the seller can retry
+		if _, closeErr := s.bidCoordinator.Close(ctx, a); closeErr != nil {
+			logger.ErrorContext(ctx, "failed to rollback auction in redis",
+				zap.String("auction_id", a.ID().String()),
+				zap.Error(closeErr),
+			)
+		}
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "auction created",
+		zap.String("auction_id", a.ID().String()),
+		zap.String("product_id", productID),
+	)
+
+	return a, nil
+}
+
+// PlaceBid places a bid; the highest bid is decided atomically in Redis.
+// Returns the number of accepted bids so far.
+func (s *AuctionService) PlaceBid(
+	ctx context.Context,
+	auctionID string,
+	bidderID string,
+	amount int64,
+) (int64, error) {
+	aid, err := auction.ParseAuctionID(auctionID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid auction id: %w", err)
+	}
+
+	uid, err := auction.ParseUserID(bidderID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bidder id: %w", err)
+	}
+
+	if amount <= 0 {
+		return 0, auction.ErrInvalidBidAmount
+	}
+
+	bidCount, err := s.bidCoordinator.PlaceBid(ctx, aid, uid, amount)
+	if err != nil {
+		if errors.Is(err, auction.ErrBidTooLow) {
+			return 0, fmt.Errorf("%w: minimum bid is %d", err, bidCount)
+		}
+		return 0, err
+	}
+
+	logger.InfoContext(ctx, "bid accepted",
+		zap.String("auction_id", auctionID),
+		zap.String("bidder_id", bidderID),
+		zap.Int64("amount", amount),
+		zap.Int64("bid_count", bidCount),
+	)
+
+	return bidCount, nil
+}
+
+// GetAuction gets an auction with its live bid state
+func (s *AuctionService) GetAuction(ctx context.Context, auctionID string) (*auction.Auction, error) {
+	aid, err := auction.ParseAuctionID(auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auction id: %w", err)
+	}
+
+	a, err := s.auctionRepo.FindByID(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+
+	// While open, Redis holds the authoritative bid state
+	if a.Status() == auction.AuctionStatusOpen {
+		state, err := s.bidCoordinator.GetState(ctx, aid)
+		if err != nil && !errors.Is(err, auction.ErrAuctionNotFound) {
+			return nil, err
+		}
+		if state != nil {
+			a.ApplyBidState(state.HighestBid, auction.UserID(state.HighestBidder), state.BidCount)
+		}
+	}
+
+	return a, nil
+}
+
+// CloseAuction closes an ended auction.
+// If the reserve price is met, the winner's stock is reserved and auction.won is
+// published so Order Service creates the order at the winning price.
+func (s *AuctionService) CloseAuction(ctx context.Context, a *auction.Auction) error {
+	if !a.HasEnded() {
+		return auction.ErrAuctionNotEnded
+	}
+
+	state, err := s.bidCoordinator.Close(ctx, a)
+	if err != nil && !errors.Is(err, auction.ErrAuctionNotFound) {
+		return err
+	}
+	if state != nil {
+		a.ApplyBidState(state.HighestBid, auction.UserID(state.HighestBidder), state.BidCount)
+	} else {
+		logger.WarnContext(ctx, "auction bid state missing in redis, closing with persisted state",
+			zap.String("auction_id", a.ID().String()),
+		)
+	}
+
+	var res *reservation.Reservation
+	var reserveErr error
+	if a.IsReserveMet() {
+		res, reserveErr = s.reserveForWinner(ctx, a)
+		if reserveErr != nil && !isUnsellableToWinner(reserveErr) {
+			return reserveErr
+		}
+	}
+
+	if res != nil {
+		err = a.MarkWon(res.ID().String())
+	} else {
+		err = a.MarkUnsold(unsoldReason(a, reserveErr))
+	}
+	if err != nil {
+		return err
+	}
+
+	outboxEvents := make([]*postgres.OutboxEvent, 0, len(a.DomainEvents()))
+	for _, event := range a.DomainEvents() {
+		outboxEvents = append(outboxEvents, postgres.NewOutboxEvent(
+			"auction",
+			a.ID().String(),
+			event.EventType(),
+			auctionEventToPayload(event),
+		))
+	}
+
+	var ledgerEntries []*stock.LedgerEntry
+	if res != nil {
+		ledgerEntries = append(ledgerEntries, stock.NewReserveLedgerEntry(stock.ProductID(a.ProductID()), "", res.ID().String(), res.Quantity()))
+	}
+
+	if err := s.auctionRepo.SaveWithEvents(ctx, a, ledgerEntries, outboxEvents); err != nil {
+		if res != nil {
+			// Give the stock back; the auction stays OPEN in PostgreSQL and is retried
+			if _, rollbackErr := s.stockReservationCoordinator.Release(ctx, stock.ProductID(a.ProductID()), "", res.ID(), res.UserID(), res.Quantity()); rollbackErr != nil {
+				logger.ErrorContext(ctx, "CRITICAL: failed to rollback winner reservation",
+					zap.String("auction_id", a.ID().String()),
+					zap.String("reservation_id", res.ID().String()),
+					zap.Error(err),
+					zap.NamedError("rollback_error", rollbackErr),
+				)
+			}
+		}
+		return fmt.Errorf("failed to save closed auction: %w", err)
+	}
+	a.ClearEvents()
+
+	if res != nil {
+		// Async write to PostgreSQL (send to queue, don't wait)
+		s.persistQueue <- res
+	}
+
+	logger.InfoContext(ctx, "auction closed",
+		zap.String("auction_id", a.ID().String()),
+		zap.String("status", string(a.Status())),
+		zap.Int64("highest_bid", a.HighestBid()),
+		zap.Int("bid_count", a.BidCount()),
+	)
+
+	return nil
+}
+
+// reserveForWinner reserves the auctioned quantity for the winning bidder
+func (s *AuctionService) reserveForWinner(ctx context.Context, a *auction.Auction) (*reservation.Reservation, error) {
+	res, err := reservation.NewReservation(
+		reservation.ProductID(a.ProductID()),
+		"",
+		reservation.UserID(a.HighestBidder()),
+		a.Quantity(),
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+	// The order is created from auction.won at the winning price, not from stock.reserved
+	res.ClearEvents()
+
+	if _, _, err := s.stockReservationCoordinator.Reserve(ctx, stock.ProductID(a.ProductID()), "", res, nil); err != nil {
+		logger.WarnContext(ctx, "failed to reserve stock for auction winner",
+			zap.String("auction_id", a.ID().String()),
+			zap.String("winner_id", a.HighestBidder().String()),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// unsoldReason explains why an auction closed without a winner
+func unsoldReason(a *auction.Auction, reserveErr error) string {
+	switch {
+	case a.BidCount() == 0:
+		return "no bids"
+	case !a.IsReserveMet():
+		return "reserve price not met"
+	case errors.Is(reserveErr, reservation.ErrPurchaseLimitExceeded):
+		return "winner exceeded purchase limit"
+	default:
+		return "insufficient stock"
+	}
+}
+
+// isUnsellableToWinner reports whether a failed winner reservation closes the auction as unsold
+// rather than failing the close, which would retry it forever
+func isUnsellableToWinner(err error) bool {
+	return errors.Is(err, stock.ErrInsufficientStock) || errors.Is(err, reservation.ErrPurchaseLimitExceeded)
+}
+
+// auctionEventToPayload converts auction event to payload
+func auctionEventToPayload(event auction.DomainEvent) map[string]interface{} {
+	payload := map[string]interface{}{
+		"occurred_at": event.OccurredAt().Format(time.RFC3339),
+	}
+
+	switch e := event.(type) {
+	case auction.AuctionWonEvent:
+		payload["auction_id"] = e.AuctionID.String()
+		payload["product_id"] = e.ProductID.String()
+		payload["user_id"] = e.WinnerID.String()
+		payload["reservation_id"] = e.ReservationID
+		payload["quantity"] = e.Quantity
+		payload["unit_price"] = e.UnitPrice
+		payload["currency"] = e.Currency
+
+	case auction.AuctionUnsoldEvent:
+		payload["auction_id"] = e.AuctionID.String()
+		payload["product_id"] = e.ProductID.String()
+		payload["highest_bid"] = e.HighestBid
+		payload["bid_count"] = e.BidCount
+		payload["reason"] = e.Reason
+	}
+
+	return payload
+}
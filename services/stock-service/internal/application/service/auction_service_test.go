@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
+)
+
+func endedAuction(highestBid int64, highestBidder auction.UserID, bidCount int) *auction.Auction {
+	now := time.Now()
+	return auction.ReconstructAuction(
+		auction.NewAuctionID(),
+		"product-1",
+		"seller-1",
+		100,
+		10,
+		500,
+		"USD",
+		1,
+		auction.AuctionStatusOpen,
+		now.Add(-time.Minute),
+		highestBid,
+		highestBidder,
+		bidCount,
+		nil,
+		now.Add(-time.Hour),
+		nil,
+		now.Add(-time.Minute),
+	)
+}
+
+func TestCloseAuctionUnsoldOutcome(t *testing.T) {
+	tests := []struct {
+		name       string
+		auction    *auction.Auction
+		reserveErr error
+		wantUnsold bool
+		wantReason string
+	}{
+		{
+			name:       "no bids",
+			auction:    endedAuction(0, "", 0),
+			wantUnsold: true,
+			wantReason: "no bids",
+		},
+		{
+			name:       "reserve price not met",
+			auction:    endedAuction(400, "bidder-1", 3),
+			wantUnsold: true,
+			wantReason: "reserve price not met",
+		},
+		{
+			name:       "insufficient stock",
+			auction:    endedAuction(600, "bidder-1", 3),
+			reserveErr: stock.ErrInsufficientStock,
+			wantUnsold: true,
+			wantReason: "insufficient stock",
+		},
+		{
+			name:       "winner over purchase limit",
+			auction:    endedAuction(600, "bidder-1", 3),
+			reserveErr: reservation.ErrPurchaseLimitExceeded,
+			wantUnsold: true,
+			wantReason: "winner exceeded purchase limit",
+		},
+		{
+			name:       "wrapped purchase limit",
+			auction:    endedAuction(600, "bidder-1", 3),
+			reserveErr: fmt.Errorf("reserve: %w", reservation.ErrPurchaseLimitExceeded),
+			wantUnsold: true,
+			wantReason: "winner exceeded purchase limit",
+		},
+		{
+			name:       "redis failure is retried",
+			auction:    endedAuction(600, "bidder-1", 3),
+			reserveErr: errors.New("connection refused"),
+			wantUnsold: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.reserveErr != nil {
+				if got := isUnsellableToWinner(tt.reserveErr); got != tt.wantUnsold {
+					t.Fatalf("isUnsellableToWinner(%v) = %v, want %v", tt.reserveErr, got, tt.wantUnsold)
+				}
+			}
+			if !tt.wantUnsold {
+				return
+			}
+
+			reason := unsoldReason(tt.auction, tt.reserveErr)
+			if reason != tt.wantReason {
+				t.Fatalf("unsoldReason() = %q, want %q", reason, tt.wantReason)
+			}
+			if err := tt.auction.MarkUnsold(reason); err != nil {
+				t.Fatalf("MarkUnsold() error = %v", err)
+			}
+			if tt.auction.Status() != auction.AuctionStatusUnsold {
+				t.Fatalf("status = %s, want %s", tt.auction.Status(), auction.AuctionStatusUnsold)
+			}
+		})
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/metrics"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/admission"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/persistence/postgres"
@@ -15,16 +18,26 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	releaseRecordAttempts = 5
+	releaseRecordBackoff  = 100 * time.Millisecond
+)
+
 // StockService handles stock use cases
 type StockService struct {
 	cfg                         *config.ServiceConfig
 	stockRepo                   stock.Repository
 	cacheReservationRepo        reservation.CacheRepository
-	persistentReservationRepo   reservation.PersistentRepository
+	persistentReservationRepo   *postgres.ReservationRepository
 	stockReservationCoordinator *redis.StockReservationCoordinator
 	outboxRepo                  *postgres.OutboxRepository
+	ledgerRepo                  *postgres.StockLedgerRepository
+	adjustmentRepo              *postgres.StockAdjustmentRepository
 	persistQueue                chan *reservation.Reservation
 	productStateRepo            *redis.ProductStateRepository
+	admissionService            *AdmissionService
+	quoteSigner                 *reservation.QuoteSigner
+	quoteTTL                    time.Duration
 }
 
 // NewStockService creates a new StockService
@@ -32,11 +45,15 @@ func NewStockService(
 	cfg *config.ServiceConfig,
 	stockRepo stock.Repository,
 	cacheReservationRepo reservation.CacheRepository,
-	persistentReservationRepo reservation.PersistentRepository,
+	persistentReservationRepo *postgres.ReservationRepository,
 	stockReservationCoordinator *redis.StockReservationCoordinator,
 	outboxRepo *postgres.OutboxRepository,
+	ledgerRepo *postgres.StockLedgerRepository,
+	adjustmentRepo *postgres.StockAdjustmentRepository,
 	persistQueue chan *reservation.Reservation,
 	productStateRepo *redis.ProductStateRepository,
+	admissionService *AdmissionService,
+	quoteCfg *config.PriceQuoteConfig,
 ) *StockService {
 	s := &StockService{
 		cfg:                         cfg,
@@ -45,21 +62,30 @@ func NewStockService(
 		persistentReservationRepo:   persistentReservationRepo,
 		stockReservationCoordinator: stockReservationCoordinator,
 		outboxRepo:                  outboxRepo,
+		ledgerRepo:                  ledgerRepo,
+		adjustmentRepo:              adjustmentRepo,
 		persistQueue:                persistQueue,
 		productStateRepo:            productStateRepo,
+		admissionService:            admissionService,
+		quoteSigner:                 reservation.NewQuoteSigner(quoteCfg.Secret),
+		quoteTTL:                    quoteCfg.TTL,
 	}
 
 	return s
 }
 
-// SetStock sets initial stock for a product
+// SetStock sets initial stock for a product, or for one of its variants.
+// It overwrites both the available and the initial quantity, so replenishing a
+// product that is already selling should go through AddStock instead.
 func (s *StockService) SetStock(
 	ctx context.Context,
 	productID string,
+	variantID string,
 	quantity int,
 ) error {
 	logger.InfoContext(ctx, "setting stock",
 		zap.String("product_id", productID),
+		zap.String("variant_id", variantID),
 		zap.Int("quantity", quantity),
 	)
 
@@ -68,32 +94,286 @@ func (s *StockService) SetStock(
 		return fmt.Errorf("invalid product id: %w", err)
 	}
 
-	stk, err := stock.NewStock(pid, quantity)
+	vid, err := stock.ParseVariantID(variantID)
+	if err != nil {
+		return fmt.Errorf("invalid variant id: %w", err)
+	}
+
+	stk, err := stock.NewStock(pid, vid, quantity)
 	if err != nil {
 		return fmt.Errorf("failed to create stock: %w", err)
 	}
 
+	// The ledger is the durable source of truth, so it is written before Redis.
+	// If the Redis write fails, reconciliation restores the counter from the ledger.
+	event := stock.NewStockSetEvent(pid, vid, quantity)
+	outboxEvent := postgres.NewOutboxEvent(
+		"stock",
+		pid.String(),
+		event.EventType(),
+		map[string]interface{}{
+			"product_id":  pid.String(),
+			"variant_id":  vid.String(),
+			"quantity":    quantity,
+			"occurred_at": event.OccurredAt().Format(time.RFC3339),
+		},
+	)
+
+	entry := stock.NewSetLedgerEntry(pid, vid, quantity)
+	if err := s.ledgerRepo.AppendWithEvents(ctx, []*stock.LedgerEntry{entry}, []*postgres.OutboxEvent{outboxEvent}); err != nil {
+		return fmt.Errorf("failed to record stock set: %w", err)
+	}
+
 	if err := s.stockRepo.Save(ctx, stk); err != nil {
 		return fmt.Errorf("failed to save stock: %w", err)
 	}
 
 	logger.InfoContext(ctx, "stock set successfully",
 		zap.String("product_id", productID),
+		zap.String("variant_id", variantID),
 		zap.Int("quantity", quantity),
 	)
 
 	return nil
 }
 
-// Reserve reserves stock for a user
+// AddStock adds stock to a product or variant (restock, customer return, correction)
+func (s *StockService) AddStock(
+	ctx context.Context,
+	productID string,
+	variantID string,
+	actorID string,
+	quantity int,
+	reason string,
+	note string,
+) (*stock.Adjustment, error) {
+	pid, err := stock.ParseProductID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product id: %w", err)
+	}
+
+	vid, err := stock.ParseVariantID(variantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid variant id: %w", err)
+	}
+
+	adj, err := stock.NewStockAddition(pid, vid, actorID, quantity, stock.AdjustmentReason(reason), note)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyAdjustment(ctx, adj)
+}
+
+// RemoveStock removes stock from a product or variant (damaged, lost, correction)
+func (s *StockService) RemoveStock(
+	ctx context.Context,
+	productID string,
+	variantID string,
+	actorID string,
+	quantity int,
+	reason string,
+	note string,
+) (*stock.Adjustment, error) {
+	pid, err := stock.ParseProductID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product id: %w", err)
+	}
+
+	vid, err := stock.ParseVariantID(variantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid variant id: %w", err)
+	}
+
+	adj, err := stock.NewStockRemoval(pid, vid, actorID, quantity, stock.AdjustmentReason(reason), note)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyAdjustment(ctx, adj)
+}
+
+// ListAdjustments lists the adjustment history of a product, newest first
+func (s *StockService) ListAdjustments(
+	ctx context.Context,
+	productID string,
+	limit int,
+	offset int,
+) ([]*stock.Adjustment, error) {
+	pid, err := stock.ParseProductID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product id: %w", err)
+	}
+
+	adjustments, err := s.adjustmentRepo.FindByProductID(ctx, pid, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
+
+// applyAdjustment applies an adjustment to the live Redis counter, then records it
+// in the audit trail and stock ledger together with the stock.adjusted outbox event.
+// Redis goes first so concurrent reservations can never drive the counter negative.
+func (s *StockService) applyAdjustment(ctx context.Context, adj *stock.Adjustment) (*stock.Adjustment, error) {
+	logger.InfoContext(ctx, "adjusting stock",
+		zap.String("product_id", adj.ProductID().String()),
+		zap.String("variant_id", adj.VariantID().String()),
+		zap.String("actor_id", adj.ActorID()),
+		zap.Int("delta", adj.Delta()),
+		zap.String("reason", string(adj.Reason())),
+	)
+
+	newQty, err := s.stockRepo.Adjust(ctx, adj.ProductID(), adj.VariantID(), adj.Delta())
+	if err != nil {
+		return nil, err
+	}
+
+	adj.MarkApplied(newQty)
+
+	if err := s.adjustmentRepo.SaveWithEvents(ctx, adj, s.adjustmentOutboxEvents(adj)); err != nil {
+		logger.ErrorContext(ctx, "adjustment persist failed, rolling back redis",
+			zap.String("adjustment_id", adj.ID()),
+			zap.String("product_id", adj.ProductID().String()),
+			zap.Error(err),
+		)
+
+		if _, rollbackErr := s.stockRepo.Adjust(ctx, adj.ProductID(), adj.VariantID(), -adj.Delta()); rollbackErr != nil {
+			logger.ErrorContext(ctx, "CRITICAL: failed to rollback redis after adjustment persist failure",
+				zap.String("adjustment_id", adj.ID()),
+				zap.String("product_id", adj.ProductID().String()),
+				zap.Error(err),
+				zap.NamedError("rollback_error", rollbackErr),
+			)
+		}
+
+		return nil, fmt.Errorf("failed to record stock adjustment: %w", err)
+	}
+	adj.ClearEvents()
+
+	if newQty == 0 {
+		if err := s.publishDepletedEvent(ctx, adj.ProductID(), adj.VariantID()); err != nil {
+			logger.ErrorContext(ctx, "failed to publish depleted event",
+				zap.String("product_id", adj.ProductID().String()),
+				zap.Error(err),
+			)
+		}
+	} else {
+		// Also after an increase: product-service marks the stock available on stock.adjusted,
+		// so a counter still below the threshold must be reported low again
+		go s.checkAndPublishLowStock(context.Background(), adj.ProductID(), adj.VariantID(), newQty)
+	}
+
+	logger.InfoContext(ctx, "stock adjusted successfully",
+		zap.String("adjustment_id", adj.ID()),
+		zap.String("product_id", adj.ProductID().String()),
+		zap.Int("delta", adj.Delta()),
+		zap.Int("quantity", newQty),
+	)
+
+	return adj, nil
+}
+
+// adjustmentOutboxEvents builds outbox events for the adjustment's pending domain events
+func (s *StockService) adjustmentOutboxEvents(adj *stock.Adjustment) []*postgres.OutboxEvent {
+	events := adj.DomainEvents()
+	outboxEvents := make([]*postgres.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		e, ok := event.(stock.StockAdjustedEvent)
+		if !ok {
+			continue
+		}
+		outboxEvents = append(outboxEvents, postgres.NewOutboxEvent(
+			"stock",
+			e.ProductID.String(),
+			e.EventType(),
+			map[string]interface{}{
+				"adjustment_id": e.AdjustmentID,
+				"product_id":    e.ProductID.String(),
+				"variant_id":    e.VariantID.String(),
+				"delta":         e.Delta,
+				"reason":        string(e.Reason),
+				"actor_id":      e.ActorID,
+				"quantity":      e.QuantityAfter,
+				"occurred_at":   e.OccurredAt().Format(time.RFC3339),
+			},
+		))
+	}
+	return outboxEvents
+}
+
+// Reserve reserves stock for a user.
+// Products with variants are reserved from the stock of the selected variant.
+// While the product's waiting room is enabled the user must present an admission token.
+// A request retried with the same idempotency key returns the original reservation.
 func (s *StockService) Reserve(
 	ctx context.Context,
 	productID string,
+	variantID string,
 	userID string,
 	quantity int,
+	admissionToken string,
+	idempotencyKey string,
 ) (*reservation.Reservation, int, error) {
+	res, newQty, replayed, err := s.reserve(ctx, productID, variantID, userID, quantity, admissionToken, idempotencyKey)
+	if replayed {
+		metrics.RecordReservation(metrics.OutcomeReplayed)
+	} else {
+		metrics.RecordReservation(reservationOutcome(err))
+	}
+	return res, newQty, err
+}
+
+// reservationOutcome classifies a reservation result for the reservation metrics
+func reservationOutcome(err error) string {
+	switch {
+	case err == nil:
+		return metrics.OutcomeReserved
+	case errors.Is(err, stock.ErrInsufficientStock):
+		return metrics.OutcomeInsufficientStock
+	case errors.Is(err, reservation.ErrPurchaseLimitExceeded):
+		return metrics.OutcomePurchaseLimitExceeded
+	case errors.Is(err, admission.ErrAdmissionRequired),
+		errors.Is(err, admission.ErrInvalidAdmissionToken),
+		errors.Is(err, admission.ErrAdmissionExpired):
+		return metrics.OutcomeAdmissionRejected
+	case errors.Is(err, auction.ErrProductNotActive),
+		errors.Is(err, reservation.ErrSaleNotStarted),
+		errors.Is(err, reservation.ErrSaleEnded),
+		errors.Is(err, reservation.ErrPriceUnavailable),
+		errors.Is(err, auction.ErrProductSoldByAuction):
+		return metrics.OutcomeSaleClosed
+	case errors.Is(err, reservation.ErrIdempotencyKeyReused):
+		return metrics.OutcomeIdempotencyConflict
+	case errors.Is(err, reservation.ErrInvalidProductID),
+		errors.Is(err, reservation.ErrProductIDRequired),
+		errors.Is(err, reservation.ErrInvalidUserID),
+		errors.Is(err, reservation.ErrUserIDRequired),
+		errors.Is(err, reservation.ErrInvalidQuantity),
+		errors.Is(err, reservation.ErrExceedsMaxQuantity),
+		errors.Is(err, reservation.ErrInvalidIdempotencyKey),
+		errors.Is(err, reservation.ErrInvalidVariantID),
+		errors.Is(err, reservation.ErrVariantRequired),
+		errors.Is(err, reservation.ErrVariantNotFound):
+		return metrics.OutcomeInvalid
+	default:
+		return metrics.OutcomeError
+	}
+}
+
+func (s *StockService) reserve(
+	ctx context.Context,
+	productID string,
+	variantID string,
+	userID string,
+	quantity int,
+	admissionToken string,
+	idempotencyKey string,
+) (*reservation.Reservation, int, bool, error) {
 	logger.InfoContext(ctx, "reserving stock",
 		zap.String("product_id", productID),
+		zap.String("variant_id", variantID),
 		zap.String("user_id", userID),
 		zap.Int("quantity", quantity),
 	)
@@ -101,17 +381,56 @@ func (s *StockService) Reserve(
 	// Parse IDs
 	reservationProductID, err := reservation.ParseProductID(productID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("invalid reservation product id: %w", err)
+		return nil, 0, false, fmt.Errorf("invalid reservation product id: %w", err)
+	}
+
+	reservationVariantID, err := reservation.ParseVariantID(variantID)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("invalid variant id: %w", err)
 	}
 
 	uid, err := reservation.ParseUserID(userID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("invalid user id: %w", err)
+		return nil, 0, false, fmt.Errorf("invalid user id: %w", err)
 	}
 
 	// Validate quantity
 	if quantity <= 0 || quantity > reservation.MaxReservationQuantity {
-		return nil, 0, reservation.ErrInvalidQuantity
+		return nil, 0, false, reservation.ErrInvalidQuantity
+	}
+
+	stockProductID, err := stock.ParseProductID(productID)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("invalid stock product id: %w", err)
+	}
+	stockVariantID := stock.VariantID(reservationVariantID)
+
+	// A retried request gets the reservation made by the first one, even if the waiting
+	// room, sale window or price has changed since
+	var idemKey *reservation.IdempotencyKey
+	if idempotencyKey != "" {
+		idemKey, err = reservation.NewIdempotencyKey(uid, idempotencyKey, reservationProductID, reservationVariantID, quantity)
+		if err != nil {
+			return nil, 0, false, err
+		}
+
+		currentQty, originalID, err := s.stockReservationCoordinator.FindIdempotentReservation(ctx, stockProductID, stockVariantID, idemKey)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		if !originalID.IsEmpty() {
+			return s.replayReservation(ctx, originalID, currentQty)
+		}
+	}
+
+	// Only users admitted from the waiting room may reserve
+	if err := s.admissionService.VerifyAdmission(ctx, productID, userID, admissionToken); err != nil {
+		logger.WarnContext(ctx, "reservation rejected by waiting room",
+			zap.String("product_id", productID),
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, 0, false, err
 	}
 
 	// Check if product is active
@@ -121,35 +440,100 @@ func (s *StockService) Reserve(
 			zap.String("product_id", productID),
 			zap.Error(err),
 		)
-		return nil, 0, fmt.Errorf("failed to check product state: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to check product state: %w", err)
 	}
 
 	if !isActive {
 		logger.WarnContext(ctx, "product is not active",
 			zap.String("product_id", productID),
 		)
-		return nil, 0, errors.New("product is not active")
+		return nil, 0, false, auction.ErrProductNotActive
 	}
 
-	// Create reservation
-	res, err := reservation.NewReservation(reservationProductID, uid, quantity)
+	// Enforce the sale window even if the scheduled deactivation has not arrived yet
+	if err := s.productStateRepo.CheckSaleWindow(ctx, productID, time.Now()); err != nil {
+		if errors.Is(err, reservation.ErrSaleNotStarted) || errors.Is(err, reservation.ErrSaleEnded) {
+			logger.WarnContext(ctx, "product is outside its sale window",
+				zap.String("product_id", productID),
+				zap.Error(err),
+			)
+			return nil, 0, false, err
+		}
+		return nil, 0, false, fmt.Errorf("failed to check sale window: %w", err)
+	}
+
+	// Auction products are sold through bidding only
+	isAuction, err := s.productStateRepo.IsAuction(ctx, productID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create reservation: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to check product price type: %w", err)
+	}
+	if isAuction {
+		return nil, 0, false, auction.ErrProductSoldByAuction
 	}
 
-	// Execute Redis Lua script to reserve stock atomically
-	stockProductID, err := stock.ParseProductID(productID)
+	// Quote the price the product was published at, order-service charges exactly this price
+	amount, currency, err := s.productStateRepo.GetPrice(ctx, productID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("invalid stock product id: %w", err)
+		if errors.Is(err, reservation.ErrPriceUnavailable) {
+			logger.WarnContext(ctx, "product price is not known yet",
+				zap.String("product_id", productID),
+			)
+			return nil, 0, false, err
+		}
+		return nil, 0, false, fmt.Errorf("failed to get product price: %w", err)
 	}
-	newQty, err := s.stockReservationCoordinator.Reserve(ctx, stockProductID, res)
+
+	// Products with variants are stocked per variant, which may override the product price
+	hasVariants, err := s.productStateRepo.HasVariants(ctx, productID)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to check product variants: %w", err)
+	}
+	if hasVariants && reservationVariantID.IsEmpty() {
+		return nil, 0, false, reservation.ErrVariantRequired
+	}
+	if !reservationVariantID.IsEmpty() {
+		overrideAmount, overrideCurrency, err := s.productStateRepo.GetVariantPrice(ctx, productID, variantID)
+		if err != nil {
+			if errors.Is(err, reservation.ErrVariantNotFound) {
+				logger.WarnContext(ctx, "variant is not sold by this product",
+					zap.String("product_id", productID),
+					zap.String("variant_id", variantID),
+				)
+				return nil, 0, false, err
+			}
+			return nil, 0, false, fmt.Errorf("failed to get variant price: %w", err)
+		}
+		if overrideAmount > 0 {
+			amount, currency = overrideAmount, overrideCurrency
+		}
+	}
+
+	quote, err := reservation.NewPriceQuote(amount, currency, time.Now().Add(s.quoteTTL))
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	// Create reservation
+	res, err := reservation.NewReservation(reservationProductID, reservationVariantID, uid, quantity, quote)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	// Execute Redis Lua script to reserve stock atomically
+	newQty, originalID, err := s.stockReservationCoordinator.Reserve(ctx, stockProductID, stockVariantID, res, idemKey)
 	if err != nil {
 		logger.WarnContext(ctx, "failed to reserve stock in redis",
 			zap.String("product_id", productID),
+			zap.String("variant_id", variantID),
 			zap.Int("quantity", quantity),
 			zap.Error(err),
 		)
-		return nil, 0, err
+		return nil, 0, false, err
+	}
+
+	// The first request committed between the lookup above and the script
+	if !originalID.IsEmpty() {
+		return s.replayReservation(ctx, originalID, newQty)
 	}
 
 	logger.InfoContext(ctx, "stock reserved in redis",
@@ -167,7 +551,7 @@ func (s *StockService) Reserve(
 			zap.Error(err),
 		)
 
-		if _, rollbackErr := s.stockReservationCoordinator.Release(ctx, stockProductID, res.ID(), quantity); rollbackErr != nil {
+		if _, rollbackErr := s.stockReservationCoordinator.Release(ctx, stockProductID, stockVariantID, res.ID(), res.UserID(), quantity); rollbackErr != nil {
 			logger.ErrorContext(ctx, "CRITICAL: failed to rollback redis after outbox failure",
 				zap.String("product_id", productID),
 				zap.String("reservation_id", res.ID().String()),
@@ -175,8 +559,16 @@ func (s *StockService) Reserve(
 				zap.NamedError("rollback_error", rollbackErr),
 			)
 		}
+		if idemKey != nil {
+			if forgetErr := s.stockReservationCoordinator.ForgetIdempotencyKey(ctx, idemKey); forgetErr != nil {
+				logger.ErrorContext(ctx, "failed to forget idempotency key of rolled back reservation",
+					zap.String("reservation_id", res.ID().String()),
+					zap.Error(forgetErr),
+				)
+			}
+		}
 
-		return nil, 0, fmt.Errorf("failed to publish event: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to publish event: %w", err)
 	}
 
 	// Async write to PostgreSQL (send to queue, don't wait)
@@ -184,7 +576,7 @@ func (s *StockService) Reserve(
 
 	// Check if stock is depleted or low
 	if newQty == 0 {
-		if err := s.publishDepletedEvent(ctx, stockProductID); err != nil {
+		if err := s.publishDepletedEvent(ctx, stockProductID, stockVariantID); err != nil {
 			logger.ErrorContext(ctx, "failed to publish depleted event",
 				zap.String("product_id", productID),
 				zap.Error(err),
@@ -192,7 +584,7 @@ func (s *StockService) Reserve(
 		}
 	} else {
 		// Check low stock (asynchronously, don't block user)
-		go s.checkAndPublishLowStock(context.Background(), stockProductID, newQty)
+		go s.checkAndPublishLowStock(context.Background(), stockProductID, stockVariantID, newQty)
 	}
 
 	logger.InfoContext(ctx, "stock reserved successfully",
@@ -203,7 +595,24 @@ func (s *StockService) Reserve(
 		zap.Int("remaining", newQty),
 	)
 
-	return res, newQty, nil
+	return res, newQty, false, nil
+}
+
+// replayReservation returns the original reservation of a retried reserve request
+func (s *StockService) replayReservation(
+	ctx context.Context,
+	originalID reservation.ReservationID,
+	currentQty int,
+) (*reservation.Reservation, int, bool, error) {
+	// The persist worker may not have written the original reservation yet
+	original, err := s.persistentReservationRepo.FindByID(ctx, originalID)
+	if errors.Is(err, reservation.ErrReservationNotFound) {
+		original, err = s.cacheReservationRepo.FindByID(ctx, originalID)
+	}
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to load replayed reservation: %w", err)
+	}
+	return original, currentQty, true, nil
 }
 
 // Release releases a reservation
@@ -220,8 +629,12 @@ func (s *StockService) Release(
 		return 0, fmt.Errorf("invalid reservation id: %w", err)
 	}
 
-	// Find reservation
+	// Find reservation; the Redis hold lapses at expiry, so expired
+	// reservations are only found in PostgreSQL
 	res, err := s.cacheReservationRepo.FindByID(ctx, rid)
+	if errors.Is(err, reservation.ErrReservationNotFound) {
+		res, err = s.persistentReservationRepo.FindByID(ctx, rid)
+	}
 	if err != nil {
 		return 0, fmt.Errorf("reservation not found: %w", err)
 	}
@@ -232,18 +645,10 @@ func (s *StockService) Release(
 	}
 
 	var newQty int
-	newQty, err = s.stockReservationCoordinator.Release(ctx, stock.ProductID(res.ProductID()), res.ID(), res.Quantity())
+	newQty, err = s.stockReservationCoordinator.Release(ctx, stock.ProductID(res.ProductID()), stock.VariantID(res.VariantID()), res.ID(), res.UserID(), res.Quantity())
 	if err == reservation.ErrReservationNotFound {
-		// cache reservation already expired, just restore stock
-		newQty, err = s.stockRepo.Release(ctx, stock.ProductID(res.ProductID()), res.Quantity())
-		if err != nil {
-			logger.ErrorContext(ctx, "failed to release stock in redis",
-				zap.String("reservation_id", reservationID),
-				zap.String("product_id", res.ProductID().String()),
-				zap.Error(err),
-			)
-			return 0, fmt.Errorf("failed to release stock: %w", err)
-		}
+		// cache reservation already expired
+		return s.releaseLapsedHold(ctx, res)
 	} else if err != nil {
 		logger.ErrorContext(ctx, "failed to release stock and delete reservation in redis",
 			zap.String("reservation_id", reservationID),
@@ -253,25 +658,62 @@ func (s *StockService) Release(
 		return 0, fmt.Errorf("failed to release: %w", err)
 	}
 
-	// Update PostgreSQL status (async)
-	if err := s.updateReservationStatus(ctx, rid, reservation.ReservationStatusReleased); err != nil {
-		logger.ErrorContext(ctx, "failed to update reservation status",
+	// If the release cannot be recorded the reservation stays RESERVED in PostgreSQL; the
+	// expiry scan, or a retried release, then records it through releaseLapsedHold, whose
+	// ReturnStock skips the stock this release already returned
+	if err := s.recordRelease(ctx, res, s.releaseOutboxEvents(ctx, res, 0)); err != nil {
+		logger.ErrorContext(ctx, "failed to record release",
 			zap.String("reservation_id", reservationID),
 			zap.Error(err),
 		)
+		return 0, fmt.Errorf("failed to record release: %w", err)
 	}
 
-	// Publish event
-	if err := s.publishReleasedEvent(ctx, res); err != nil {
-		logger.ErrorContext(ctx, "failed to publish released event",
-			zap.String("reservation_id", reservationID),
+	logger.InfoContext(ctx, "reservation released successfully",
+		zap.String("reservation_id", reservationID),
+		zap.String("product_id", res.ProductID().String()),
+		zap.Int("quantity", res.Quantity()),
+		zap.Int("new_stock", newQty),
+	)
+
+	return newQty, nil
+}
+
+// releaseLapsedHold releases a reservation whose Redis hold already expired.
+// The release is claimed in PostgreSQL first (the upsert only overwrites RESERVED rows),
+// so concurrent releases (expiry scan, order cancellation) return the stock and the
+// user's purchase quota exactly once. It also records a Release whose Redis step went
+// through but whose PostgreSQL write failed; ReturnStock then leaves the stock alone.
+func (s *StockService) releaseLapsedHold(ctx context.Context, res *reservation.Reservation) (int, error) {
+	productID := stock.ProductID(res.ProductID())
+	variantID := stock.VariantID(res.VariantID())
+
+	entry := stock.NewReleaseLedgerEntry(productID, variantID, res.ID().String(), res.Quantity())
+	// The stock is returned after the release is recorded, so the event counts it as pending
+	events := s.releaseOutboxEvents(ctx, res, res.Quantity())
+	err := s.persistentReservationRepo.SaveWithEvents(ctx, res, []*stock.LedgerEntry{entry}, events)
+	if errors.Is(err, reservation.ErrReservationFinalized) {
+		return 0, reservation.ErrCanOnlyReleaseReserved
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to persist released reservation: %w", err)
+	}
+	res.ClearEvents()
+
+	newQty, err := s.stockReservationCoordinator.ReturnStock(ctx, productID, variantID, res.ID(), res.UserID(), res.Quantity())
+	if err != nil {
+		// The RELEASE ledger entry is committed, so stock reconciliation restores the counter
+		logger.ErrorContext(ctx, "failed to return stock in redis after release was recorded",
+			zap.String("reservation_id", res.ID().String()),
+			zap.String("product_id", productID.String()),
 			zap.Error(err),
 		)
+		return 0, fmt.Errorf("failed to release stock: %w", err)
 	}
 
-	logger.InfoContext(ctx, "reservation released successfully",
-		zap.String("reservation_id", reservationID),
-		zap.String("product_id", res.ProductID().String()),
+	logger.InfoContext(ctx, "lapsed reservation released successfully",
+		zap.String("reservation_id", res.ID().String()),
+		zap.String("product_id", productID.String()),
 		zap.Int("quantity", res.Quantity()),
 		zap.Int("new_stock", newQty),
 	)
@@ -279,17 +721,96 @@ func (s *StockService) Release(
 	return newQty, nil
 }
 
-// GetStock gets current stock for a product
+// Consume finalizes a reservation whose order has been paid.
+// The reservation is marked CONSUMED in Redis first, so no concurrent release
+// (order cancellation, expiry scan) can return the sold stock, then in PostgreSQL
+// together with the stock.consumed outbox event. Redelivery is a no-op.
+func (s *StockService) Consume(
+	ctx context.Context,
+	reservationID string,
+	orderID string,
+) error {
+	logger.InfoContext(ctx, "consuming reservation",
+		zap.String("reservation_id", reservationID),
+		zap.String("order_id", orderID),
+	)
+
+	rid, err := reservation.ParseReservationID(reservationID)
+	if err != nil {
+		return fmt.Errorf("invalid reservation id: %w", err)
+	}
+
+	// PostgreSQL is the source of truth for finalized reservations; fall back to Redis
+	// when the async persist worker has not written the reservation yet
+	res, err := s.persistentReservationRepo.FindByID(ctx, rid)
+	if errors.Is(err, reservation.ErrReservationNotFound) {
+		res, err = s.cacheReservationRepo.FindByID(ctx, rid)
+	}
+	if err != nil {
+		return fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if res.Status() == reservation.ReservationStatusConsumed {
+		logger.InfoContext(ctx, "reservation already consumed",
+			zap.String("reservation_id", reservationID),
+		)
+		return nil
+	}
+
+	if err := res.Consume(orderID); err != nil {
+		logger.ErrorContext(ctx, "paid order references a reservation that cannot be consumed",
+			zap.String("reservation_id", reservationID),
+			zap.String("order_id", orderID),
+			zap.String("status", string(res.Status())),
+			zap.Error(err),
+		)
+		return fmt.Errorf("cannot consume reservation: %w", err)
+	}
+
+	// A missing Redis key only means the hold TTL lapsed; the stock is still deducted
+	if err := s.stockReservationCoordinator.Consume(ctx, res); err != nil && !errors.Is(err, reservation.ErrReservationNotFound) {
+		return fmt.Errorf("failed to consume reservation in redis: %w", err)
+	}
+
+	entry := stock.NewConsumeLedgerEntry(stock.ProductID(res.ProductID()), stock.VariantID(res.VariantID()), res.ID().String(), res.Quantity())
+	err = s.persistentReservationRepo.SaveWithEvents(ctx, res, []*stock.LedgerEntry{entry}, s.reservationOutboxEvents(res))
+	if errors.Is(err, reservation.ErrReservationFinalized) {
+		logger.WarnContext(ctx, "reservation finalized concurrently, skipping",
+			zap.String("reservation_id", reservationID),
+		)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to persist consumed reservation: %w", err)
+	}
+	res.ClearEvents()
+
+	logger.InfoContext(ctx, "reservation consumed successfully",
+		zap.String("reservation_id", reservationID),
+		zap.String("product_id", res.ProductID().String()),
+		zap.String("order_id", orderID),
+	)
+
+	return nil
+}
+
+// GetStock gets current stock for a product, or for one of its variants
 func (s *StockService) GetStock(
 	ctx context.Context,
 	productID string,
+	variantID string,
 ) (*stock.Stock, error) {
 	pid, err := stock.ParseProductID(productID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid product id: %w", err)
 	}
 
-	stk, err := s.stockRepo.FindByProductID(ctx, pid)
+	vid, err := stock.ParseVariantID(variantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid variant id: %w", err)
+	}
+
+	stk, err := s.stockRepo.FindByProductID(ctx, pid, vid)
 	if err != nil {
 		return nil, fmt.Errorf("stock not found: %w", err)
 	}
@@ -319,11 +840,12 @@ func (s *StockService) GetReservation(
 func (s *StockService) rollbackReservation(
 	ctx context.Context,
 	productID reservation.ProductID,
+	variantID reservation.VariantID,
 	quantity int,
 	reservationID reservation.ReservationID,
 ) error {
 	// Return stock
-	if _, err := s.stockRepo.Release(ctx, stock.ProductID(productID), quantity); err != nil {
+	if _, err := s.stockRepo.Release(ctx, stock.ProductID(productID), stock.VariantID(variantID), quantity); err != nil {
 		return err
 	}
 
@@ -338,29 +860,61 @@ func (s *StockService) rollbackReservation(
 	return nil
 }
 
-// publishReservedEvent publishes stock.reserved event to outbox
+// publishReservedEvent records the reserve in the stock ledger and publishes stock.reserved to outbox
 func (s *StockService) publishReservedEvent(ctx context.Context, res *reservation.Reservation) error {
+	entry := stock.NewReserveLedgerEntry(stock.ProductID(res.ProductID()), stock.VariantID(res.VariantID()), res.ID().String(), res.Quantity())
+	if err := s.ledgerRepo.AppendWithEvents(ctx, []*stock.LedgerEntry{entry}, s.reservationOutboxEvents(res)); err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+
+	res.ClearEvents()
+	return nil
+}
+
+// reservationOutboxEvents builds outbox events for the reservation's pending domain events
+func (s *StockService) reservationOutboxEvents(res *reservation.Reservation) []*postgres.OutboxEvent {
 	events := res.DomainEvents()
+	outboxEvents := make([]*postgres.OutboxEvent, 0, len(events))
 	for _, event := range events {
-		outboxEvent := postgres.NewOutboxEvent(
+		outboxEvents = append(outboxEvents, postgres.NewOutboxEvent(
 			"reservation",
 			res.ID().String(),
 			event.EventType(),
 			s.reservationEventToPayload(event),
+		))
+	}
+	return outboxEvents
+}
+
+// releaseOutboxEvents builds the outbox events of a released reservation. stock.released
+// carries the stock after the release and the low-stock threshold, so product-service
+// derives the stock status from the event itself rather than from a stock.low that may be
+// consumed before it. pending is the released quantity not yet back in the stock counter.
+func (s *StockService) releaseOutboxEvents(ctx context.Context, res *reservation.Reservation, pending int) []*postgres.OutboxEvent {
+	events := s.reservationOutboxEvents(res)
+
+	stk, err := s.stockRepo.FindByProductID(ctx, stock.ProductID(res.ProductID()), stock.VariantID(res.VariantID()))
+	if err != nil {
+		// product-service then only learns that the stock is available again
+		logger.ErrorContext(ctx, "failed to read stock for released event",
+			zap.String("reservation_id", res.ID().String()),
+			zap.Error(err),
 		)
+		return events
+	}
 
-		if err := s.outboxRepo.Insert(ctx, outboxEvent); err != nil {
-			return fmt.Errorf("failed to insert outbox event: %w", err)
+	for _, event := range events {
+		if event.EventType == "stock.released" {
+			event.Payload["stock_quantity"] = stk.Quantity() + pending
+			event.Payload["low_stock_threshold"] = stk.GetLowStockThreshold()
 		}
 	}
-
-	res.ClearEvents()
-	return nil
+	return events
 }
 
 // publishDepletedEvent publishes stock.depleted event
-func (s *StockService) publishDepletedEvent(ctx context.Context, productID stock.ProductID) error {
-	event := stock.NewStockDepletedEvent(productID)
+func (s *StockService) publishDepletedEvent(ctx context.Context, productID stock.ProductID, variantID stock.VariantID) error {
+	event := stock.NewStockDepletedEvent(productID, variantID)
 
 	outboxEvent := postgres.NewOutboxEvent(
 		"stock",
@@ -368,6 +922,7 @@ func (s *StockService) publishDepletedEvent(ctx context.Context, productID stock
 		event.EventType(),
 		map[string]interface{}{
 			"product_id":  productID.String(),
+			"variant_id":  variantID.String(),
 			"occurred_at": event.OccurredAt().Format(time.RFC3339),
 		},
 	)
@@ -379,20 +934,39 @@ func (s *StockService) publishDepletedEvent(ctx context.Context, productID stock
 	return nil
 }
 
-// publishReleasedEvent publishes stock.released event
-func (s *StockService) publishReleasedEvent(ctx context.Context, res *reservation.Reservation) error {
-	events := res.DomainEvents()
-	for _, event := range events {
-		outboxEvent := postgres.NewOutboxEvent(
-			"reservation",
-			res.ID().String(),
-			event.EventType(),
-			s.reservationEventToPayload(event),
+// recordRelease saves the RELEASED status together with the RELEASE ledger entry and the
+// stock.released event, retrying with backoff since stock reconciliation trusts the ledger
+func (s *StockService) recordRelease(ctx context.Context, res *reservation.Reservation, events []*postgres.OutboxEvent) error {
+	entry := stock.NewReleaseLedgerEntry(stock.ProductID(res.ProductID()), stock.VariantID(res.VariantID()), res.ID().String(), res.Quantity())
+	backoff := releaseRecordBackoff
+
+	var err error
+	for attempt := 1; attempt <= releaseRecordAttempts; attempt++ {
+		err = s.persistentReservationRepo.SaveWithEvents(ctx, res, []*stock.LedgerEntry{entry}, events)
+		if err == nil || errors.Is(err, reservation.ErrReservationFinalized) {
+			break
+		}
+
+		logger.WarnContext(ctx, "failed to record release, retrying",
+			zap.String("reservation_id", res.ID().String()),
+			zap.Int("attempt", attempt),
+			zap.Error(err),
 		)
 
-		if err := s.outboxRepo.Insert(ctx, outboxEvent); err != nil {
-			return fmt.Errorf("failed to insert outbox event: %w", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
 		}
+		backoff *= 2
+	}
+
+	// Another release already finalized the row in PostgreSQL and recorded it
+	if errors.Is(err, reservation.ErrReservationFinalized) {
+		err = nil
+	}
+	if err != nil {
+		return err
 	}
 
 	res.ClearEvents()
@@ -400,9 +974,9 @@ func (s *StockService) publishReleasedEvent(ctx context.Context, res *reservatio
 }
 
 // checkAndPublishLowStock checks if stock is low and publishes event
-func (s *StockService) checkAndPublishLowStock(ctx context.Context, productID stock.ProductID, currentQty int) {
+func (s *StockService) checkAndPublishLowStock(ctx context.Context, productID stock.ProductID, variantID stock.VariantID, currentQty int) {
 	// Get full stock info to check threshold
-	stk, err := s.stockRepo.FindByProductID(ctx, productID)
+	stk, err := s.stockRepo.FindByProductID(ctx, productID, variantID)
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to check low stock",
 			zap.String("product_id", productID.String()),
@@ -414,11 +988,12 @@ func (s *StockService) checkAndPublishLowStock(ctx context.Context, productID st
 	if stk.IsLowStock() {
 		logger.InfoContext(ctx, "stock is low",
 			zap.String("product_id", productID.String()),
+			zap.String("variant_id", variantID.String()),
 			zap.Int("quantity", currentQty),
 			zap.Int("threshold", stk.GetLowStockThreshold()),
 		)
 
-		event := stock.NewStockLowEvent(productID, currentQty, stk.GetLowStockThreshold())
+		event := stock.NewStockLowEvent(productID, variantID, currentQty, stk.GetLowStockThreshold())
 
 		outboxEvent := postgres.NewOutboxEvent(
 			"stock",
@@ -426,6 +1001,7 @@ func (s *StockService) checkAndPublishLowStock(ctx context.Context, productID st
 			event.EventType(),
 			map[string]interface{}{
 				"product_id":  productID.String(),
+				"variant_id":  variantID.String(),
 				"quantity":    currentQty,
 				"threshold":   stk.GetLowStockThreshold(),
 				"occurred_at": event.OccurredAt().Format(time.RFC3339),
@@ -441,15 +1017,6 @@ func (s *StockService) checkAndPublishLowStock(ctx context.Context, productID st
 	}
 }
 
-// updateReservationStatus updates reservation status in persistent Repo
-func (s *StockService) updateReservationStatus(
-	ctx context.Context,
-	id reservation.ReservationID,
-	status reservation.ReservationStatus,
-) error {
-	return s.persistentReservationRepo.UpdateStatus(ctx, id, status)
-}
-
 // reservationEventToPayload converts reservation event to payload
 func (s *StockService) reservationEventToPayload(event reservation.DomainEvent) map[string]interface{} {
 	payload := map[string]interface{}{
@@ -460,17 +1027,29 @@ func (s *StockService) reservationEventToPayload(event reservation.DomainEvent)
 	case reservation.ReservationCreatedEvent:
 		payload["reservation_id"] = e.ReservationID.String()
 		payload["product_id"] = e.ProductID.String()
+		payload["variant_id"] = e.VariantID.String()
 		payload["user_id"] = e.UserID.String()
 		payload["quantity"] = e.Quantity
+		if e.Quote != nil {
+			payload["price_quote"] = map[string]interface{}{
+				"quote_id":   e.Quote.ID(),
+				"amount":     e.Quote.Amount(),
+				"currency":   e.Quote.Currency(),
+				"expires_at": e.Quote.ExpiresAt().Format(time.RFC3339),
+				"signature":  s.quoteSigner.Sign(e),
+			}
+		}
 
 	case reservation.ReservationReleasedEvent:
 		payload["reservation_id"] = e.ReservationID.String()
 		payload["product_id"] = e.ProductID.String()
+		payload["variant_id"] = e.VariantID.String()
 		payload["quantity"] = e.Quantity
 
 	case reservation.ReservationConsumedEvent:
 		payload["reservation_id"] = e.ReservationID.String()
 		payload["product_id"] = e.ProductID.String()
+		payload["variant_id"] = e.VariantID.String()
 		payload["order_id"] = e.OrderID
 	}
 
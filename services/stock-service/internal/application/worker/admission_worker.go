@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/application/service"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
+	"go.uber.org/zap"
+)
+
+// AdmissionWorker admits users from product waiting rooms at their configured rate.
+// The rate is enforced in Redis, so running the worker on several instances is safe.
+type AdmissionWorker struct {
+	admissionService *service.AdmissionService
+	interval         time.Duration
+}
+
+// NewAdmissionWorker creates a new admission worker
+func NewAdmissionWorker(
+	admissionService *service.AdmissionService,
+	cfg *config.AdmissionConfig,
+) *AdmissionWorker {
+	return &AdmissionWorker{
+		admissionService: admissionService,
+		interval:         cfg.AdmitInterval,
+	}
+}
+
+// Start starts the admission worker
+func (w *AdmissionWorker) Start(ctx context.Context) error {
+	zap.L().Info("starting admission worker",
+		zap.Duration("interval", w.interval),
+	)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			admitted, err := w.admissionService.AdmitWaiting(ctx)
+			if err != nil {
+				zap.L().Error("failed to admit waiting users", zap.Error(err))
+				continue
+			}
+			if admitted > 0 {
+				zap.L().Debug("waiting users admitted", zap.Int("admitted", admitted))
+			}
+
+		case <-ctx.Done():
+			zap.L().Info("admission worker stopping")
+			return nil
+		}
+	}
+}
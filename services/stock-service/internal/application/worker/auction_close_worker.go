@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/application/service"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
+	"go.uber.org/zap"
+)
+
+// AuctionCloseWorker closes auctions whose end time has passed
+type AuctionCloseWorker struct {
+	auctionService *service.AuctionService
+	auctionRepo    auction.Repository
+	scanInterval   time.Duration
+	batchSize      int
+	claimTTL       time.Duration
+}
+
+// NewAuctionCloseWorker creates a new auction close worker
+func NewAuctionCloseWorker(
+	auctionService *service.AuctionService,
+	auctionRepo auction.Repository,
+	cfg *config.AuctionCloseWorkerConfig,
+) *AuctionCloseWorker {
+	return &AuctionCloseWorker{
+		auctionService: auctionService,
+		auctionRepo:    auctionRepo,
+		scanInterval:   cfg.ScanInterval,
+		batchSize:      cfg.BatchSize,
+		claimTTL:       cfg.ClaimTTL,
+	}
+}
+
+// Start starts the close worker
+func (w *AuctionCloseWorker) Start(ctx context.Context) error {
+	zap.L().Info("starting auction close worker",
+		zap.Duration("scan_interval", w.scanInterval),
+		zap.Int("batch_size", w.batchSize),
+	)
+
+	ticker := time.NewTicker(w.scanInterval)
+	defer ticker.Stop()
+
+	// Run once immediately on startup
+	if err := w.closeDueAuctions(ctx); err != nil {
+		zap.L().Error("initial auction close scan failed", zap.Error(err))
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.closeDueAuctions(ctx); err != nil {
+				zap.L().Error("auction close scan failed", zap.Error(err))
+			}
+
+		case <-ctx.Done():
+			zap.L().Info("auction close worker stopping")
+			return nil
+		}
+	}
+}
+
+// closeDueAuctions closes the open auctions past their end time that this replica claims
+func (w *AuctionCloseWorker) closeDueAuctions(ctx context.Context) error {
+	now := time.Now()
+	due, err := w.auctionRepo.ClaimDueForClose(ctx, now, now.Add(w.claimTTL), w.batchSize)
+	if err != nil {
+		zap.L().Error("failed to claim due auctions", zap.Error(err))
+		return err
+	}
+
+	if len(due) == 0 {
+		return nil
+	}
+
+	successCount := 0
+	failCount := 0
+
+	for _, a := range due {
+		if err := w.auctionService.CloseAuction(ctx, a); err != nil {
+			// Log but continue; the auction stays OPEN and is retried once the claim lapses
+			zap.L().Error("failed to close auction",
+				zap.String("auction_id", a.ID().String()),
+				zap.String("product_id", a.ProductID().String()),
+				zap.Time("ends_at", a.EndsAt()),
+				zap.Error(err),
+			)
+			failCount++
+			continue
+		}
+
+		successCount++
+	}
+
+	zap.L().Info("due auctions processed",
+		zap.Int("success", successCount),
+		zap.Int("failed", failCount),
+		zap.Int("total", len(due)),
+	)
+
+	return nil
+}
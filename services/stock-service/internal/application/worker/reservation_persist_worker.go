@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/metrics"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
 	"go.uber.org/zap"
@@ -25,6 +26,10 @@ func NewReservationPersistWorker(
 	persistentReservationRepo reservation.PersistentRepository,
 	persistQueue chan *reservation.Reservation,
 ) *ReservationPersistWorker {
+	metrics.RegisterPersistQueueDepth(func() int {
+		return len(persistQueue)
+	})
+
 	return &ReservationPersistWorker{
 		cfg:                       cfg,
 		persistentReservationRepo: persistentReservationRepo,
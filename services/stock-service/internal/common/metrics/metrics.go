@@ -0,0 +1,198 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+)
+
+// namespace prefixes every metric of the system, e.g. auction_grpc_server_requests_total
+const namespace = "auction"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	// registerer labels everything it registers with the service name once Init has run
+	registerer prometheus.Registerer = registry
+)
+
+var (
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "requests_total",
+		Help:      "gRPC requests handled, by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC request latency, by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	reservations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "reservation",
+		Name:      "requests_total",
+		Help:      "Reservation attempts, by outcome.",
+	}, []string{"outcome"})
+
+	redisScriptDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "redis",
+		Name:      "script_duration_seconds",
+		Help:      "Latency of the reservation Lua scripts, by script.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 12), // 0.5ms to ~1s
+	}, []string{"script"})
+
+	outboxBacklog = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "backlog_events",
+		Help:      "Outbox events waiting to be relayed.",
+	})
+
+	outboxOldestAge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "oldest_event_age_seconds",
+		Help:      "Age of the oldest outbox event waiting to be relayed.",
+	})
+
+	outboxRelayLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "relay_lag_seconds",
+		Help:      "Time from an outbox event being written to it being published.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 14), // 50ms to ~7min
+	})
+
+	outboxPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "publish_total",
+		Help:      "Outbox publish attempts, by result.",
+	}, []string{"result"})
+
+	consumerLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "kafka_consumer",
+		Name:      "lag",
+		Help:      "Messages behind the partition high watermark at the last fetch.",
+	}, []string{"topic", "group", "partition"})
+
+	consumedMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "kafka_consumer",
+		Name:      "messages_total",
+		Help:      "Consumed messages, by result.",
+	}, []string{"topic", "group", "result"})
+)
+
+// Init registers the runtime and service collectors, labelled with the service name.
+// It must be called once, before any Register call.
+func Init(serviceName string) {
+	registerer = prometheus.WrapRegistererWith(prometheus.Labels{"service": serviceName}, registry)
+	registerer.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		grpcRequests,
+		grpcDuration,
+		reservations,
+		redisScriptDuration,
+		outboxBacklog,
+		outboxOldestAge,
+		outboxRelayLag,
+		outboxPublished,
+		consumerLag,
+		consumedMessages,
+	)
+}
+
+// NewServer creates the HTTP server exposing /metrics
+func NewServer(cfg *config.MetricsConfig) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+// RegisterPersistQueueDepth exposes the number of reservations waiting to be persisted
+func RegisterPersistQueueDepth(depth func() int) {
+	registerer.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "reservation",
+		Name:      "persist_queue_depth",
+		Help:      "Reservations waiting to be persisted to Postgres.",
+	}, func() float64 {
+		return float64(depth())
+	}))
+}
+
+// ObserveGRPCRequest records a handled gRPC request
+func ObserveGRPCRequest(method string, code codes.Code, latency time.Duration) {
+	grpcRequests.WithLabelValues(method, code.String()).Inc()
+	grpcDuration.WithLabelValues(method).Observe(latency.Seconds())
+}
+
+// Reservation outcomes
+const (
+	OutcomeReserved              = "reserved"
+	OutcomeReplayed              = "replayed"
+	OutcomeInsufficientStock     = "insufficient_stock"
+	OutcomePurchaseLimitExceeded = "purchase_limit_exceeded"
+	OutcomeAdmissionRejected     = "admission_rejected"
+	OutcomeSaleClosed            = "sale_closed"
+	OutcomeIdempotencyConflict   = "idempotency_conflict"
+	OutcomeInvalid               = "invalid"
+	OutcomeError                 = "error"
+)
+
+// RecordReservation records the outcome of a reservation attempt
+func RecordReservation(outcome string) {
+	reservations.WithLabelValues(outcome).Inc()
+}
+
+// ObserveRedisScript records the latency of a Lua script call
+func ObserveRedisScript(script string, latency time.Duration) {
+	redisScriptDuration.WithLabelValues(script).Observe(latency.Seconds())
+}
+
+// SetOutboxBacklog records the pending outbox events and the age of the oldest one
+func SetOutboxBacklog(count int64, oldestAge time.Duration) {
+	outboxBacklog.Set(float64(count))
+	outboxOldestAge.Set(oldestAge.Seconds())
+}
+
+// ObserveOutboxPublish records a publish attempt of an outbox event written at createdAt
+func ObserveOutboxPublish(createdAt time.Time, err error) {
+	if err != nil {
+		outboxPublished.WithLabelValues("error").Inc()
+		return
+	}
+	outboxPublished.WithLabelValues("success").Inc()
+	outboxRelayLag.Observe(time.Since(createdAt).Seconds())
+}
+
+// SetConsumerLag records how far a consumer is behind on a partition
+func SetConsumerLag(topic, group string, partition int, lag int64) {
+	consumerLag.WithLabelValues(topic, group, strconv.Itoa(partition)).Set(float64(max(lag, 0)))
+}
+
+// RecordConsumedMessage records a consumed message, result being "processed" or "dead_lettered"
+func RecordConsumedMessage(topic, group, result string) {
+	consumedMessages.WithLabelValues(topic, group, result).Inc()
+}
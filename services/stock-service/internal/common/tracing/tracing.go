@@ -0,0 +1,131 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service"
+
+// ExporterFactory creates a span exporter from the tracing configuration
+type ExporterFactory func(cfg *config.TracingConfig) (sdktrace.SpanExporter, error)
+
+// exporters are selectable by TRACING_EXPORTER
+var exporters = map[string]ExporterFactory{
+	"stdout": newStdoutExporter,
+	"file":   newFileExporter,
+}
+
+// RegisterExporter makes an exporter selectable by name, e.g. an OTLP exporter.
+// It must be called before Init.
+func RegisterExporter(name string, factory ExporterFactory) {
+	exporters[name] = factory
+}
+
+// Init installs the global tracer provider and the W3C trace context propagator.
+// With the "none" exporter spans are still created and propagated but never exported.
+// The returned function flushes pending spans and shuts the exporter down.
+func Init(cfg *config.TracingConfig, serviceName string) (func(context.Context) error, error) {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+
+	if cfg.Exporter != "none" {
+		factory, ok := exporters[cfg.Exporter]
+		if !ok {
+			return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+		}
+		exporter, err := factory(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s exporter: %w", cfg.Exporter, err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the service
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// TraceID returns the trace ID of the span in ctx, or "" if there is none
+func TraceID(ctx context.Context) string {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.HasTraceID() {
+		return ""
+	}
+	return spanCtx.TraceID().String()
+}
+
+// Inject returns the trace context of ctx as a string map, for storing with outbox rows
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns ctx continuing the trace context produced by Inject
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+func newStdoutExporter(_ *config.TracingConfig) (sdktrace.SpanExporter, error) {
+	return stdouttrace.New()
+}
+
+// fileExporter writes spans as JSON lines and closes the file on shutdown
+type fileExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+func newFileExporter(cfg *config.TracingConfig) (sdktrace.SpanExporter, error) {
+	file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &fileExporter{SpanExporter: exporter, file: file}, nil
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
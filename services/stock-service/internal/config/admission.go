@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// AdmissionConfig holds waiting room configuration
+type AdmissionConfig struct {
+	// TokenSecret signs admission tokens; the API gateway must use the same secret
+	TokenSecret   string
+	AdmissionTTL  time.Duration
+	AdmitInterval time.Duration
+}
+
+func loadAdmissionConfig() AdmissionConfig {
+	return AdmissionConfig{
+		TokenSecret:   mustEnv("ADMISSION_TOKEN_SECRET"),
+		AdmissionTTL:  getEnvDuration("ADMISSION_TTL", 5*time.Minute),
+		AdmitInterval: getEnvDuration("ADMISSION_ADMIT_INTERVAL", 1*time.Second),
+	}
+}
+
+func (c *AdmissionConfig) Validate() error {
+	if c.TokenSecret == "" {
+		return fmt.Errorf("token_secret is required")
+	}
+	if c.AdmissionTTL <= 0 {
+		return fmt.Errorf("admission_ttl must be positive")
+	}
+	if c.AdmitInterval <= 0 {
+		return fmt.Errorf("admit_interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// AuctionCloseWorkerConfig holds auction close worker configuration
+type AuctionCloseWorkerConfig struct {
+	ScanInterval time.Duration
+	BatchSize    int
+	// ClaimTTL is how long a replica holds a due auction before another may close it
+	ClaimTTL time.Duration
+}
+
+func loadAuctionCloseWorkerConfig() AuctionCloseWorkerConfig {
+	return AuctionCloseWorkerConfig{
+		ScanInterval: getEnvDuration("AUCTION_CLOSE_SCAN_INTERVAL", 5*time.Second),
+		BatchSize:    getEnvInt("AUCTION_CLOSE_BATCH_SIZE", 50),
+		ClaimTTL:     getEnvDuration("AUCTION_CLOSE_CLAIM_TTL", 1*time.Minute),
+	}
+}
+
+func (c *AuctionCloseWorkerConfig) Validate() error {
+	if c.ScanInterval <= 0 {
+		return fmt.Errorf("scan_interval must be positive")
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive")
+	}
+	if c.ClaimTTL <= 0 {
+		return fmt.Errorf("claim_ttl must be positive")
+	}
+	return nil
+}
@@ -19,7 +19,12 @@ type Config struct {
 	Outbox                    OutboxConfig
 	Service                   ServiceConfig
 	Logger                    LoggerConfig
+	Tracing                   TracingConfig
+	Metrics                   MetricsConfig
 	ExpiredReservationScanner ExpiredReservationScannerConfig
+	AuctionCloseWorker        AuctionCloseWorkerConfig
+	Admission                 AdmissionConfig
+	PriceQuote                PriceQuoteConfig
 }
 
 // Load loads configuration from environment variables
@@ -33,8 +38,13 @@ func Load() (*Config, error) {
 		Outbox:                    loadOutboxConfig(),
 		Service:                   loadServiceConfig(),
 		Logger:                    loadLoggerConfig(),
+		Tracing:                   loadTracingConfig(),
+		Metrics:                   loadMetricsConfig(),
 		Kafka:                     loadKafkaConfig(),
 		ExpiredReservationScanner: loadExpiredReservationScannerConfig(),
+		AuctionCloseWorker:        loadAuctionCloseWorkerConfig(),
+		Admission:                 loadAdmissionConfig(),
+		PriceQuote:                loadPriceQuoteConfig(),
 	}
 
 	// Validate configuration
@@ -56,15 +66,33 @@ func (c *Config) Validate() error {
 	if err := c.Redis.Validate(); err != nil {
 		return fmt.Errorf("redis config: %w", err)
 	}
+	if err := c.Outbox.Validate(); err != nil {
+		return fmt.Errorf("outbox config: %w", err)
+	}
 	if err := c.Service.Validate(); err != nil {
 		return fmt.Errorf("service config: %w", err)
 	}
 	if err := c.Kafka.Validate(); err != nil {
 		return fmt.Errorf("kafka config: %w", err)
 	}
+	if err := c.Tracing.Validate(); err != nil {
+		return fmt.Errorf("tracing config: %w", err)
+	}
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("metrics config: %w", err)
+	}
 	if err := c.ExpiredReservationScanner.Validate(); err != nil {
 		return fmt.Errorf("expired reservation scanner config: %w", err)
 	}
+	if err := c.AuctionCloseWorker.Validate(); err != nil {
+		return fmt.Errorf("auction close worker config: %w", err)
+	}
+	if err := c.Admission.Validate(); err != nil {
+		return fmt.Errorf("admission config: %w", err)
+	}
+	if err := c.PriceQuote.Validate(); err != nil {
+		return fmt.Errorf("price quote config: %w", err)
+	}
 	return nil
 }
 
@@ -78,6 +106,15 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// mustEnv gets a required environment variable, such as a secret with no safe default
+func mustEnv(key string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		panic("environment variable " + key + " is required")
+	}
+	return value
+}
+
 // getEnvInt gets environment variable as int with default value
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
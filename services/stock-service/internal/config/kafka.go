@@ -16,6 +16,12 @@ type KafkaConfig struct {
 	ProducerMaxAttempts  int
 	ProducerBatchSize    int
 	ProducerBatchTimeout time.Duration
+
+	// Failed messages are retried with exponential backoff, then moved to DLQTopic
+	DLQTopic                string
+	ConsumerMaxAttempts     int
+	ConsumerRetryBackoff    time.Duration
+	ConsumerRetryMaxBackoff time.Duration
 }
 
 func loadKafkaConfig() KafkaConfig {
@@ -31,6 +37,11 @@ func loadKafkaConfig() KafkaConfig {
 		ProducerMaxAttempts:  getEnvInt("KAFKA_PRODUCER_MAX_ATTEMPTS", 3),
 		ProducerBatchSize:    getEnvInt("KAFKA_PRODUCER_BATCH_SIZE", 100),
 		ProducerBatchTimeout: getEnvDuration("KAFKA_PRODUCER_BATCH_TIMEOUT", 10*time.Millisecond),
+
+		DLQTopic:                getEnv("KAFKA_DLQ_TOPIC", "stock-service-dlq"),
+		ConsumerMaxAttempts:     getEnvInt("KAFKA_CONSUMER_MAX_ATTEMPTS", 5),
+		ConsumerRetryBackoff:    getEnvDuration("KAFKA_CONSUMER_RETRY_BACKOFF", 200*time.Millisecond),
+		ConsumerRetryMaxBackoff: getEnvDuration("KAFKA_CONSUMER_RETRY_MAX_BACKOFF", 5*time.Second),
 	}
 }
 
@@ -50,5 +61,14 @@ func (c KafkaConfig) Validate() error {
 	if c.ConsumerGroupID == "" {
 		return errors.New("kafka consumer group ID is required")
 	}
+	if c.DLQTopic == "" {
+		return errors.New("kafka dead-letter topic is required")
+	}
+	if c.ConsumerMaxAttempts <= 0 {
+		return errors.New("kafka consumer max attempts must be positive")
+	}
+	if c.ConsumerRetryBackoff <= 0 || c.ConsumerRetryMaxBackoff < c.ConsumerRetryBackoff {
+		return errors.New("kafka consumer retry backoff must be positive and not exceed the max backoff")
+	}
 	return nil
 }
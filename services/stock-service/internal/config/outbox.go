@@ -1,23 +1,51 @@
 package config
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // OutboxConfig holds outbox pattern configuration
 type OutboxConfig struct {
-	PollInterval  time.Duration
-	BatchSize     int
-	MaxRetries    int
-	CleanupAge    time.Duration
-	CleanupPeriod time.Duration
+	PollInterval   time.Duration
+	BatchSize      int
+	ClaimLease     time.Duration // how long a relay owns a claimed batch
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	CleanupAge     time.Duration
+	CleanupPeriod  time.Duration
 }
 
 // loadOutboxConfig loads outbox configuration
 func loadOutboxConfig() OutboxConfig {
 	return OutboxConfig{
-		PollInterval:  getEnvDuration("OUTBOX_POLL_INTERVAL", 1*time.Second),
-		BatchSize:     getEnvInt("OUTBOX_BATCH_SIZE", 100),
-		MaxRetries:    getEnvInt("OUTBOX_MAX_RETRIES", 3),
-		CleanupAge:    getEnvDuration("OUTBOX_CLEANUP_AGE", 7*24*time.Hour),    // 7 days
-		CleanupPeriod: getEnvDuration("OUTBOX_CLEANUP_PERIOD", 1*24*time.Hour), // 1 day
+		PollInterval:   getEnvDuration("OUTBOX_POLL_INTERVAL", 1*time.Second),
+		BatchSize:      getEnvInt("OUTBOX_BATCH_SIZE", 100),
+		ClaimLease:     getEnvDuration("OUTBOX_CLAIM_LEASE", 30*time.Second),
+		MaxRetries:     getEnvInt("OUTBOX_MAX_RETRIES", 5),
+		RetryBaseDelay: getEnvDuration("OUTBOX_RETRY_BASE_DELAY", 1*time.Second),
+		RetryMaxDelay:  getEnvDuration("OUTBOX_RETRY_MAX_DELAY", 5*time.Minute),
+		CleanupAge:     getEnvDuration("OUTBOX_CLEANUP_AGE", 7*24*time.Hour),    // 7 days
+		CleanupPeriod:  getEnvDuration("OUTBOX_CLEANUP_PERIOD", 1*24*time.Hour), // 1 day
 	}
 }
+
+func (c OutboxConfig) Validate() error {
+	if c.PollInterval <= 0 {
+		return errors.New("outbox poll interval must be positive")
+	}
+	if c.BatchSize <= 0 {
+		return errors.New("outbox batch size must be positive")
+	}
+	if c.ClaimLease <= c.PollInterval {
+		return errors.New("outbox claim lease must be longer than the poll interval")
+	}
+	if c.MaxRetries <= 0 {
+		return errors.New("outbox max retries must be positive")
+	}
+	if c.RetryBaseDelay <= 0 || c.RetryMaxDelay < c.RetryBaseDelay {
+		return errors.New("outbox retry base delay must be positive and not exceed the max delay")
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// PriceQuoteConfig holds reservation price quote configuration
+type PriceQuoteConfig struct {
+	// Secret signs price quotes; order-service must use the same secret
+	Secret string
+	TTL    time.Duration
+}
+
+func loadPriceQuoteConfig() PriceQuoteConfig {
+	return PriceQuoteConfig{
+		Secret: mustEnv("PRICE_QUOTE_SECRET"),
+		TTL:    getEnvDuration("PRICE_QUOTE_TTL", 15*time.Minute),
+	}
+}
+
+func (c *PriceQuoteConfig) Validate() error {
+	if c.Secret == "" {
+		return fmt.Errorf("secret is required")
+	}
+	if c.TTL <= 0 {
+		return fmt.Errorf("ttl must be positive")
+	}
+	return nil
+}
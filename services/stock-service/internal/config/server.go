@@ -11,6 +11,9 @@ type ServerConfig struct {
 	MaxConnectionIdle time.Duration
 	MaxConnectionAge  time.Duration
 	Timeout           time.Duration
+	// IdentitySecret verifies the caller identity the API gateway forwards; the gateway must
+	// sign with the same secret
+	IdentitySecret string
 }
 
 // loadServerConfig loads server configuration
@@ -20,6 +23,7 @@ func loadServerConfig() ServerConfig {
 		MaxConnectionIdle: getEnvDuration("SERVER_MAX_CONNECTION_IDLE", 5*time.Minute),
 		MaxConnectionAge:  getEnvDuration("SERVER_MAX_CONNECTION_AGE", 10*time.Minute),
 		Timeout:           getEnvDuration("SERVER_TIMEOUT", 30*time.Second),
+		IdentitySecret:    mustEnv("IDENTITY_SECRET"),
 	}
 }
 
@@ -28,5 +32,8 @@ func (c *ServerConfig) Validate() error {
 	if c.GRPCPort <= 0 || c.GRPCPort > 65535 {
 		return fmt.Errorf("invalid grpc port: %d", c.GRPCPort)
 	}
+	if c.IdentitySecret == "" {
+		return fmt.Errorf("identity_secret is required")
+	}
 	return nil
 }
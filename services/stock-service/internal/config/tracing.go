@@ -0,0 +1,32 @@
+package config
+
+import "errors"
+
+// TracingConfig holds tracing configuration
+type TracingConfig struct {
+	Exporter    string  // "none", "stdout", "file" or a registered exporter
+	FilePath    string  // output file of the "file" exporter
+	SampleRatio float64 // share of new traces that are sampled, incoming sampled traces are always kept
+}
+
+// loadTracingConfig loads tracing configuration
+func loadTracingConfig() TracingConfig {
+	return TracingConfig{
+		Exporter:    getEnv("TRACING_EXPORTER", "none"),
+		FilePath:    getEnv("TRACING_FILE_PATH", "traces.jsonl"),
+		SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1.0),
+	}
+}
+
+func (c TracingConfig) Validate() error {
+	if c.Exporter == "" {
+		return errors.New("tracing exporter is required, use \"none\" to disable exporting")
+	}
+	if c.Exporter == "file" && c.FilePath == "" {
+		return errors.New("tracing file path is required for the file exporter")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return errors.New("tracing sample ratio must be between 0 and 1")
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package admission
+
+import "errors"
+
+var (
+	ErrInvalidProductID      = errors.New("invalid product id")
+	ErrInvalidUserID         = errors.New("invalid user id")
+	ErrInvalidAdmissionRate  = errors.New("admission rate must be positive")
+	ErrQueueNotEnabled       = errors.New("waiting room is not enabled for this product")
+	ErrNotInQueue            = errors.New("user is not in the waiting room")
+	ErrAdmissionRequired     = errors.New("admission token is required for this product")
+	ErrInvalidAdmissionToken = errors.New("invalid admission token")
+	ErrAdmissionExpired      = errors.New("admission token has expired")
+)
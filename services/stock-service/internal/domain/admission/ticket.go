@@ -0,0 +1,59 @@
+package admission
+
+import "time"
+
+// MaxAdmissionRate caps how many users per second a product may admit
+const MaxAdmissionRate = 10000
+
+// ValidateAdmissionRate checks a per-second admission rate
+func ValidateAdmissionRate(rate int) error {
+	if rate <= 0 || rate > MaxAdmissionRate {
+		return ErrInvalidAdmissionRate
+	}
+	return nil
+}
+
+// QueueStatus describes the waiting room of a product
+type QueueStatus struct {
+	ProductID     string
+	Enabled       bool
+	AdmissionRate int // users admitted per second
+	Waiting       int64
+}
+
+// Ticket is a user's place in the waiting room of a product.
+// Position is 1-based while waiting and 0 once admitted.
+type Ticket struct {
+	ProductID     string
+	UserID        string
+	Position      int64
+	Admitted      bool
+	Token         string
+	ExpiresAt     time.Time // admission expiry, zero while waiting
+	EstimatedWait time.Duration
+}
+
+// NewWaitingTicket creates a ticket for a user still waiting to be admitted
+func NewWaitingTicket(productID, userID string, position int64, admissionRate int) *Ticket {
+	var wait time.Duration
+	if admissionRate > 0 {
+		wait = time.Duration(position) * time.Second / time.Duration(admissionRate)
+	}
+	return &Ticket{
+		ProductID:     productID,
+		UserID:        userID,
+		Position:      position,
+		EstimatedWait: wait,
+	}
+}
+
+// NewAdmittedTicket creates a ticket carrying the admission token
+func NewAdmittedTicket(productID, userID, token string, expiresAt time.Time) *Ticket {
+	return &Ticket{
+		ProductID: productID,
+		UserID:    userID,
+		Admitted:  true,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+}
@@ -0,0 +1,59 @@
+package admission
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenSigner issues and verifies admission tokens.
+// A token is "<expires_unix>.<hex hmac-sha256(product_id|user_id|expires_unix)>", so it is
+// bound to one user and one product and can be verified without a Redis lookup.
+// The API gateway verifies the same format with the shared secret.
+type TokenSigner struct {
+	secret []byte
+}
+
+// NewTokenSigner creates a token signer
+func NewTokenSigner(secret string) *TokenSigner {
+	return &TokenSigner{secret: []byte(secret)}
+}
+
+// Issue signs an admission token valid until expiresAt
+func (s *TokenSigner) Issue(productID, userID string, expiresAt time.Time) string {
+	exp := strconv.FormatInt(expiresAt.Unix(), 10)
+	return exp + "." + s.sign(productID, userID, exp)
+}
+
+// Verify checks that the token was issued for the product and user and has not expired
+func (s *TokenSigner) Verify(token, productID, userID string, now time.Time) error {
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidAdmissionToken
+	}
+
+	expectedSig := s.sign(productID, userID, exp)
+	if !hmac.Equal([]byte(sig), []byte(expectedSig)) {
+		return ErrInvalidAdmissionToken
+	}
+
+	expUnix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return ErrInvalidAdmissionToken
+	}
+	if now.Unix() >= expUnix {
+		return ErrAdmissionExpired
+	}
+
+	return nil
+}
+
+func (s *TokenSigner) sign(productID, userID, exp string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s|%s|%s", productID, userID, exp)
+	return hex.EncodeToString(mac.Sum(nil))
+}
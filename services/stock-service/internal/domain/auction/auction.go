@@ -0,0 +1,300 @@
+package auction
+
+import (
+	"time"
+)
+
+const (
+	// MinAuctionDuration is the minimum time between creation and end of an auction
+	MinAuctionDuration = 1 * time.Minute
+
+	// MaxAuctionQuantity is the maximum quantity sold in one auction (same as reservation limit)
+	MaxAuctionQuantity = 10
+)
+
+// AuctionStatus represents the status of an auction
+type AuctionStatus string
+
+const (
+	AuctionStatusOpen   AuctionStatus = "OPEN"
+	AuctionStatusWon    AuctionStatus = "WON"
+	AuctionStatusUnsold AuctionStatus = "UNSOLD"
+)
+
+// Auction represents an auction of a product's stock.
+// Bids are accepted atomically in Redis; the aggregate holds the
+// durable state and decides the outcome when the auction closes.
+type Auction struct {
+	id            AuctionID
+	productID     ProductID
+	sellerID      UserID
+	startPrice    int64
+	minIncrement  int64
+	reservePrice  int64
+	currency      string
+	quantity      int
+	status        AuctionStatus
+	endsAt        time.Time
+	highestBid    int64
+	highestBidder UserID
+	bidCount      int
+	reservationID *string
+	createdAt     time.Time
+	closedAt      *time.Time
+	updatedAt     time.Time
+	domainEvents  []DomainEvent
+}
+
+// NewAuction creates a new open auction
+func NewAuction(
+	productID ProductID,
+	sellerID UserID,
+	startPrice int64,
+	minIncrement int64,
+	reservePrice int64,
+	currency string,
+	quantity int,
+	endsAt time.Time,
+) (*Auction, error) {
+	if productID.IsEmpty() {
+		return nil, ErrInvalidProductID
+	}
+	if sellerID.IsEmpty() {
+		return nil, ErrInvalidUserID
+	}
+	if startPrice <= 0 {
+		return nil, ErrInvalidStartPrice
+	}
+	if minIncrement <= 0 {
+		return nil, ErrInvalidMinIncrement
+	}
+	if reservePrice < 0 {
+		return nil, ErrInvalidReservePrice
+	}
+	if currency == "" {
+		return nil, ErrCurrencyRequired
+	}
+	if quantity <= 0 || quantity > MaxAuctionQuantity {
+		return nil, ErrInvalidQuantity
+	}
+
+	now := time.Now()
+	if endsAt.Before(now.Add(MinAuctionDuration)) {
+		return nil, ErrInvalidEndTime
+	}
+
+	return &Auction{
+		id:           NewAuctionID(),
+		productID:    productID,
+		sellerID:     sellerID,
+		startPrice:   startPrice,
+		minIncrement: minIncrement,
+		reservePrice: reservePrice,
+		currency:     currency,
+		quantity:     quantity,
+		status:       AuctionStatusOpen,
+		endsAt:       endsAt,
+		createdAt:    now,
+		updatedAt:    now,
+	}, nil
+}
+
+// ReconstructAuction reconstructs from persistence
+func ReconstructAuction(
+	id AuctionID,
+	productID ProductID,
+	sellerID UserID,
+	startPrice int64,
+	minIncrement int64,
+	reservePrice int64,
+	currency string,
+	quantity int,
+	status AuctionStatus,
+	endsAt time.Time,
+	highestBid int64,
+	highestBidder UserID,
+	bidCount int,
+	reservationID *string,
+	createdAt time.Time,
+	closedAt *time.Time,
+	updatedAt time.Time,
+) *Auction {
+	return &Auction{
+		id:            id,
+		productID:     productID,
+		sellerID:      sellerID,
+		startPrice:    startPrice,
+		minIncrement:  minIncrement,
+		reservePrice:  reservePrice,
+		currency:      currency,
+		quantity:      quantity,
+		status:        status,
+		endsAt:        endsAt,
+		highestBid:    highestBid,
+		highestBidder: highestBidder,
+		bidCount:      bidCount,
+		reservationID: reservationID,
+		createdAt:     createdAt,
+		closedAt:      closedAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+// Getters
+func (a *Auction) ID() AuctionID {
+	return a.id
+}
+
+func (a *Auction) ProductID() ProductID {
+	return a.productID
+}
+
+func (a *Auction) SellerID() UserID {
+	return a.sellerID
+}
+
+func (a *Auction) StartPrice() int64 {
+	return a.startPrice
+}
+
+func (a *Auction) MinIncrement() int64 {
+	return a.minIncrement
+}
+
+func (a *Auction) ReservePrice() int64 {
+	return a.reservePrice
+}
+
+func (a *Auction) Currency() string {
+	return a.currency
+}
+
+func (a *Auction) Quantity() int {
+	return a.quantity
+}
+
+func (a *Auction) Status() AuctionStatus {
+	return a.status
+}
+
+func (a *Auction) EndsAt() time.Time {
+	return a.endsAt
+}
+
+func (a *Auction) HighestBid() int64 {
+	return a.highestBid
+}
+
+func (a *Auction) HighestBidder() UserID {
+	return a.highestBidder
+}
+
+func (a *Auction) BidCount() int {
+	return a.bidCount
+}
+
+func (a *Auction) ReservationID() *string {
+	return a.reservationID
+}
+
+func (a *Auction) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *Auction) ClosedAt() *time.Time {
+	return a.closedAt
+}
+
+func (a *Auction) UpdatedAt() time.Time {
+	return a.updatedAt
+}
+
+// IsOpen checks if the auction still accepts bids
+func (a *Auction) IsOpen() bool {
+	return a.status == AuctionStatusOpen && time.Now().Before(a.endsAt)
+}
+
+// HasEnded checks if the auction end time has passed
+func (a *Auction) HasEnded() bool {
+	return !time.Now().Before(a.endsAt)
+}
+
+// MinimumNextBid returns the lowest amount the next bid must reach
+func (a *Auction) MinimumNextBid() int64 {
+	if a.bidCount == 0 {
+		return a.startPrice
+	}
+	return a.highestBid + a.minIncrement
+}
+
+// ApplyBidState updates the leading bid from the authoritative bid store (Redis)
+func (a *Auction) ApplyBidState(highestBid int64, highestBidder UserID, bidCount int) {
+	a.highestBid = highestBid
+	a.highestBidder = highestBidder
+	a.bidCount = bidCount
+}
+
+// IsReserveMet checks if the leading bid satisfies the reserve price
+func (a *Auction) IsReserveMet() bool {
+	return a.bidCount > 0 && !a.highestBidder.IsEmpty() && a.highestBid >= a.reservePrice
+}
+
+// MarkWon closes the auction with the leading bidder as winner
+func (a *Auction) MarkWon(reservationID string) error {
+	if a.status != AuctionStatusOpen {
+		return ErrCanOnlyCloseOpen
+	}
+	if !a.IsReserveMet() {
+		return ErrBidTooLow
+	}
+
+	now := time.Now()
+	a.status = AuctionStatusWon
+	a.reservationID = &reservationID
+	a.closedAt = &now
+	a.updatedAt = now
+
+	a.recordEvent(NewAuctionWonEvent(
+		a.id,
+		a.productID,
+		a.highestBidder,
+		reservationID,
+		a.quantity,
+		a.highestBid,
+		a.currency,
+		now,
+	))
+
+	return nil
+}
+
+// MarkUnsold closes the auction without a winner
+func (a *Auction) MarkUnsold(reason string) error {
+	if a.status != AuctionStatusOpen {
+		return ErrCanOnlyCloseOpen
+	}
+
+	now := time.Now()
+	a.status = AuctionStatusUnsold
+	a.closedAt = &now
+	a.updatedAt = now
+
+	a.recordEvent(NewAuctionUnsoldEvent(a.id, a.productID, a.highestBid, a.bidCount, reason, now))
+
+	return nil
+}
+
+// Domain events
+func (a *Auction) recordEvent(event DomainEvent) {
+	a.domainEvents = append(a.domainEvents, event)
+}
+
+func (a *Auction) DomainEvents() []DomainEvent {
+	events := make([]DomainEvent, len(a.domainEvents))
+	copy(events, a.domainEvents)
+	return events
+}
+
+func (a *Auction) ClearEvents() {
+	a.domainEvents = nil
+}
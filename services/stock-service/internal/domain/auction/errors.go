@@ -0,0 +1,26 @@
+package auction
+
+import "errors"
+
+var (
+	ErrAuctionNotFound      = errors.New("auction not found")
+	ErrInvalidAuctionID     = errors.New("invalid auction id")
+	ErrInvalidProductID     = errors.New("invalid product id")
+	ErrInvalidUserID        = errors.New("invalid user id")
+	ErrInvalidStartPrice    = errors.New("start price must be positive")
+	ErrInvalidMinIncrement  = errors.New("min increment must be positive")
+	ErrInvalidReservePrice  = errors.New("reserve price cannot be negative")
+	ErrCurrencyRequired     = errors.New("currency is required")
+	ErrInvalidQuantity      = errors.New("invalid quantity")
+	ErrInvalidEndTime       = errors.New("auction end time is too soon")
+	ErrInvalidBidAmount     = errors.New("bid amount must be positive")
+	ErrBidTooLow            = errors.New("bid is below the minimum acceptable amount")
+	ErrSellerCannotBid      = errors.New("seller cannot bid on own auction")
+	ErrAuctionNotOpen       = errors.New("auction is not open for bidding")
+	ErrAuctionAlreadyExists = errors.New("product already has an open auction")
+	ErrAuctionNotEnded      = errors.New("auction has not ended yet")
+	ErrCanOnlyCloseOpen     = errors.New("only open auctions can be closed")
+	ErrNotAuctionProduct    = errors.New("product is not sold by auction")
+	ErrProductSoldByAuction = errors.New("product can only be purchased through auction")
+	ErrProductNotActive     = errors.New("product is not active")
+)
@@ -0,0 +1,88 @@
+package auction
+
+import "time"
+
+// DomainEvent interface
+type DomainEvent interface {
+	OccurredAt() time.Time
+	EventType() string
+}
+
+// AuctionWonEvent is emitted when an auction closes with a winning bid.
+// The winner's stock is already reserved; Order Service creates the order from it.
+type AuctionWonEvent struct {
+	AuctionID     AuctionID
+	ProductID     ProductID
+	WinnerID      UserID
+	ReservationID string
+	Quantity      int
+	UnitPrice     int64
+	Currency      string
+	occurredAt    time.Time
+}
+
+func NewAuctionWonEvent(
+	auctionID AuctionID,
+	productID ProductID,
+	winnerID UserID,
+	reservationID string,
+	quantity int,
+	unitPrice int64,
+	currency string,
+	occurredAt time.Time,
+) AuctionWonEvent {
+	return AuctionWonEvent{
+		AuctionID:     auctionID,
+		ProductID:     productID,
+		WinnerID:      winnerID,
+		ReservationID: reservationID,
+		Quantity:      quantity,
+		UnitPrice:     unitPrice,
+		Currency:      currency,
+		occurredAt:    occurredAt,
+	}
+}
+
+func (e AuctionWonEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e AuctionWonEvent) EventType() string {
+	return "auction.won"
+}
+
+// AuctionUnsoldEvent is emitted when an auction closes without a winner
+type AuctionUnsoldEvent struct {
+	AuctionID  AuctionID
+	ProductID  ProductID
+	HighestBid int64
+	BidCount   int
+	Reason     string
+	occurredAt time.Time
+}
+
+func NewAuctionUnsoldEvent(
+	auctionID AuctionID,
+	productID ProductID,
+	highestBid int64,
+	bidCount int,
+	reason string,
+	occurredAt time.Time,
+) AuctionUnsoldEvent {
+	return AuctionUnsoldEvent{
+		AuctionID:  auctionID,
+		ProductID:  productID,
+		HighestBid: highestBid,
+		BidCount:   bidCount,
+		Reason:     reason,
+		occurredAt: occurredAt,
+	}
+}
+
+func (e AuctionUnsoldEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e AuctionUnsoldEvent) EventType() string {
+	return "auction.unsold"
+}
@@ -0,0 +1,69 @@
+package auction
+
+import (
+	"github.com/samborkent/uuidv7"
+)
+
+// AuctionID represents unique identifier of an auction
+type AuctionID string
+
+func NewAuctionID() AuctionID {
+	return AuctionID(uuidv7.New().String())
+}
+
+func ParseAuctionID(id string) (AuctionID, error) {
+	if id == "" {
+		return "", ErrInvalidAuctionID
+	}
+	if !uuidv7.IsValidString(id) {
+		return "", ErrInvalidAuctionID
+	}
+	return AuctionID(id), nil
+}
+
+func (id AuctionID) String() string {
+	return string(id)
+}
+
+func (id AuctionID) IsEmpty() bool {
+	return id == ""
+}
+
+// ProductID reference
+type ProductID string
+
+func ParseProductID(id string) (ProductID, error) {
+	if id == "" {
+		return "", ErrInvalidProductID
+	}
+	if !uuidv7.IsValidString(id) {
+		return "", ErrInvalidProductID
+	}
+	return ProductID(id), nil
+}
+
+func (id ProductID) String() string {
+	return string(id)
+}
+
+func (id ProductID) IsEmpty() bool {
+	return id == ""
+}
+
+// UserID reference (seller or bidder)
+type UserID string
+
+func ParseUserID(id string) (UserID, error) {
+	if id == "" {
+		return "", ErrInvalidUserID
+	}
+	return UserID(id), nil
+}
+
+func (id UserID) String() string {
+	return string(id)
+}
+
+func (id UserID) IsEmpty() bool {
+	return id == ""
+}
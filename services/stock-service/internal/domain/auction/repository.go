@@ -0,0 +1,16 @@
+package auction
+
+import (
+	"context"
+	"time"
+)
+
+// Repository persists auctions (durable state, source of truth after close)
+type Repository interface {
+	Save(ctx context.Context, a *Auction) error
+	FindByID(ctx context.Context, id AuctionID) (*Auction, error)
+	// ClaimDueForClose claims open auctions whose end time has passed until claimedUntil,
+	// so each is closed by a single worker replica. A claim that lapses before the auction is
+	// closed, e.g. after a crash, lets another scan pick it up.
+	ClaimDueForClose(ctx context.Context, now time.Time, claimedUntil time.Time, limit int) ([]*Auction, error)
+}
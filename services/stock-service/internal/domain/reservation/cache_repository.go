@@ -7,4 +7,7 @@ type CacheRepository interface {
 	FindByID(ctx context.Context, id ReservationID) (*Reservation, error)
 	Delete(ctx context.Context, id ReservationID) error
 	FindActiveByProductID(ctx context.Context, productID ProductID) ([]*Reservation, error)
+
+	// SetPurchaseQuota overwrites the used purchase quota of a user for a product (recovery)
+	SetPurchaseQuota(ctx context.Context, total PurchaseTotal) error
 }
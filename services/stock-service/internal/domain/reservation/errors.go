@@ -15,4 +15,14 @@ var (
 	ErrCanOnlyConsumeReserved = errors.New("only reserved reservations can be consumed")
 	ErrCanOnlyReleaseReserved = errors.New("only reserved reservations can be released")
 	ErrCanOnlyExpireReserved  = errors.New("only reserved reservations can expire")
+	ErrReservationFinalized   = errors.New("reservation is already finalized")
+	ErrPurchaseLimitExceeded  = errors.New("purchase limit exceeded for this product")
+	ErrSaleNotStarted         = errors.New("sale has not started yet")
+	ErrSaleEnded              = errors.New("sale has ended")
+	ErrInvalidIdempotencyKey  = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was already used with a different request")
+	ErrPriceUnavailable       = errors.New("product price is not available yet")
+	ErrInvalidVariantID       = errors.New("invalid variant id")
+	ErrVariantRequired        = errors.New("product has variants, a variant must be selected")
+	ErrVariantNotFound        = errors.New("variant not found")
 )
@@ -12,23 +12,29 @@ type DomainEvent interface {
 type ReservationCreatedEvent struct {
 	ReservationID ReservationID
 	ProductID     ProductID
+	VariantID     VariantID
 	UserID        UserID
 	Quantity      int
+	Quote         *PriceQuote // nil for auction reservations, which are priced by the winning bid
 	occurredAt    time.Time
 }
 
 func NewReservationCreatedEvent(
 	reservationID ReservationID,
 	productID ProductID,
+	variantID VariantID,
 	userID UserID,
 	quantity int,
+	quote *PriceQuote,
 	occurredAt time.Time,
 ) ReservationCreatedEvent {
 	return ReservationCreatedEvent{
 		ReservationID: reservationID,
 		ProductID:     productID,
+		VariantID:     variantID,
 		UserID:        userID,
 		Quantity:      quantity,
+		Quote:         quote,
 		occurredAt:    occurredAt,
 	}
 }
@@ -45,6 +51,7 @@ func (e ReservationCreatedEvent) EventType() string {
 type ReservationConsumedEvent struct {
 	ReservationID ReservationID
 	ProductID     ProductID
+	VariantID     VariantID
 	OrderID       string
 	occurredAt    time.Time
 }
@@ -52,12 +59,14 @@ type ReservationConsumedEvent struct {
 func NewReservationConsumedEvent(
 	reservationID ReservationID,
 	productID ProductID,
+	variantID VariantID,
 	orderID string,
 	occurredAt time.Time,
 ) ReservationConsumedEvent {
 	return ReservationConsumedEvent{
 		ReservationID: reservationID,
 		ProductID:     productID,
+		VariantID:     variantID,
 		OrderID:       orderID,
 		occurredAt:    occurredAt,
 	}
@@ -75,6 +84,7 @@ func (e ReservationConsumedEvent) EventType() string {
 type ReservationReleasedEvent struct {
 	ReservationID ReservationID
 	ProductID     ProductID
+	VariantID     VariantID
 	Quantity      int
 	occurredAt    time.Time
 }
@@ -82,12 +92,14 @@ type ReservationReleasedEvent struct {
 func NewReservationReleasedEvent(
 	reservationID ReservationID,
 	productID ProductID,
+	variantID VariantID,
 	quantity int,
 	occurredAt time.Time,
 ) ReservationReleasedEvent {
 	return ReservationReleasedEvent{
 		ReservationID: reservationID,
 		ProductID:     productID,
+		VariantID:     variantID,
 		Quantity:      quantity,
 		occurredAt:    occurredAt,
 	}
@@ -0,0 +1,48 @@
+package reservation
+
+import "fmt"
+
+// MaxIdempotencyKeyLength bounds client supplied idempotency keys
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey identifies a client's reserve request so that a retried request
+// returns the original reservation instead of reserving stock again.
+// Keys are scoped to the user; the fingerprint ties a key to the request payload.
+type IdempotencyKey struct {
+	userID      UserID
+	key         string
+	fingerprint string
+}
+
+// NewIdempotencyKey creates the idempotency key of a reserve request
+func NewIdempotencyKey(userID UserID, key string, productID ProductID, variantID VariantID, quantity int) (*IdempotencyKey, error) {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	// Requests without a variant keep the fingerprint format they had before variants
+	fingerprint := fmt.Sprintf("%s:%d", productID.String(), quantity)
+	if !variantID.IsEmpty() {
+		fingerprint = fmt.Sprintf("%s:%s:%d", productID.String(), variantID.String(), quantity)
+	}
+
+	return &IdempotencyKey{
+		userID:      userID,
+		key:         key,
+		fingerprint: fingerprint,
+	}, nil
+}
+
+// Getters
+func (k *IdempotencyKey) UserID() UserID {
+	return k.userID
+}
+
+func (k *IdempotencyKey) Key() string {
+	return k.key
+}
+
+// Fingerprint identifies the request payload the key was first used with
+func (k *IdempotencyKey) Fingerprint() string {
+	return k.fingerprint
+}
@@ -67,3 +67,25 @@ func (id UserID) String() string {
 func (id UserID) IsEmpty() bool {
 	return id == ""
 }
+
+// VariantID reference, empty for products sold without variants
+type VariantID string
+
+// ParseVariantID parses an optional variant ID
+func ParseVariantID(id string) (VariantID, error) {
+	if id == "" {
+		return "", nil
+	}
+	if !uuidv7.IsValidString(id) {
+		return "", ErrInvalidVariantID
+	}
+	return VariantID(id), nil
+}
+
+func (id VariantID) String() string {
+	return string(id)
+}
+
+func (id VariantID) IsEmpty() bool {
+	return id == ""
+}
@@ -13,4 +13,15 @@ type PersistentRepository interface {
 	FindActiveByProductID(ctx context.Context, productID ProductID) ([]*Reservation, error)
 
 	FindExpiredWithinWindow(ctx context.Context, windowStart, windowEnd time.Time, limit int) ([]*Reservation, error)
+
+	// FindPurchaseTotals sums the units each user holds or bought per product
+	FindPurchaseTotals(ctx context.Context) ([]PurchaseTotal, error)
+}
+
+// PurchaseTotal is the quantity a user holds (RESERVED) or bought (CONSUMED) of a product,
+// i.e. the part of a per-user purchase limit that is used up
+type PurchaseTotal struct {
+	ProductID ProductID
+	UserID    UserID
+	Quantity  int
 }
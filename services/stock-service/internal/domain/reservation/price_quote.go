@@ -0,0 +1,74 @@
+package reservation
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/samborkent/uuidv7"
+)
+
+// PriceQuote is the unit price a reservation was made at.
+// Order-service creates the order at exactly this price as long as the quote has not expired.
+type PriceQuote struct {
+	id        string
+	amount    int64
+	currency  string
+	expiresAt time.Time
+}
+
+// NewPriceQuote quotes a unit price until expiresAt
+func NewPriceQuote(amount int64, currency string, expiresAt time.Time) (*PriceQuote, error) {
+	if amount <= 0 || currency == "" {
+		return nil, ErrPriceUnavailable
+	}
+	return &PriceQuote{
+		id:        uuidv7.New().String(),
+		amount:    amount,
+		currency:  currency,
+		expiresAt: expiresAt,
+	}, nil
+}
+
+func (q *PriceQuote) ID() string {
+	return q.id
+}
+
+func (q *PriceQuote) Amount() int64 {
+	return q.amount
+}
+
+func (q *PriceQuote) Currency() string {
+	return q.currency
+}
+
+func (q *PriceQuote) ExpiresAt() time.Time {
+	return q.expiresAt
+}
+
+// QuoteSigner signs price quotes carried on stock.reserved.
+// The signature is hex hmac-sha256 over
+// "quote_id|reservation_id|product_id|user_id|quantity|amount|currency|expires_unix",
+// so a quote cannot be altered or moved to another reservation.
+// Order-service verifies the same format with the shared secret.
+type QuoteSigner struct {
+	secret []byte
+}
+
+// NewQuoteSigner creates a quote signer
+func NewQuoteSigner(secret string) *QuoteSigner {
+	return &QuoteSigner{secret: []byte(secret)}
+}
+
+// Sign returns the signature of a quote for the reservation described by the event
+func (s *QuoteSigner) Sign(event ReservationCreatedEvent) string {
+	q := event.Quote
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s|%s|%s|%s|%d|%d|%s|%d",
+		q.ID(), event.ReservationID, event.ProductID, event.UserID, event.Quantity,
+		q.Amount(), q.Currency(), q.ExpiresAt().Unix(),
+	)
+	return hex.EncodeToString(mac.Sum(nil))
+}
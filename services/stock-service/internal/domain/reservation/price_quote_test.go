@@ -0,0 +1,74 @@
+package reservation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuoteSignerSign(t *testing.T) {
+	quote := &PriceQuote{
+		id:        "quote-1",
+		amount:    1500,
+		currency:  "USD",
+		expiresAt: time.Unix(1790000000, 0),
+	}
+	base := ReservationCreatedEvent{
+		ReservationID: "res-1",
+		ProductID:     "prod-1",
+		UserID:        "user-1",
+		Quantity:      2,
+		Quote:         quote,
+	}
+	// Order-service verifies this exact signature, see its price_quote_test.go
+	const want = "664c2612bf5a9c6bbf18550696b0764e4448f6df2dc3a93fdcbeaaf0e6e35a95"
+
+	tests := []struct {
+		name     string
+		secret   string
+		event    func(e *ReservationCreatedEvent)
+		wantSame bool
+	}{
+		{
+			name:     "signs the documented format",
+			secret:   "test-secret",
+			wantSame: true,
+		},
+		{
+			name:   "other secret",
+			secret: "other-secret",
+		},
+		{
+			name:   "other reservation",
+			secret: "test-secret",
+			event:  func(e *ReservationCreatedEvent) { e.ReservationID = "res-2" },
+		},
+		{
+			name:   "other quantity",
+			secret: "test-secret",
+			event:  func(e *ReservationCreatedEvent) { e.Quantity = 3 },
+		},
+		{
+			name:   "other amount",
+			secret: "test-secret",
+			event: func(e *ReservationCreatedEvent) {
+				q := *quote
+				q.amount = 1
+				e.Quote = &q
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := base
+			if tt.event != nil {
+				tt.event(&event)
+			}
+
+			got := NewQuoteSigner(tt.secret).Sign(event)
+			if (got == want) != tt.wantSame {
+				t.Fatalf("Sign() = %s, same as %s: %v, want %v", got, want, got == want, tt.wantSame)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ const (
 type Reservation struct {
 	id           ReservationID
 	productID    ProductID
+	variantID    VariantID // empty for products sold without variants
 	userID       UserID
 	quantity     int
 	status       ReservationStatus
@@ -37,11 +38,13 @@ type Reservation struct {
 	domainEvents []DomainEvent
 }
 
-// NewReservation creates a new reservation
+// NewReservation creates a new reservation at the quoted price (nil for auction reservations)
 func NewReservation(
 	productID ProductID,
+	variantID VariantID,
 	userID UserID,
 	quantity int,
+	quote *PriceQuote,
 ) (*Reservation, error) {
 	if productID.IsEmpty() {
 		return nil, ErrProductIDRequired
@@ -62,6 +65,7 @@ func NewReservation(
 	r := &Reservation{
 		id:         reservationID,
 		productID:  productID,
+		variantID:  variantID,
 		userID:     userID,
 		quantity:   quantity,
 		status:     ReservationStatusReserved,
@@ -69,7 +73,7 @@ func NewReservation(
 		expiredAt:  now.Add(ReservationTTL),
 	}
 
-	r.recordEvent(NewReservationCreatedEvent(reservationID, productID, userID, quantity, now))
+	r.recordEvent(NewReservationCreatedEvent(reservationID, productID, variantID, userID, quantity, quote, now))
 
 	return r, nil
 }
@@ -78,6 +82,7 @@ func NewReservation(
 func ReconstructReservation(
 	id ReservationID,
 	productID ProductID,
+	variantID VariantID,
 	userID UserID,
 	quantity int,
 	status ReservationStatus,
@@ -90,6 +95,7 @@ func ReconstructReservation(
 	return &Reservation{
 		id:         id,
 		productID:  productID,
+		variantID:  variantID,
 		userID:     userID,
 		quantity:   quantity,
 		status:     status,
@@ -110,6 +116,10 @@ func (r *Reservation) ProductID() ProductID {
 	return r.productID
 }
 
+func (r *Reservation) VariantID() VariantID {
+	return r.variantID
+}
+
 func (r *Reservation) UserID() UserID {
 	return r.userID
 }
@@ -134,6 +144,14 @@ func (r *Reservation) OrderID() *string {
 	return r.orderID
 }
 
+func (r *Reservation) ConsumedAt() *time.Time {
+	return r.consumedAt
+}
+
+func (r *Reservation) ReleasedAt() *time.Time {
+	return r.releasedAt
+}
+
 // IsExpired checks if reservation has expired
 func (r *Reservation) IsExpired() bool {
 	return time.Now().After(r.expiredAt)
@@ -144,21 +162,20 @@ func (r *Reservation) IsActive() bool {
 	return r.status == ReservationStatusReserved && !r.IsExpired()
 }
 
-// Consume marks reservation as consumed (order created)
+// Consume marks reservation as consumed (order paid).
+// A reservation past its TTL still holds its stock until it is released,
+// so a payment confirmed by Order Service may consume it.
 func (r *Reservation) Consume(orderID string) error {
 	if r.status != ReservationStatusReserved {
 		return ErrCanOnlyConsumeReserved
 	}
-	if r.IsExpired() {
-		return ErrReservationExpired
-	}
 
 	now := time.Now()
 	r.status = ReservationStatusConsumed
 	r.consumedAt = &now
 	r.orderID = &orderID
 
-	r.recordEvent(NewReservationConsumedEvent(r.id, r.productID, orderID, now))
+	r.recordEvent(NewReservationConsumedEvent(r.id, r.productID, r.variantID, orderID, now))
 
 	return nil
 }
@@ -173,7 +190,7 @@ func (r *Reservation) Release() error {
 	r.status = ReservationStatusReleased
 	r.releasedAt = &now
 
-	r.recordEvent(NewReservationReleasedEvent(r.id, r.productID, r.quantity, now))
+	r.recordEvent(NewReservationReleasedEvent(r.id, r.productID, r.variantID, r.quantity, now))
 
 	return nil
 }
@@ -0,0 +1,200 @@
+package stock
+
+import (
+	"context"
+	"time"
+
+	"github.com/samborkent/uuidv7"
+)
+
+// MaxAdjustmentNoteLength is the maximum length of a free-text adjustment note
+const MaxAdjustmentNoteLength = 255
+
+// AdjustmentReason explains why a seller changed the stock level
+type AdjustmentReason string
+
+const (
+	AdjustmentReasonRestock    AdjustmentReason = "RESTOCK"
+	AdjustmentReasonReturn     AdjustmentReason = "RETURN"
+	AdjustmentReasonDamaged    AdjustmentReason = "DAMAGED"
+	AdjustmentReasonLost       AdjustmentReason = "LOST"
+	AdjustmentReasonCorrection AdjustmentReason = "CORRECTION"
+)
+
+// allowsIncrease reports whether the reason can justify adding stock
+func (r AdjustmentReason) allowsIncrease() bool {
+	switch r {
+	case AdjustmentReasonRestock, AdjustmentReasonReturn, AdjustmentReasonCorrection:
+		return true
+	}
+	return false
+}
+
+// allowsDecrease reports whether the reason can justify removing stock
+func (r AdjustmentReason) allowsDecrease() bool {
+	switch r {
+	case AdjustmentReasonDamaged, AdjustmentReasonLost, AdjustmentReasonCorrection:
+		return true
+	}
+	return false
+}
+
+// Adjustment is a seller-initiated change to the available stock of a product or variant
+type Adjustment struct {
+	id            string
+	productID     ProductID
+	variantID     VariantID
+	actorID       string
+	delta         int
+	reason        AdjustmentReason
+	note          string
+	quantityAfter int
+	createdAt     time.Time
+	domainEvents  []DomainEvent
+}
+
+// NewStockAddition creates an adjustment that adds stock
+func NewStockAddition(productID ProductID, variantID VariantID, actorID string, quantity int, reason AdjustmentReason, note string) (*Adjustment, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	if !reason.allowsIncrease() {
+		return nil, ErrInvalidAdjustmentReason
+	}
+	return newAdjustment(productID, variantID, actorID, quantity, reason, note)
+}
+
+// NewStockRemoval creates an adjustment that removes stock
+func NewStockRemoval(productID ProductID, variantID VariantID, actorID string, quantity int, reason AdjustmentReason, note string) (*Adjustment, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	if !reason.allowsDecrease() {
+		return nil, ErrInvalidAdjustmentReason
+	}
+	return newAdjustment(productID, variantID, actorID, -quantity, reason, note)
+}
+
+func newAdjustment(productID ProductID, variantID VariantID, actorID string, delta int, reason AdjustmentReason, note string) (*Adjustment, error) {
+	if productID.IsEmpty() {
+		return nil, ErrProductIDRequired
+	}
+	if actorID == "" {
+		return nil, ErrActorIDRequired
+	}
+	if len(note) > MaxAdjustmentNoteLength {
+		return nil, ErrAdjustmentNoteTooLong
+	}
+
+	return &Adjustment{
+		id:        uuidv7.New().String(),
+		productID: productID,
+		variantID: variantID,
+		actorID:   actorID,
+		delta:     delta,
+		reason:    reason,
+		note:      note,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// ReconstructAdjustment reconstructs an adjustment from persistence
+func ReconstructAdjustment(
+	id string,
+	productID ProductID,
+	variantID VariantID,
+	actorID string,
+	delta int,
+	reason AdjustmentReason,
+	note string,
+	quantityAfter int,
+	createdAt time.Time,
+) *Adjustment {
+	return &Adjustment{
+		id:            id,
+		productID:     productID,
+		variantID:     variantID,
+		actorID:       actorID,
+		delta:         delta,
+		reason:        reason,
+		note:          note,
+		quantityAfter: quantityAfter,
+		createdAt:     createdAt,
+	}
+}
+
+// MarkApplied records the available quantity after the adjustment was applied
+func (a *Adjustment) MarkApplied(quantityAfter int) {
+	a.quantityAfter = quantityAfter
+	a.recordEvent(NewStockAdjustedEvent(a.id, a.productID, a.variantID, a.delta, a.reason, a.actorID, quantityAfter, a.createdAt))
+}
+
+// LedgerEntry returns the ledger entry recording this adjustment
+func (a *Adjustment) LedgerEntry() *LedgerEntry {
+	return newLedgerEntry(a.productID, a.variantID, LedgerEntryAdjust, abs(a.delta), a.delta, "")
+}
+
+// Getters
+func (a *Adjustment) ID() string {
+	return a.id
+}
+
+func (a *Adjustment) ProductID() ProductID {
+	return a.productID
+}
+
+func (a *Adjustment) VariantID() VariantID {
+	return a.variantID
+}
+
+func (a *Adjustment) ActorID() string {
+	return a.actorID
+}
+
+func (a *Adjustment) Delta() int {
+	return a.delta
+}
+
+func (a *Adjustment) Reason() AdjustmentReason {
+	return a.reason
+}
+
+func (a *Adjustment) Note() string {
+	return a.note
+}
+
+func (a *Adjustment) QuantityAfter() int {
+	return a.quantityAfter
+}
+
+func (a *Adjustment) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// Domain events
+func (a *Adjustment) recordEvent(event DomainEvent) {
+	a.domainEvents = append(a.domainEvents, event)
+}
+
+func (a *Adjustment) DomainEvents() []DomainEvent {
+	events := make([]DomainEvent, len(a.domainEvents))
+	copy(events, a.domainEvents)
+	return events
+}
+
+func (a *Adjustment) ClearEvents() {
+	a.domainEvents = nil
+}
+
+// AdjustmentRepository defines the interface for the adjustment audit trail
+type AdjustmentRepository interface {
+	// FindByProductID lists the adjustments of a product and all of its variants
+	FindByProductID(ctx context.Context, productID ProductID, limit, offset int) ([]*Adjustment, error)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -5,9 +5,14 @@ import "errors"
 var (
 	ErrStockNotFound      = errors.New("stock not found")
 	ErrInvalidProductID   = errors.New("invalid product id")
+	ErrInvalidVariantID   = errors.New("invalid variant id")
 	ErrProductIDRequired  = errors.New("product id is required")
 	ErrInsufficientStock  = errors.New("insufficient stock")
 	ErrInvalidQuantity    = errors.New("invalid quantity")
 	ErrNegativeQuantity   = errors.New("quantity cannot be negative")
 	ErrExceedsMaxQuantity = errors.New("quantity exceeds maximum limit of 10")
+
+	ErrInvalidAdjustmentReason = errors.New("invalid adjustment reason")
+	ErrActorIDRequired         = errors.New("actor id is required")
+	ErrAdjustmentNoteTooLong   = errors.New("adjustment note cannot be longer than 255 characters")
 )
@@ -11,13 +11,15 @@ type DomainEvent interface {
 // StockSetEvent is emitted when stock is initially set
 type StockSetEvent struct {
 	ProductID  ProductID
+	VariantID  VariantID
 	Quantity   int
 	occurredAt time.Time
 }
 
-func NewStockSetEvent(productID ProductID, quantity int) StockSetEvent {
+func NewStockSetEvent(productID ProductID, variantID VariantID, quantity int) StockSetEvent {
 	return StockSetEvent{
 		ProductID:  productID,
+		VariantID:  variantID,
 		Quantity:   quantity,
 		occurredAt: time.Now(),
 	}
@@ -34,12 +36,14 @@ func (e StockSetEvent) EventType() string {
 // StockDepletedEvent is emitted when stock reaches zero
 type StockDepletedEvent struct {
 	ProductID  ProductID
+	VariantID  VariantID
 	occurredAt time.Time
 }
 
-func NewStockDepletedEvent(productID ProductID) StockDepletedEvent {
+func NewStockDepletedEvent(productID ProductID, variantID VariantID) StockDepletedEvent {
 	return StockDepletedEvent{
 		ProductID:  productID,
+		VariantID:  variantID,
 		occurredAt: time.Now(),
 	}
 }
@@ -55,14 +59,16 @@ func (e StockDepletedEvent) EventType() string {
 // StockLowEvent is emitted when stock falls below threshold
 type StockLowEvent struct {
 	ProductID  ProductID
+	VariantID  VariantID
 	Quantity   int
 	Threshold  int
 	occurredAt time.Time
 }
 
-func NewStockLowEvent(productID ProductID, quantity int, threshold int) StockLowEvent {
+func NewStockLowEvent(productID ProductID, variantID VariantID, quantity int, threshold int) StockLowEvent {
 	return StockLowEvent{
 		ProductID:  productID,
+		VariantID:  variantID,
 		Quantity:   quantity,
 		Threshold:  threshold,
 		occurredAt: time.Now(),
@@ -76,3 +82,45 @@ func (e StockLowEvent) OccurredAt() time.Time {
 func (e StockLowEvent) EventType() string {
 	return "stock.low"
 }
+
+// StockAdjustedEvent is emitted when a seller adds or removes stock
+type StockAdjustedEvent struct {
+	AdjustmentID  string
+	ProductID     ProductID
+	VariantID     VariantID
+	Delta         int
+	Reason        AdjustmentReason
+	ActorID       string
+	QuantityAfter int
+	occurredAt    time.Time
+}
+
+func NewStockAdjustedEvent(
+	adjustmentID string,
+	productID ProductID,
+	variantID VariantID,
+	delta int,
+	reason AdjustmentReason,
+	actorID string,
+	quantityAfter int,
+	occurredAt time.Time,
+) StockAdjustedEvent {
+	return StockAdjustedEvent{
+		AdjustmentID:  adjustmentID,
+		ProductID:     productID,
+		VariantID:     variantID,
+		Delta:         delta,
+		Reason:        reason,
+		ActorID:       actorID,
+		QuantityAfter: quantityAfter,
+		occurredAt:    occurredAt,
+	}
+}
+
+func (e StockAdjustedEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e StockAdjustedEvent) EventType() string {
+	return "stock.adjusted"
+}
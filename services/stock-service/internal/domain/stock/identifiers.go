@@ -24,3 +24,25 @@ func (id ProductID) String() string {
 func (id ProductID) IsEmpty() bool {
 	return id == ""
 }
+
+// VariantID references a product variant; it is empty for products sold without variants
+type VariantID string
+
+// ParseVariantID parses an optional variant ID, an empty string selects the product's own stock
+func ParseVariantID(id string) (VariantID, error) {
+	if id == "" {
+		return "", nil
+	}
+	if !uuidv7.IsValidString(id) {
+		return "", ErrInvalidVariantID
+	}
+	return VariantID(id), nil
+}
+
+func (id VariantID) String() string {
+	return string(id)
+}
+
+func (id VariantID) IsEmpty() bool {
+	return id == ""
+}
@@ -0,0 +1,110 @@
+package stock
+
+import (
+	"context"
+	"time"
+
+	"github.com/samborkent/uuidv7"
+)
+
+// LedgerEntryType represents the kind of stock movement recorded in the ledger
+type LedgerEntryType string
+
+const (
+	LedgerEntrySet     LedgerEntryType = "SET"
+	LedgerEntryReserve LedgerEntryType = "RESERVE"
+	LedgerEntryRelease LedgerEntryType = "RELEASE"
+	LedgerEntryConsume LedgerEntryType = "CONSUME"
+	LedgerEntryAdjust  LedgerEntryType = "ADJUST"
+)
+
+// LedgerEntry is an append-only record of a stock movement.
+// A SET entry resets the available quantity to its quantity; every other entry
+// changes the available quantity by its delta. Replaying the entries of a product
+// (or variant) after its latest SET yields the available quantity held in Redis.
+type LedgerEntry struct {
+	id            string
+	productID     ProductID
+	variantID     VariantID
+	entryType     LedgerEntryType
+	quantity      int
+	delta         int
+	reservationID string
+	occurredAt    time.Time
+}
+
+// NewSetLedgerEntry records stock being set to an absolute quantity
+func NewSetLedgerEntry(productID ProductID, variantID VariantID, quantity int) *LedgerEntry {
+	return newLedgerEntry(productID, variantID, LedgerEntrySet, quantity, 0, "")
+}
+
+// NewReserveLedgerEntry records stock held by a reservation
+func NewReserveLedgerEntry(productID ProductID, variantID VariantID, reservationID string, quantity int) *LedgerEntry {
+	return newLedgerEntry(productID, variantID, LedgerEntryReserve, quantity, -quantity, reservationID)
+}
+
+// NewReleaseLedgerEntry records reserved stock returned to availability
+func NewReleaseLedgerEntry(productID ProductID, variantID VariantID, reservationID string, quantity int) *LedgerEntry {
+	return newLedgerEntry(productID, variantID, LedgerEntryRelease, quantity, quantity, reservationID)
+}
+
+// NewConsumeLedgerEntry records reserved stock being sold.
+// Consumed stock was already deducted when it was reserved, so availability is unchanged.
+func NewConsumeLedgerEntry(productID ProductID, variantID VariantID, reservationID string, quantity int) *LedgerEntry {
+	return newLedgerEntry(productID, variantID, LedgerEntryConsume, quantity, 0, reservationID)
+}
+
+func newLedgerEntry(productID ProductID, variantID VariantID, entryType LedgerEntryType, quantity, delta int, reservationID string) *LedgerEntry {
+	return &LedgerEntry{
+		id:            uuidv7.New().String(),
+		productID:     productID,
+		variantID:     variantID,
+		entryType:     entryType,
+		quantity:      quantity,
+		delta:         delta,
+		reservationID: reservationID,
+		occurredAt:    time.Now(),
+	}
+}
+
+// Getters
+func (e *LedgerEntry) ID() string {
+	return e.id
+}
+
+func (e *LedgerEntry) ProductID() ProductID {
+	return e.productID
+}
+
+func (e *LedgerEntry) VariantID() VariantID {
+	return e.variantID
+}
+
+func (e *LedgerEntry) EntryType() LedgerEntryType {
+	return e.entryType
+}
+
+func (e *LedgerEntry) Quantity() int {
+	return e.quantity
+}
+
+func (e *LedgerEntry) Delta() int {
+	return e.delta
+}
+
+func (e *LedgerEntry) ReservationID() string {
+	return e.reservationID
+}
+
+func (e *LedgerEntry) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// LedgerRepository defines the interface for the durable stock ledger
+type LedgerRepository interface {
+	// Append appends entries to the ledger
+	Append(ctx context.Context, entries ...*LedgerEntry) error
+
+	// FindAllBalances replays the ledger into the current stock of every product and variant
+	FindAllBalances(ctx context.Context) ([]*Stock, error)
+}
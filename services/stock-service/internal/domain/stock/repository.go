@@ -2,22 +2,28 @@ package stock
 
 import "context"
 
-// Repository defines the interface for stock persistence
+// Repository defines the interface for stock persistence.
+// Each product without variants, and each variant, has its own stock; an empty
+// variantID selects the product's own stock.
 type Repository interface {
 	// Save saves stock to Redis
 	Save(ctx context.Context, stock *Stock) error
 
-	// FindByProductID finds stock by product ID
-	FindByProductID(ctx context.Context, productID ProductID) (*Stock, error)
+	// FindByProductID finds the stock of a product or one of its variants
+	FindByProductID(ctx context.Context, productID ProductID, variantID VariantID) (*Stock, error)
 
-	// Exists checks if stock exists for a product
-	Exists(ctx context.Context, productID ProductID) (bool, error)
+	// Exists checks if stock exists for a product or one of its variants
+	Exists(ctx context.Context, productID ProductID, variantID VariantID) (bool, error)
 
 	// Reserve reserves stock atomically (Lua script)
 	// Returns new quantity after deduction
-	Reserve(ctx context.Context, productID ProductID, quantity int) (newQuantity int, err error)
+	Reserve(ctx context.Context, productID ProductID, variantID VariantID, quantity int) (newQuantity int, err error)
 
 	// Release releases reserved stock
 	// Returns new quantity after addition
-	Release(ctx context.Context, productID ProductID, quantity int) (newQuantity int, err error)
+	Release(ctx context.Context, productID ProductID, variantID VariantID, quantity int) (newQuantity int, err error)
+
+	// Adjust applies a signed seller adjustment to the live counter atomically (Lua script)
+	// Returns new quantity after the adjustment
+	Adjust(ctx context.Context, productID ProductID, variantID VariantID, delta int) (newQuantity int, err error)
 }
@@ -12,18 +12,19 @@ const (
 	MaxDeductQuantity = 10
 )
 
-// Stock represents the inventory for a product
+// Stock represents the inventory for a product, or for one of its variants
 type Stock struct {
 	productID         ProductID
-	quantity          int     // current available quantity
-	initialQuantity   int     // initial quantity (for low stock calculation)
-	lowStockThreshold float64 // percentage threshold
+	variantID         VariantID // empty for the stock of a product without variants
+	quantity          int       // current available quantity
+	initialQuantity   int       // initial quantity (for low stock calculation)
+	lowStockThreshold float64   // percentage threshold
 	updatedAt         time.Time
 	domainEvents      []DomainEvent
 }
 
 // NewStock creates a new stock
-func NewStock(productID ProductID, quantity int) (*Stock, error) {
+func NewStock(productID ProductID, variantID VariantID, quantity int) (*Stock, error) {
 	if productID.IsEmpty() {
 		return nil, ErrProductIDRequired
 	}
@@ -33,6 +34,7 @@ func NewStock(productID ProductID, quantity int) (*Stock, error) {
 
 	return &Stock{
 		productID:         productID,
+		variantID:         variantID,
 		quantity:          quantity,
 		initialQuantity:   quantity,
 		lowStockThreshold: LowStockThresholdPercentage,
@@ -43,12 +45,14 @@ func NewStock(productID ProductID, quantity int) (*Stock, error) {
 // ReconstructStock reconstructs stock from persistence
 func ReconstructStock(
 	productID ProductID,
+	variantID VariantID,
 	quantity int,
 	initialQuantity int,
 	updatedAt time.Time,
 ) *Stock {
 	return &Stock{
 		productID:         productID,
+		variantID:         variantID,
 		quantity:          quantity,
 		initialQuantity:   initialQuantity,
 		lowStockThreshold: LowStockThresholdPercentage,
@@ -61,6 +65,10 @@ func (s *Stock) ProductID() ProductID {
 	return s.productID
 }
 
+func (s *Stock) VariantID() VariantID {
+	return s.variantID
+}
+
 func (s *Stock) Quantity() int {
 	return s.quantity
 }
@@ -0,0 +1,260 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
+	"github.com/segmentio/kafka-go"
+)
+
+// ErrDeadLetterNotFound is returned when no dead letter exists at the given offset
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// Dead-letter headers, added next to the original message headers
+const (
+	dlqHeaderPrefix            = "dlq_"
+	dlqHeaderOriginalTopic     = "dlq_original_topic"
+	dlqHeaderOriginalPartition = "dlq_original_partition"
+	dlqHeaderOriginalOffset    = "dlq_original_offset"
+	dlqHeaderConsumerGroup     = "dlq_consumer_group"
+	dlqHeaderError             = "dlq_error"
+	dlqHeaderAttempts          = "dlq_attempts"
+	dlqHeaderFailedAt          = "dlq_failed_at"
+)
+
+// DeadLetter is a consumed message that could not be handled
+type DeadLetter struct {
+	Offset            int64
+	OriginalTopic     string
+	OriginalPartition int
+	OriginalOffset    int64
+	ConsumerGroup     string
+	EventID           string
+	EventType         string
+	Key               []byte
+	Value             []byte
+	Error             string
+	Attempts          int
+	FailedAt          time.Time
+}
+
+// DeadLetterQueue publishes failed messages to the service's dead-letter topic,
+// lists them and replays them into their original topic.
+// Every dead letter is written to partition 0 so its offset alone identifies it.
+type DeadLetterQueue struct {
+	brokers      []string
+	topic        string
+	writer       *kafka.Writer
+	replayWriter *kafka.Writer
+}
+
+// NewDeadLetterQueue creates a new DeadLetterQueue
+func NewDeadLetterQueue(cfg *config.KafkaConfig) *DeadLetterQueue {
+	return &DeadLetterQueue{
+		brokers: cfg.Brokers,
+		topic:   cfg.DLQTopic,
+		writer: &kafka.Writer{
+			Addr:  kafka.TCP(cfg.Brokers...),
+			Topic: cfg.DLQTopic,
+			Balancer: kafka.BalancerFunc(func(_ kafka.Message, partitions ...int) int {
+				return partitions[0]
+			}),
+			MaxAttempts:            cfg.ProducerMaxAttempts,
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+		// Topic is set per message when replaying
+		replayWriter: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Balancer:     &kafka.Hash{},
+			MaxAttempts:  cfg.ProducerMaxAttempts,
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+// Publish moves a failed message to the dead-letter topic
+func (q *DeadLetterQueue) Publish(ctx context.Context, msg kafka.Message, groupID string, attempts int, cause error) error {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+7)
+	headers = append(headers, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: dlqHeaderOriginalTopic, Value: []byte(msg.Topic)},
+		kafka.Header{Key: dlqHeaderOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: dlqHeaderOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: dlqHeaderConsumerGroup, Value: []byte(groupID)},
+		kafka.Header{Key: dlqHeaderError, Value: []byte(cause.Error())},
+		kafka.Header{Key: dlqHeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: dlqHeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))},
+	)
+
+	if err := q.writer.WriteMessages(ctx, kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}); err != nil {
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// List returns up to limit dead letters starting at fromOffset, and the offset to continue from
+func (q *DeadLetterQueue) List(ctx context.Context, fromOffset int64, limit int) ([]*DeadLetter, int64, error) {
+	first, last, err := q.offsets(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Older dead letters may have been removed by retention
+	fromOffset = max(fromOffset, first)
+	if fromOffset >= last {
+		return []*DeadLetter{}, fromOffset, nil
+	}
+
+	reader := q.newReader()
+	defer reader.Close()
+
+	if err := reader.SetOffset(fromOffset); err != nil {
+		return nil, 0, fmt.Errorf("failed to seek dead-letter topic: %w", err)
+	}
+
+	deadLetters := make([]*DeadLetter, 0, min(int64(limit), last-fromOffset))
+	next := fromOffset
+	for len(deadLetters) < limit && next < last {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read dead letter: %w", err)
+		}
+		deadLetters = append(deadLetters, toDeadLetter(msg))
+		next = msg.Offset + 1
+	}
+
+	return deadLetters, next, nil
+}
+
+// Replay republishes the dead letter at offset into its original topic.
+// The dead letter itself stays in the topic.
+func (q *DeadLetterQueue) Replay(ctx context.Context, offset int64) (*DeadLetter, error) {
+	first, last, err := q.offsets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if offset < first || offset >= last {
+		return nil, ErrDeadLetterNotFound
+	}
+
+	reader := q.newReader()
+	defer reader.Close()
+
+	if err := reader.SetOffset(offset); err != nil {
+		return nil, fmt.Errorf("failed to seek dead-letter topic: %w", err)
+	}
+
+	msg, err := reader.ReadMessage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead letter: %w", err)
+	}
+
+	deadLetter := toDeadLetter(msg)
+	if deadLetter.OriginalTopic == "" {
+		return nil, fmt.Errorf("dead letter at offset %d has no original topic", offset)
+	}
+
+	// Drop the dead-letter headers so the replayed message looks like the original
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for _, h := range msg.Headers {
+		if !strings.HasPrefix(h.Key, dlqHeaderPrefix) {
+			headers = append(headers, h)
+		}
+	}
+
+	if err := q.replayWriter.WriteMessages(ctx, kafka.Message{
+		Topic:   deadLetter.OriginalTopic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to replay dead letter: %w", err)
+	}
+
+	return deadLetter, nil
+}
+
+// Close closes the dead-letter writers
+func (q *DeadLetterQueue) Close() error {
+	if err := q.writer.Close(); err != nil {
+		return fmt.Errorf("failed to close dead-letter writer: %w", err)
+	}
+	if err := q.replayWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close replay writer: %w", err)
+	}
+	return nil
+}
+
+// offsets returns the first and next offsets of the dead-letter partition
+func (q *DeadLetterQueue) offsets(ctx context.Context) (int64, int64, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", q.brokers[0], q.topic, 0)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to connect to dead-letter topic: %w", err)
+	}
+	defer conn.Close()
+
+	first, last, err := conn.ReadOffsets()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read dead-letter offsets: %w", err)
+	}
+
+	return first, last, nil
+}
+
+func (q *DeadLetterQueue) newReader() *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   q.brokers,
+		Topic:     q.topic,
+		Partition: 0,
+		MaxBytes:  10e6,
+	})
+}
+
+func toDeadLetter(msg kafka.Message) *DeadLetter {
+	deadLetter := &DeadLetter{
+		Offset: msg.Offset,
+		Key:    msg.Key,
+		Value:  msg.Value,
+	}
+
+	for _, h := range msg.Headers {
+		value := string(h.Value)
+		switch h.Key {
+		case dlqHeaderOriginalTopic:
+			deadLetter.OriginalTopic = value
+		case dlqHeaderOriginalPartition:
+			deadLetter.OriginalPartition, _ = strconv.Atoi(value)
+		case dlqHeaderOriginalOffset:
+			deadLetter.OriginalOffset, _ = strconv.ParseInt(value, 10, 64)
+		case dlqHeaderConsumerGroup:
+			deadLetter.ConsumerGroup = value
+		case dlqHeaderError:
+			deadLetter.Error = value
+		case dlqHeaderAttempts:
+			deadLetter.Attempts, _ = strconv.Atoi(value)
+		case dlqHeaderFailedAt:
+			deadLetter.FailedAt, _ = time.Parse(time.RFC3339Nano, value)
+		}
+	}
+
+	// Best effort: the payload may be the malformed message that was dead-lettered
+	var eventMsg EventMessage
+	if err := json.Unmarshal(msg.Value, &eventMsg); err == nil {
+		deadLetter.EventID = eventMsg.EventID
+		deadLetter.EventType = eventMsg.EventType
+	}
+
+	return deadLetter
+}
@@ -26,6 +26,8 @@ func (h *OrderEventHandler) Handle(ctx context.Context, msg *EventMessage) error
 	switch msg.EventType {
 	case "order.cancelled":
 		return h.handleOrderCancelled(ctx, msg)
+	case "order.paid":
+		return h.handleOrderPaid(ctx, msg)
 	default:
 		logger.DebugContext(ctx, "unknown order event type",
 			zap.String("event_type", msg.EventType),
@@ -68,3 +70,41 @@ func (h *OrderEventHandler) handleOrderCancelled(ctx context.Context, msg *Event
 
 	return nil
 }
+
+// handleOrderPaid handles order.paid event
+func (h *OrderEventHandler) handleOrderPaid(ctx context.Context, msg *EventMessage) error {
+	reservationID, ok := msg.Data["reservation_id"].(string)
+	if !ok {
+		logger.ErrorContext(ctx, "missing or invalid reservation_id in order.paid event",
+			zap.String("event_id", msg.EventID),
+		)
+		return fmt.Errorf("missing or invalid reservation_id in event data")
+	}
+
+	orderID, ok := msg.Data["order_id"].(string)
+	if !ok {
+		logger.ErrorContext(ctx, "missing or invalid order_id in order.paid event",
+			zap.String("event_id", msg.EventID),
+		)
+		return fmt.Errorf("missing or invalid order_id in event data")
+	}
+
+	logger.InfoContext(ctx, "handling order.paid event",
+		zap.String("reservation_id", reservationID),
+		zap.String("order_id", orderID),
+		zap.String("event_id", msg.EventID),
+	)
+
+	// Consume reservation (stock is sold, stop tracking its expiry)
+	if err := h.stockService.Consume(ctx, reservationID, orderID); err != nil {
+		logger.ErrorContext(ctx, "failed to consume reservation",
+			zap.String("reservation_id", reservationID),
+			zap.String("order_id", orderID),
+			zap.String("event_id", msg.EventID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to consume reservation: %w", err)
+	}
+
+	return nil
+}
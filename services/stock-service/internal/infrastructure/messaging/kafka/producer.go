@@ -7,8 +7,12 @@ import (
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -46,8 +50,15 @@ func NewProducer(cfg *config.KafkaConfig) *Producer {
 	}
 }
 
-// Publish publishes an event message to Kafka
+// Publish publishes an event message to Kafka.
+// The trace context of ctx is carried in the message headers.
 func (p *Producer) Publish(ctx context.Context, msg *EventMessage) error {
+	ctx, span := tracing.Tracer().Start(ctx, p.topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(messagingAttributes(p.topic, msg)...),
+	)
+	defer span.End()
+
 	logger.DebugContext(ctx, "publishing kafka message",
 		zap.String("topic", p.topic),
 		zap.String("event_type", msg.EventType),
@@ -72,8 +83,11 @@ func (p *Producer) Publish(ctx context.Context, msg *EventMessage) error {
 		},
 		Time: msg.OccurredAt,
 	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{headers: &kafkaMsg.Headers})
 
 	if err := p.writer.WriteMessages(ctx, kafkaMsg); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "kafka publish failed")
 		logger.ErrorContext(ctx, "kafka publish failed",
 			zap.String("topic", p.topic),
 			zap.String("event_type", msg.EventType),
@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"context"
+	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/persistence/redis"
 	"go.uber.org/zap"
@@ -32,6 +33,9 @@ func (h *ProductEventHandler) Handle(ctx context.Context, msg *EventMessage) err
 	case "product.published":
 		return h.handleProductPublished(ctx, msg)
 
+	case "product.price_updated":
+		return h.handleProductPriceUpdated(ctx, msg)
+
 	case "product.deactivated":
 		return h.handleProductDeactivated(ctx, msg)
 
@@ -39,7 +43,7 @@ func (h *ProductEventHandler) Handle(ctx context.Context, msg *EventMessage) err
 		return h.handleProductDeleted(ctx, msg)
 
 	default:
-		// Ignore other product events (info.updated, pricing.updated, etc.)
+		// Ignore other product events (info.updated, etc.)
 		zap.L().Debug("ignoring non-lifecycle event",
 			zap.String("event_type", msg.EventType),
 		)
@@ -61,7 +65,8 @@ func (h *ProductEventHandler) handleProductPublished(ctx context.Context, msg *E
 		zap.String("event_id", msg.EventID),
 	)
 
-	if err := h.productStateRepo.MarkActive(ctx, productID); err != nil {
+	err := h.productStateRepo.MarkActive(ctx, productID)
+	if err != nil {
 		zap.L().Error("failed to mark product as active",
 			zap.String("product_id", productID),
 			zap.Error(err),
@@ -69,8 +74,119 @@ func (h *ProductEventHandler) handleProductPublished(ctx context.Context, msg *E
 		return err
 	}
 
+	// Auction products can only be sold through bidding
+	priceType, _ := msg.Data["price_type"].(string)
+	if priceType == "AUCTION" {
+		err = h.productStateRepo.MarkAuction(ctx, productID)
+	} else {
+		err = h.productStateRepo.UnmarkAuction(ctx, productID)
+	}
+	if err != nil {
+		zap.L().Error("failed to update product price type",
+			zap.String("product_id", productID),
+			zap.String("price_type", priceType),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// Unit price reservations are quoted at, auction products are priced by bids
+	amount, currency := EventPrice(msg.Data)
+	if priceType == "AUCTION" {
+		amount, currency = 0, ""
+	}
+	if err := h.productStateRepo.SetPrice(ctx, productID, amount, currency); err != nil {
+		zap.L().Error("failed to update product price",
+			zap.String("product_id", productID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// Variants each have their own stock counter, reservations must select one of them
+	variants := EventVariants(msg.Data)
+	if err := h.productStateRepo.SetVariants(ctx, productID, variants); err != nil {
+		zap.L().Error("failed to update product variants",
+			zap.String("product_id", productID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// Per-user purchase limit, absent or 0 means unlimited
+	purchaseLimit, _ := msg.Data["purchase_limit"].(float64)
+	if err := h.productStateRepo.SetPurchaseLimit(ctx, productID, int(purchaseLimit)); err != nil {
+		zap.L().Error("failed to update product purchase limit",
+			zap.String("product_id", productID),
+			zap.Int("purchase_limit", int(purchaseLimit)),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// Scheduled sale window, absent bounds are open
+	startsAt, err := ParseEventTime(msg.Data, "sale_starts_at")
+	if err != nil {
+		zap.L().Error("invalid sale_starts_at in event data",
+			zap.String("event_id", msg.EventID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	endsAt, err := ParseEventTime(msg.Data, "sale_ends_at")
+	if err != nil {
+		zap.L().Error("invalid sale_ends_at in event data",
+			zap.String("event_id", msg.EventID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if err := h.productStateRepo.SetSaleWindow(ctx, productID, startsAt, endsAt); err != nil {
+		zap.L().Error("failed to update product sale window",
+			zap.String("product_id", productID),
+			zap.Error(err),
+		)
+		return err
+	}
+
 	zap.L().Info("product marked as active",
 		zap.String("product_id", productID),
+		zap.String("price_type", priceType),
+		zap.Int("purchase_limit", int(purchaseLimit)),
+		zap.Int("variants", len(variants)),
+	)
+
+	return nil
+}
+
+// handleProductPriceUpdated stores the new price; pricing only changes while a product is
+// off sale, so it applies from the next publish on
+func (h *ProductEventHandler) handleProductPriceUpdated(ctx context.Context, msg *EventMessage) error {
+	productID, ok := msg.Data["product_id"].(string)
+	if !ok {
+		zap.L().Error("missing product_id in event data",
+			zap.String("event_id", msg.EventID),
+		)
+		return nil
+	}
+
+	if priceType, _ := msg.Data["price_type"].(string); priceType == "AUCTION" {
+		return nil
+	}
+
+	amount, currency := EventPrice(msg.Data)
+	if err := h.productStateRepo.SetPrice(ctx, productID, amount, currency); err != nil {
+		zap.L().Error("failed to update product price",
+			zap.String("product_id", productID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	zap.L().Info("product price updated",
+		zap.String("product_id", productID),
+		zap.Int64("price", amount),
+		zap.String("currency", currency),
 	)
 
 	return nil
@@ -98,6 +214,15 @@ func (h *ProductEventHandler) handleProductDeactivated(ctx context.Context, msg
 		return err
 	}
 
+	// Deactivation ends any scheduled sale
+	if err := h.productStateRepo.SetSaleWindow(ctx, productID, nil, nil); err != nil {
+		zap.L().Error("failed to clear product sale window",
+			zap.String("product_id", productID),
+			zap.Error(err),
+		)
+		return err
+	}
+
 	zap.L().Info("product marked as inactive",
 		zap.String("product_id", productID),
 	)
@@ -133,3 +258,47 @@ func (h *ProductEventHandler) handleProductDeleted(ctx context.Context, msg *Eve
 
 	return nil
 }
+
+// EventPrice reads the current unit price of a product event, zero when absent
+func EventPrice(data map[string]interface{}) (int64, string) {
+	amount, _ := data["price"].(float64) // JSON numbers are float64
+	currency, _ := data["currency"].(string)
+	return int64(amount), currency
+}
+
+// EventVariants reads the variants of a product.published event, nil for a product without variants.
+// A variant without a price override has no price in the event.
+func EventVariants(data map[string]interface{}) []redis.ProductVariant {
+	raw, ok := data["variants"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	variants := make([]redis.ProductVariant, 0, len(raw))
+	for _, item := range raw {
+		v, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := v["variant_id"].(string)
+		if id == "" {
+			continue
+		}
+		amount, currency := EventPrice(v)
+		variants = append(variants, redis.ProductVariant{ID: id, Amount: amount, Currency: currency})
+	}
+	return variants
+}
+
+// ParseEventTime reads an optional RFC3339 timestamp from event data
+func ParseEventTime(data map[string]interface{}, key string) (*time.Time, error) {
+	raw, ok := data[key].(string)
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -2,20 +2,31 @@ package outbox
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/tracing"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/messaging/kafka"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/persistence/postgres"
+	"github.com/samborkent/uuidv7"
 	"go.uber.org/zap"
 )
 
-// OutboxRelay is responsible for relaying outbox events to Kafka
+// backlogSampleInterval is how often the relay samples the outbox backlog for metrics
+const backlogSampleInterval = 15 * time.Second
+
+// OutboxRelay is responsible for relaying outbox events to Kafka.
+// Several relays can run side by side: each batch is claimed under relayID
+// for the configured lease, so an event is relayed by one relay only.
 type OutboxRelay struct {
 	outboxRepo *postgres.OutboxRepository
 	producer   *kafka.Producer
 	config     *config.OutboxConfig
+	relayID    string
 }
 
 // NewOutboxRelay creates a new OutboxRelay
@@ -28,7 +39,17 @@ func NewOutboxRelay(
 		outboxRepo: outboxRepo,
 		producer:   producer,
 		config:     cfg,
+		relayID:    newRelayID(),
+	}
+}
+
+// newRelayID identifies this relay instance in outbox claims
+func newRelayID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
 	}
+	return fmt.Sprintf("%s-%s", hostname, uuidv7.New().String())
 }
 
 // Start starts the outbox relay worker
@@ -36,9 +57,14 @@ func (r *OutboxRelay) Start(ctx context.Context) error {
 	ticker := time.NewTicker(r.config.PollInterval)
 	defer ticker.Stop()
 
+	backlogTicker := time.NewTicker(backlogSampleInterval)
+	defer backlogTicker.Stop()
+
 	zap.L().Info("outbox relay started",
+		zap.String("relay_id", r.relayID),
 		zap.Int("batch_size", r.config.BatchSize),
 		zap.Duration("poll_interval", r.config.PollInterval),
+		zap.Duration("claim_lease", r.config.ClaimLease),
 	)
 
 	for {
@@ -50,13 +76,42 @@ func (r *OutboxRelay) Start(ctx context.Context) error {
 				)
 			}
 
+		case <-backlogTicker.C:
+			r.sampleBacklog(ctx)
+
 		case <-ctx.Done():
+			r.releaseClaims()
 			zap.L().Info("outbox relay stopped")
 			return ctx.Err()
 		}
 	}
 }
 
+// sampleBacklog records how many events are waiting to be relayed and for how long
+func (r *OutboxRelay) sampleBacklog(ctx context.Context) {
+	count, oldestAge, err := r.outboxRepo.Backlog(ctx)
+	if err != nil {
+		zap.L().Warn("failed to sample outbox backlog",
+			zap.Error(err),
+		)
+		return
+	}
+	metrics.SetOutboxBacklog(count, oldestAge)
+}
+
+// releaseClaims hands unfinished claims back on shutdown instead of waiting for the lease to expire
+func (r *OutboxRelay) releaseClaims() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := r.outboxRepo.ReleaseClaims(ctx, r.relayID); err != nil {
+		zap.L().Warn("failed to release outbox claims",
+			zap.String("relay_id", r.relayID),
+			zap.Error(err),
+		)
+	}
+}
+
 // StartCleanup starts the cleanup worker
 func (r *OutboxRelay) StartCleanup(ctx context.Context) error {
 	ticker := time.NewTicker(r.config.CleanupPeriod)
@@ -85,11 +140,13 @@ func (r *OutboxRelay) StartCleanup(ctx context.Context) error {
 	}
 }
 
-// processOutbox processes pending outbox events
+// processOutbox claims and processes pending outbox events
 func (r *OutboxRelay) processOutbox(ctx context.Context) error {
-	events, err := r.outboxRepo.FindPending(ctx, r.config.BatchSize)
+	leaseExpiresAt := time.Now().Add(r.config.ClaimLease)
+
+	events, err := r.outboxRepo.ClaimPending(ctx, r.relayID, r.config.ClaimLease, r.config.BatchSize)
 	if err != nil {
-		return fmt.Errorf("failed to find pending events: %w", err)
+		return fmt.Errorf("failed to claim pending events: %w", err)
 	}
 
 	if len(events) == 0 {
@@ -102,13 +159,31 @@ func (r *OutboxRelay) processOutbox(ctx context.Context) error {
 
 	successCount := 0
 	for _, event := range events {
+		// Once the lease is over another relay may have claimed the rest of the batch
+		if time.Now().After(leaseExpiresAt) {
+			zap.L().Warn("outbox claim lease expired, leaving the rest of the batch",
+				zap.String("relay_id", r.relayID),
+				zap.Int("processed", successCount),
+				zap.Int("total", len(events)),
+			)
+			break
+		}
+
 		if err := r.processEvent(ctx, event); err != nil {
 			zap.L().Error("failed to process outbox event",
 				zap.String("event_id", event.EventID),
 				zap.String("event_type", event.EventType),
 				zap.Error(err),
 			)
-			if err := r.outboxRepo.IncrementRetry(ctx, event.ID, err.Error()); err != nil {
+			if err := r.outboxRepo.IncrementRetry(
+				ctx,
+				event.ID,
+				r.relayID,
+				err.Error(),
+				r.config.RetryBaseDelay,
+				r.config.RetryMaxDelay,
+				r.config.MaxRetries,
+			); err != nil {
 				zap.L().Error("failed to increment retry count",
 					zap.String("outbox_id", event.ID),
 					zap.Error(err),
@@ -117,7 +192,15 @@ func (r *OutboxRelay) processOutbox(ctx context.Context) error {
 			continue
 		}
 
-		if err := r.outboxRepo.MarkAsProcessed(ctx, event.ID); err != nil {
+		if err := r.outboxRepo.MarkAsProcessed(ctx, event.ID, r.relayID); err != nil {
+			if errors.Is(err, postgres.ErrOutboxClaimLost) {
+				// Already published, so another relay will publish a duplicate
+				zap.L().Warn("outbox event claim lost after publishing",
+					zap.String("outbox_id", event.ID),
+					zap.String("event_id", event.EventID),
+				)
+				continue
+			}
 			zap.L().Error("failed to mark outbox event as processed",
 				zap.String("outbox_id", event.ID),
 				zap.Error(err),
@@ -135,8 +218,10 @@ func (r *OutboxRelay) processOutbox(ctx context.Context) error {
 	return nil
 }
 
-// processEvent processes a single outbox event
+// processEvent processes a single outbox event, publishing it in the trace that produced it
 func (r *OutboxRelay) processEvent(ctx context.Context, event *postgres.OutboxEvent) error {
+	ctx = tracing.Extract(ctx, event.TraceContext)
+
 	kafkaMsg := &kafka.EventMessage{
 		EventID:     event.EventID,
 		EventType:   event.EventType,
@@ -145,7 +230,9 @@ func (r *OutboxRelay) processEvent(ctx context.Context, event *postgres.OutboxEv
 		Data:        event.Payload,
 	}
 
-	if err := r.producer.Publish(ctx, kafkaMsg); err != nil {
+	err := r.producer.Publish(ctx, kafkaMsg)
+	metrics.ObserveOutboxPublish(event.CreatedAt, err)
+	if err != nil {
 		return fmt.Errorf("failed to publish to kafka: %w", err)
 	}
 
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
+)
+
+// AuctionDomainToModel converts domain Auction to database model
+func AuctionDomainToModel(a *auction.Auction) *AuctionModel {
+	model := &AuctionModel{
+		ID:           a.ID().String(),
+		ProductID:    a.ProductID().String(),
+		SellerID:     a.SellerID().String(),
+		StartPrice:   a.StartPrice(),
+		MinIncrement: a.MinIncrement(),
+		ReservePrice: a.ReservePrice(),
+		Currency:     a.Currency(),
+		Quantity:     a.Quantity(),
+		Status:       string(a.Status()),
+		EndsAt:       a.EndsAt(),
+		HighestBid:   a.HighestBid(),
+		BidCount:     a.BidCount(),
+		CreatedAt:    a.CreatedAt(),
+		UpdatedAt:    a.UpdatedAt(),
+	}
+
+	if !a.HighestBidder().IsEmpty() {
+		model.HighestBidder = sql.NullString{String: a.HighestBidder().String(), Valid: true}
+	}
+	if reservationID := a.ReservationID(); reservationID != nil {
+		model.ReservationID = sql.NullString{String: *reservationID, Valid: true}
+	}
+	if closedAt := a.ClosedAt(); closedAt != nil {
+		model.ClosedAt = sql.NullTime{Time: *closedAt, Valid: true}
+	}
+
+	return model
+}
+
+// AuctionModelToDomain converts database model to domain Auction
+func AuctionModelToDomain(model *AuctionModel) (*auction.Auction, error) {
+	aid, err := auction.ParseAuctionID(model.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := auction.ParseProductID(model.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	sellerID, err := auction.ParseUserID(model.SellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reservationID *string
+	if model.ReservationID.Valid {
+		reservationID = &model.ReservationID.String
+	}
+
+	var closedAt *time.Time
+	if model.ClosedAt.Valid {
+		closedAt = &model.ClosedAt.Time
+	}
+
+	return auction.ReconstructAuction(
+		aid,
+		pid,
+		sellerID,
+		model.StartPrice,
+		model.MinIncrement,
+		model.ReservePrice,
+		model.Currency,
+		model.Quantity,
+		auction.AuctionStatus(model.Status),
+		model.EndsAt,
+		model.HighestBid,
+		auction.UserID(model.HighestBidder.String),
+		model.BidCount,
+		reservationID,
+		model.CreatedAt,
+		closedAt,
+		model.UpdatedAt,
+	), nil
+}
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const saveAuctionQuery = `
+	INSERT INTO auctions (
+		id, product_id, seller_id, start_price, min_increment,
+		reserve_price, currency, quantity, status, ends_at,
+		highest_bid, highest_bidder, bid_count, reservation_id,
+		created_at, closed_at, updated_at
+	) VALUES (
+		:id, :product_id, :seller_id, :start_price, :min_increment,
+		:reserve_price, :currency, :quantity, :status, :ends_at,
+		:highest_bid, :highest_bidder, :bid_count, :reservation_id,
+		:created_at, :closed_at, :updated_at
+	)
+	ON CONFLICT (id) DO UPDATE SET
+		status = EXCLUDED.status,
+		highest_bid = EXCLUDED.highest_bid,
+		highest_bidder = EXCLUDED.highest_bidder,
+		bid_count = EXCLUDED.bid_count,
+		reservation_id = EXCLUDED.reservation_id,
+		closed_at = EXCLUDED.closed_at,
+		updated_at = EXCLUDED.updated_at
+`
+
+// AuctionRepository implements auction persistence in PostgreSQL
+//
+//	ALTER TABLE auctions ADD COLUMN close_claimed_until TIMESTAMPTZ;
+type AuctionRepository struct {
+	db *sqlx.DB
+}
+
+var _ auction.Repository = (*AuctionRepository)(nil)
+
+// NewAuctionRepository creates a new AuctionRepository
+func NewAuctionRepository(db *sqlx.DB) *AuctionRepository {
+	return &AuctionRepository{db: db}
+}
+
+// Save inserts or updates an auction
+func (r *AuctionRepository) Save(ctx context.Context, a *auction.Auction) error {
+	model := AuctionDomainToModel(a)
+
+	if _, err := r.db.NamedExecContext(ctx, saveAuctionQuery, model); err != nil {
+		logger.ErrorContext(ctx, "failed to save auction to postgresql",
+			zap.String("auction_id", a.ID().String()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to save auction: %w", err)
+	}
+
+	return nil
+}
+
+// SaveWithEvents saves the auction with its stock ledger entries and outbox events in one transaction
+func (r *AuctionRepository) SaveWithEvents(
+	ctx context.Context,
+	a *auction.Auction,
+	entries []*stock.LedgerEntry,
+	events []*OutboxEvent,
+) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NamedExecContext(ctx, saveAuctionQuery, AuctionDomainToModel(a)); err != nil {
+		logger.ErrorContext(ctx, "failed to save auction to postgresql",
+			zap.String("auction_id", a.ID().String()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to save auction: %w", err)
+	}
+
+	for _, entry := range entries {
+		if err := insertLedgerEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		if err := insertOutboxEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds an auction by ID
+func (r *AuctionRepository) FindByID(ctx context.Context, id auction.AuctionID) (*auction.Auction, error) {
+	query := `
+		SELECT id, product_id, seller_id, start_price, min_increment,
+			   reserve_price, currency, quantity, status, ends_at,
+			   highest_bid, highest_bidder, bid_count, reservation_id,
+			   created_at, closed_at, updated_at
+		FROM auctions
+		WHERE id = $1
+	`
+
+	var model AuctionModel
+	if err := r.db.GetContext(ctx, &model, query, id.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, auction.ErrAuctionNotFound
+		}
+
+		logger.ErrorContext(ctx, "database query failed",
+			zap.String("operation", "FindByID"),
+			zap.String("auction_id", id.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to find auction: %w", err)
+	}
+
+	return AuctionModelToDomain(&model)
+}
+
+// ClaimDueForClose claims open auctions whose end time has passed and that no other
+// replica holds. SKIP LOCKED keeps concurrent scans from waiting on, or claiming, each
+// other's rows.
+func (r *AuctionRepository) ClaimDueForClose(
+	ctx context.Context,
+	now time.Time,
+	claimedUntil time.Time,
+	limit int,
+) ([]*auction.Auction, error) {
+	query := `
+		UPDATE auctions
+		SET close_claimed_until = $2
+		WHERE id IN (
+			SELECT id
+			FROM auctions
+			WHERE status = 'OPEN'
+			  AND ends_at <= $1
+			  AND (close_claimed_until IS NULL OR close_claimed_until <= $1)
+			ORDER BY ends_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, product_id, seller_id, start_price, min_increment,
+			   reserve_price, currency, quantity, status, ends_at,
+			   highest_bid, highest_bidder, bid_count, reservation_id,
+			   created_at, closed_at, updated_at
+	`
+
+	var models []AuctionModel
+	if err := r.db.SelectContext(ctx, &models, query, now, claimedUntil, limit); err != nil {
+		logger.ErrorContext(ctx, "failed to claim due auctions",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to claim due auctions: %w", err)
+	}
+
+	auctions := make([]*auction.Auction, 0, len(models))
+	for _, model := range models {
+		a, err := AuctionModelToDomain(&model)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to convert model to domain",
+				zap.String("auction_id", model.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		auctions = append(auctions, a)
+	}
+
+	return auctions, nil
+}
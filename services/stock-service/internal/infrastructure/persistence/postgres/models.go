@@ -10,6 +10,7 @@ type ReservationModel struct {
 	ID            string         `db:"id"`
 	ReservationID string         `db:"reservation_id"`
 	ProductID     string         `db:"product_id"`
+	VariantID     string         `db:"variant_id"` // '' for products sold without variants
 	UserID        string         `db:"user_id"`
 	Quantity      int            `db:"quantity"`
 	Status        string         `db:"status"`
@@ -36,4 +37,67 @@ type OutboxEventModel struct {
 	RetryCount    int            `db:"retry_count"`
 	LastError     sql.NullString `db:"last_error"`
 	NextRetryAt   sql.NullTime   `db:"next_retry_at"`
+	TraceContext  []byte         `db:"trace_context"`
+}
+
+// AuctionModel represents the database model for auctions
+type AuctionModel struct {
+	ID            string         `db:"id"`
+	ProductID     string         `db:"product_id"`
+	SellerID      string         `db:"seller_id"`
+	StartPrice    int64          `db:"start_price"`
+	MinIncrement  int64          `db:"min_increment"`
+	ReservePrice  int64          `db:"reserve_price"`
+	Currency      string         `db:"currency"`
+	Quantity      int            `db:"quantity"`
+	Status        string         `db:"status"`
+	EndsAt        time.Time      `db:"ends_at"`
+	HighestBid    int64          `db:"highest_bid"`
+	HighestBidder sql.NullString `db:"highest_bidder"`
+	BidCount      int            `db:"bid_count"`
+	ReservationID sql.NullString `db:"reservation_id"`
+	CreatedAt     time.Time      `db:"created_at"`
+	ClosedAt      sql.NullTime   `db:"closed_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+// StockLedgerEntryModel represents the database model for stock ledger entries
+type StockLedgerEntryModel struct {
+	ID            string         `db:"id"`
+	ProductID     string         `db:"product_id"`
+	VariantID     string         `db:"variant_id"`
+	EntryType     string         `db:"entry_type"`
+	Quantity      int            `db:"quantity"`
+	Delta         int            `db:"delta"`
+	ReservationID sql.NullString `db:"reservation_id"`
+	OccurredAt    time.Time      `db:"occurred_at"`
+}
+
+// StockBalanceModel is a product's or variant's stock replayed from the ledger
+type StockBalanceModel struct {
+	ProductID       string    `db:"product_id"`
+	VariantID       string    `db:"variant_id"`
+	InitialQuantity int       `db:"initial_quantity"`
+	Available       int       `db:"available"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// StockAdjustmentModel represents the database model for stock adjustments
+type StockAdjustmentModel struct {
+	ID            string    `db:"id"`
+	ProductID     string    `db:"product_id"`
+	VariantID     string    `db:"variant_id"`
+	ActorID       string    `db:"actor_id"`
+	Delta         int       `db:"delta"`
+	Reason        string    `db:"reason"`
+	Note          string    `db:"note"`
+	QuantityAfter int       `db:"quantity_after"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// PurchaseTotalModel represents the units a user holds or bought of a product
+type PurchaseTotalModel struct {
+	ProductID string `db:"product_id"`
+	UserID    string `db:"user_id"`
+	Quantity  int    `db:"quantity"`
 }
@@ -23,6 +23,7 @@ type OutboxEvent struct {
 	RetryCount    int
 	LastError     *string
 	NextRetryAt   *time.Time
+	TraceContext  map[string]string // W3C trace context of the request that produced the event
 }
 
 // NewOutboxEvent creates a new OutboxEvent
@@ -73,6 +74,13 @@ func (e *OutboxEvent) toModel() (*OutboxEventModel, error) {
 	if e.NextRetryAt != nil {
 		model.NextRetryAt = sql.NullTime{Time: *e.NextRetryAt, Valid: true}
 	}
+	if len(e.TraceContext) > 0 {
+		traceContextJSON, err := json.Marshal(e.TraceContext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal trace context: %w", err)
+		}
+		model.TraceContext = traceContextJSON
+	}
 
 	return model, nil
 }
@@ -105,6 +113,11 @@ func fromModel(model *OutboxEventModel) (*OutboxEvent, error) {
 	if model.NextRetryAt.Valid {
 		event.NextRetryAt = &model.NextRetryAt.Time
 	}
+	if len(model.TraceContext) > 0 {
+		if err := json.Unmarshal(model.TraceContext, &event.TraceContext); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal trace context: %w", err)
+		}
+	}
 
 	return event, nil
 }
@@ -14,6 +14,7 @@ func DomainToModel(r *reservation.Reservation) *ReservationModel {
 		ID:            uuidv7.New().String(),
 		ReservationID: r.ID().String(),
 		ProductID:     r.ProductID().String(),
+		VariantID:     r.VariantID().String(),
 		UserID:        r.UserID().String(),
 		Quantity:      r.Quantity(),
 		Status:        string(r.Status()),
@@ -28,6 +29,16 @@ func DomainToModel(r *reservation.Reservation) *ReservationModel {
 		model.OrderID = sql.NullString{String: *orderID, Valid: true}
 	}
 
+	if consumedAt := r.ConsumedAt(); consumedAt != nil {
+		model.ConsumedAt = sql.NullTime{Time: *consumedAt, Valid: true}
+		model.UpdatedAt = *consumedAt
+	}
+
+	if releasedAt := r.ReleasedAt(); releasedAt != nil {
+		model.ReleasedAt = sql.NullTime{Time: *releasedAt, Valid: true}
+		model.UpdatedAt = *releasedAt
+	}
+
 	return model
 }
 
@@ -43,6 +54,11 @@ func ModelToDomain(model *ReservationModel) (*reservation.Reservation, error) {
 		return nil, err
 	}
 
+	vid, err := reservation.ParseVariantID(model.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
 	uid, err := reservation.ParseUserID(model.UserID)
 	if err != nil {
 		return nil, err
@@ -66,6 +82,7 @@ func ModelToDomain(model *ReservationModel) (*reservation.Reservation, error) {
 	return reservation.ReconstructReservation(
 		rid,
 		pid,
+		vid,
 		uid,
 		model.Quantity,
 		reservation.ReservationStatus(model.Status),
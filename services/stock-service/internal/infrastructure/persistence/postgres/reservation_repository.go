@@ -9,11 +9,14 @@ import (
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
 // ReservationRepository implements reservation persistence in PostgreSQL
+//
+//	ALTER TABLE stock_reservations ADD COLUMN variant_id TEXT NOT NULL DEFAULT '';
 type ReservationRepository struct {
 	db *sqlx.DB
 }
@@ -25,29 +28,58 @@ func NewReservationRepository(db *sqlx.DB) *ReservationRepository {
 	return &ReservationRepository{db: db}
 }
 
+// saveReservationQuery upserts a reservation. A finalized row is never overwritten,
+// so a late async persist of the RESERVED snapshot cannot undo a consume or release.
+const saveReservationQuery = `
+	INSERT INTO stock_reservations (
+		id, reservation_id, product_id, variant_id, user_id,
+		quantity, status, reserved_at, expired_at,
+		consumed_at, released_at, order_id, created_at, updated_at
+	) VALUES (
+		:id, :reservation_id, :product_id, :variant_id, :user_id,
+		:quantity, :status, :reserved_at, :expired_at,
+		:consumed_at, :released_at, :order_id, :created_at, :updated_at
+	)
+	ON CONFLICT (reservation_id) DO UPDATE SET
+		status = EXCLUDED.status,
+		consumed_at = EXCLUDED.consumed_at,
+		released_at = EXCLUDED.released_at,
+		order_id = EXCLUDED.order_id,
+		updated_at = EXCLUDED.updated_at
+	WHERE stock_reservations.status = 'RESERVED'
+`
+
 // SaveToPostgres saves reservation to PostgreSQL
 func (r *ReservationRepository) Save(ctx context.Context, res *reservation.Reservation) error {
 	model := DomainToModel(res)
 
-	query := `
-		INSERT INTO stock_reservations (
-			id, reservation_id, product_id, user_id,
-			quantity, status, reserved_at, expired_at,
-			consumed_at, released_at, order_id, created_at, updated_at
-		) VALUES (
-			:id, :reservation_id, :product_id, :user_id,
-			:quantity, :status, :reserved_at, :expired_at,
-			:consumed_at, :released_at, :order_id, :created_at, :updated_at
+	_, err := r.db.NamedExecContext(ctx, saveReservationQuery, model)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to save reservation to postgresql",
+			zap.String("reservation_id", res.ID().String()),
+			zap.Error(err),
 		)
-		ON CONFLICT (reservation_id) DO UPDATE SET
-			status = EXCLUDED.status,
-			consumed_at = EXCLUDED.consumed_at,
-			released_at = EXCLUDED.released_at,
-			order_id = EXCLUDED.order_id,
-			updated_at = EXCLUDED.updated_at
-	`
+		return fmt.Errorf("failed to save reservation: %w", err)
+	}
 
-	_, err := r.db.NamedExecContext(ctx, query, model)
+	return nil
+}
+
+// SaveWithEvents saves a reservation state transition with its stock ledger entries and outbox events in one transaction.
+// Returns ErrReservationFinalized if the stored reservation had already left RESERVED.
+func (r *ReservationRepository) SaveWithEvents(
+	ctx context.Context,
+	res *reservation.Reservation,
+	entries []*stock.LedgerEntry,
+	events []*OutboxEvent,
+) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.NamedExecContext(ctx, saveReservationQuery, DomainToModel(res))
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to save reservation to postgresql",
 			zap.String("reservation_id", res.ID().String()),
@@ -56,13 +88,37 @@ func (r *ReservationRepository) Save(ctx context.Context, res *reservation.Reser
 		return fmt.Errorf("failed to save reservation: %w", err)
 	}
 
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return reservation.ErrReservationFinalized
+	}
+
+	for _, entry := range entries {
+		if err := insertLedgerEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		if err := insertOutboxEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
 // FindByID finds reservation by ID from PostgreSQL
 func (r *ReservationRepository) FindByID(ctx context.Context, id reservation.ReservationID) (*reservation.Reservation, error) {
 	query := `
-		SELECT id, reservation_id, product_id, user_id,
+		SELECT id, reservation_id, product_id, variant_id, user_id,
 			   quantity, status, reserved_at, expired_at,
 			   consumed_at, released_at, order_id, created_at, updated_at
 		FROM stock_reservations
@@ -96,7 +152,7 @@ func (r *ReservationRepository) FindActiveByProductID(
 	productID reservation.ProductID,
 ) ([]*reservation.Reservation, error) {
 	query := `
-		SELECT id, reservation_id, product_id, user_id,
+		SELECT id, reservation_id, product_id, variant_id, user_id,
 			   quantity, status, reserved_at, expired_at,
 			   consumed_at, released_at, order_id, created_at, updated_at
 		FROM stock_reservations
@@ -167,7 +223,7 @@ func (r *ReservationRepository) FindAllActive(ctx context.Context) ([]*reservati
 	logger.InfoContext(ctx, "querying all active reservations from postgresql")
 
 	query := `
-		SELECT id, reservation_id, product_id, user_id,
+		SELECT id, reservation_id, product_id, variant_id, user_id,
 			   quantity, status, reserved_at, expired_at,
 			   consumed_at, released_at, order_id, created_at, updated_at
 		FROM stock_reservations
@@ -213,7 +269,7 @@ func (r *ReservationRepository) FindExpiredWithinWindow(
 	limit int,
 ) ([]*reservation.Reservation, error) {
 	query := `
-        SELECT id, reservation_id, product_id, user_id,
+        SELECT id, reservation_id, product_id, variant_id, user_id,
                quantity, status, reserved_at, expired_at,
                consumed_at, released_at, order_id, created_at, updated_at
         FROM stock_reservations
@@ -241,3 +297,32 @@ func (r *ReservationRepository) FindExpiredWithinWindow(
 
 	return reservations, nil
 }
+
+// FindPurchaseTotals sums the units each user holds or bought per product
+func (r *ReservationRepository) FindPurchaseTotals(ctx context.Context) ([]reservation.PurchaseTotal, error) {
+	query := `
+		SELECT product_id, user_id, SUM(quantity) AS quantity
+		FROM stock_reservations
+		WHERE status IN ('RESERVED', 'CONSUMED')
+		GROUP BY product_id, user_id
+	`
+
+	var models []PurchaseTotalModel
+	if err := r.db.SelectContext(ctx, &models, query); err != nil {
+		logger.ErrorContext(ctx, "failed to query purchase totals",
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to query purchase totals: %w", err)
+	}
+
+	totals := make([]reservation.PurchaseTotal, 0, len(models))
+	for _, m := range models {
+		totals = append(totals, reservation.PurchaseTotal{
+			ProductID: reservation.ProductID(m.ProductID),
+			UserID:    reservation.UserID(m.UserID),
+			Quantity:  m.Quantity,
+		})
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// StockAdjustmentRepository implements the stock adjustment audit trail in PostgreSQL
+//
+//	ALTER TABLE stock_adjustments ADD COLUMN variant_id TEXT NOT NULL DEFAULT '';
+type StockAdjustmentRepository struct {
+	db *sqlx.DB
+}
+
+var _ stock.AdjustmentRepository = (*StockAdjustmentRepository)(nil)
+
+// NewStockAdjustmentRepository creates a new StockAdjustmentRepository
+func NewStockAdjustmentRepository(db *sqlx.DB) *StockAdjustmentRepository {
+	return &StockAdjustmentRepository{db: db}
+}
+
+// SaveWithEvents saves an adjustment with its stock ledger entry and outbox events in one transaction
+func (r *StockAdjustmentRepository) SaveWithEvents(
+	ctx context.Context,
+	adj *stock.Adjustment,
+	events []*OutboxEvent,
+) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO stock_adjustments (
+			id, product_id, variant_id, actor_id, delta, reason, note, quantity_after, created_at
+		) VALUES (
+			:id, :product_id, :variant_id, :actor_id, :delta, :reason, :note, :quantity_after, :created_at
+		)
+	`
+
+	model := StockAdjustmentModel{
+		ID:            adj.ID(),
+		ProductID:     adj.ProductID().String(),
+		VariantID:     adj.VariantID().String(),
+		ActorID:       adj.ActorID(),
+		Delta:         adj.Delta(),
+		Reason:        string(adj.Reason()),
+		Note:          adj.Note(),
+		QuantityAfter: adj.QuantityAfter(),
+		CreatedAt:     adj.CreatedAt(),
+	}
+
+	if _, err := tx.NamedExecContext(ctx, query, model); err != nil {
+		logger.ErrorContext(ctx, "failed to save stock adjustment to postgresql",
+			zap.String("adjustment_id", adj.ID()),
+			zap.String("product_id", model.ProductID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to save stock adjustment: %w", err)
+	}
+
+	if err := insertLedgerEntry(ctx, tx, adj.LedgerEntry()); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := insertOutboxEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByProductID lists the adjustments of a product and its variants, newest first
+func (r *StockAdjustmentRepository) FindByProductID(
+	ctx context.Context,
+	productID stock.ProductID,
+	limit, offset int,
+) ([]*stock.Adjustment, error) {
+	query := `
+		SELECT id, product_id, variant_id, actor_id, delta, reason, note, quantity_after, created_at
+		FROM stock_adjustments
+		WHERE product_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var models []StockAdjustmentModel
+	if err := r.db.SelectContext(ctx, &models, query, productID.String(), limit, offset); err != nil {
+		logger.ErrorContext(ctx, "database query failed",
+			zap.String("operation", "FindByProductID"),
+			zap.String("product_id", productID.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to find stock adjustments: %w", err)
+	}
+
+	adjustments := make([]*stock.Adjustment, 0, len(models))
+	for _, m := range models {
+		adjustments = append(adjustments, stock.ReconstructAdjustment(
+			m.ID,
+			stock.ProductID(m.ProductID),
+			stock.VariantID(m.VariantID),
+			m.ActorID,
+			m.Delta,
+			stock.AdjustmentReason(m.Reason),
+			m.Note,
+			m.QuantityAfter,
+			m.CreatedAt,
+		))
+	}
+
+	return adjustments, nil
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// StockLedgerRepository implements the append-only stock ledger in PostgreSQL.
+// Every product without variants, and every variant, is a separate balance.
+//
+//	ALTER TABLE stock_ledger ADD COLUMN variant_id TEXT NOT NULL DEFAULT '';
+type StockLedgerRepository struct {
+	db *sqlx.DB
+}
+
+var _ stock.LedgerRepository = (*StockLedgerRepository)(nil)
+
+// NewStockLedgerRepository creates a new StockLedgerRepository
+func NewStockLedgerRepository(db *sqlx.DB) *StockLedgerRepository {
+	return &StockLedgerRepository{db: db}
+}
+
+// Append appends entries to the ledger
+func (r *StockLedgerRepository) Append(ctx context.Context, entries ...*stock.LedgerEntry) error {
+	return r.AppendWithEvents(ctx, entries, nil)
+}
+
+// AppendWithEvents appends ledger entries and their outbox events in one transaction
+func (r *StockLedgerRepository) AppendWithEvents(
+	ctx context.Context,
+	entries []*stock.LedgerEntry,
+	events []*OutboxEvent,
+) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, entry := range entries {
+		if err := insertLedgerEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		if err := insertOutboxEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindAllBalances replays the ledger into the current stock of every product and variant.
+// Available = latest SET quantity + sum of deltas appended after it; the low-stock
+// baseline is the SET quantity raised by every stock addition after it.
+func (r *StockLedgerRepository) FindAllBalances(ctx context.Context) ([]*stock.Stock, error) {
+	query := `
+		WITH last_set AS (
+			SELECT DISTINCT ON (product_id, variant_id) product_id, variant_id, seq, quantity, occurred_at
+			FROM stock_ledger
+			WHERE entry_type = 'SET'
+			ORDER BY product_id, variant_id, seq DESC
+		)
+		SELECT s.product_id, s.variant_id,
+			   s.quantity + COALESCE(SUM(l.delta) FILTER (WHERE l.entry_type = 'ADJUST' AND l.delta > 0), 0) AS initial_quantity,
+			   s.quantity + COALESCE(SUM(l.delta), 0) AS available,
+			   GREATEST(s.occurred_at, COALESCE(MAX(l.occurred_at), s.occurred_at)) AS updated_at
+		FROM last_set s
+		LEFT JOIN stock_ledger l
+			ON l.product_id = s.product_id AND l.variant_id = s.variant_id AND l.seq > s.seq
+		GROUP BY s.product_id, s.variant_id, s.quantity, s.occurred_at
+	`
+
+	var models []StockBalanceModel
+	if err := sqlx.SelectContext(ctx, r.db, &models, query); err != nil {
+		logger.ErrorContext(ctx, "database query failed",
+			zap.String("operation", "FindAllBalances"),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to replay stock ledger: %w", err)
+	}
+
+	stocks := make([]*stock.Stock, 0, len(models))
+	for _, m := range models {
+		pid, err := stock.ParseProductID(m.ProductID)
+		if err != nil {
+			logger.WarnContext(ctx, "skipping ledger balance with invalid product id",
+				zap.String("product_id", m.ProductID),
+				zap.Error(err),
+			)
+			continue
+		}
+		vid, err := stock.ParseVariantID(m.VariantID)
+		if err != nil {
+			logger.WarnContext(ctx, "skipping ledger balance with invalid variant id",
+				zap.String("product_id", m.ProductID),
+				zap.String("variant_id", m.VariantID),
+				zap.Error(err),
+			)
+			continue
+		}
+		stocks = append(stocks, stock.ReconstructStock(pid, vid, m.Available, m.InitialQuantity, m.UpdatedAt))
+	}
+
+	return stocks, nil
+}
+
+// insertLedgerEntry inserts a ledger entry using the given executor (db or tx)
+func insertLedgerEntry(ctx context.Context, exec sqlx.ExecerContext, entry *stock.LedgerEntry) error {
+	model := StockLedgerEntryModel{
+		ID:         entry.ID(),
+		ProductID:  entry.ProductID().String(),
+		VariantID:  entry.VariantID().String(),
+		EntryType:  string(entry.EntryType()),
+		Quantity:   entry.Quantity(),
+		Delta:      entry.Delta(),
+		OccurredAt: entry.OccurredAt(),
+	}
+	if entry.ReservationID() != "" {
+		model.ReservationID = sql.NullString{String: entry.ReservationID(), Valid: true}
+	}
+
+	// seq is a BIGSERIAL that orders entries per product for replay
+	query := `
+		INSERT INTO stock_ledger (
+			id, product_id, variant_id, entry_type, quantity, delta, reservation_id, occurred_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := exec.ExecContext(
+		ctx, query,
+		model.ID,
+		model.ProductID,
+		model.VariantID,
+		model.EntryType,
+		model.Quantity,
+		model.Delta,
+		model.ReservationID,
+		model.OccurredAt,
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to insert stock ledger entry",
+			zap.String("product_id", model.ProductID),
+			zap.String("variant_id", model.VariantID),
+			zap.String("entry_type", model.EntryType),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to insert stock ledger entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/admission"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// queuedProductsKey is a hash of product_id -> admission rate (users per second)
+	// for products whose waiting room is enabled
+	queuedProductsKey = "stock_service:queued_products"
+)
+
+// AdmissionQueueRepository manages product waiting rooms in Redis sorted sets.
+// Joining, polling and admitting run as Lua scripts so positions stay consistent under load.
+type AdmissionQueueRepository struct {
+	client *redis.Client
+}
+
+// NewAdmissionQueueRepository creates a new admission queue repository
+func NewAdmissionQueueRepository(client *redis.Client) *AdmissionQueueRepository {
+	return &AdmissionQueueRepository{
+		client: client,
+	}
+}
+
+// EnableQueue enables the waiting room of a product, or updates its admission rate
+func (r *AdmissionQueueRepository) EnableQueue(ctx context.Context, productID string, rate int) error {
+	return r.client.HSet(ctx, queuedProductsKey, productID, rate).Err()
+}
+
+// DisableQueue disables the waiting room of a product and drops its queue state
+func (r *AdmissionQueueRepository) DisableQueue(ctx context.Context, productID string) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, queuedProductsKey, productID)
+		pipe.Del(ctx,
+			queueWaitingKey(productID),
+			queueAdmittedKey(productID),
+			queueSequenceKey(productID),
+			queueLastAdmitKey(productID),
+		)
+		return nil
+	})
+	return err
+}
+
+// GetAdmissionRate returns the admission rate of a product (0 = waiting room disabled)
+func (r *AdmissionQueueRepository) GetAdmissionRate(ctx context.Context, productID string) (int, error) {
+	raw, err := r.client.HGet(ctx, queuedProductsKey, productID).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(raw)
+}
+
+// ListQueuedProducts returns the admission rate of every product with an enabled waiting room
+func (r *AdmissionQueueRepository) ListQueuedProducts(ctx context.Context) (map[string]int, error) {
+	raw, err := r.client.HGetAll(ctx, queuedProductsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]int, len(raw))
+	for productID, value := range raw {
+		rate, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		rates[productID] = rate
+	}
+
+	return rates, nil
+}
+
+// Waiting returns the number of users waiting to be admitted
+func (r *AdmissionQueueRepository) Waiting(ctx context.Context, productID string) (int64, error) {
+	return r.client.ZCard(ctx, queueWaitingKey(productID)).Result()
+}
+
+// Join puts the user in the waiting room (idempotent).
+// Returns the 1-based position, or the admission expiry when the user is already admitted.
+func (r *AdmissionQueueRepository) Join(
+	ctx context.Context,
+	productID string,
+	userID string,
+	now time.Time,
+) (int64, *time.Time, error) {
+	code, value, err := r.evalQueueScript(ctx, JoinQueueScript,
+		[]string{queueWaitingKey(productID), queueAdmittedKey(productID), queueSequenceKey(productID)},
+		userID,
+		now.Unix(),
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "join queue script failed",
+			zap.String("product_id", productID),
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return 0, nil, fmt.Errorf("failed to execute join queue script: %w", err)
+	}
+
+	if code == 1 {
+		admittedUntil := time.Unix(value, 0)
+		return 0, &admittedUntil, nil
+	}
+
+	return value, nil, nil
+}
+
+// Position returns the user's 1-based position, or the admission expiry when admitted.
+// Returns ErrNotInQueue when the user never joined or the admission lapsed.
+func (r *AdmissionQueueRepository) Position(
+	ctx context.Context,
+	productID string,
+	userID string,
+	now time.Time,
+) (int64, *time.Time, error) {
+	code, value, err := r.evalQueueScript(ctx, QueuePositionScript,
+		[]string{queueWaitingKey(productID), queueAdmittedKey(productID)},
+		userID,
+		now.Unix(),
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to execute queue position script: %w", err)
+	}
+
+	switch code {
+	case 1:
+		admittedUntil := time.Unix(value, 0)
+		return 0, &admittedUntil, nil
+	case 0:
+		return value, nil, nil
+	default:
+		return 0, nil, admission.ErrNotInQueue
+	}
+}
+
+// Admit admits the users at the front of the waiting room at the given per-second rate,
+// each until admittedUntil. Returns the number of users admitted.
+func (r *AdmissionQueueRepository) Admit(
+	ctx context.Context,
+	productID string,
+	rate int,
+	now time.Time,
+	admittedUntil time.Time,
+) (int, error) {
+	admitted, err := r.client.Eval(ctx, AdmitQueueScript,
+		[]string{queueWaitingKey(productID), queueAdmittedKey(productID), queueLastAdmitKey(productID)},
+		now.UnixMilli(),
+		rate,
+		admittedUntil.Unix(),
+		now.Unix(),
+	).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute admit queue script: %w", err)
+	}
+
+	return admitted, nil
+}
+
+// evalQueueScript runs a queue script returning {code, value}
+func (r *AdmissionQueueRepository) evalQueueScript(
+	ctx context.Context,
+	script string,
+	keys []string,
+	args ...interface{},
+) (int64, int64, error) {
+	result, err := r.client.Eval(ctx, script, keys, args...).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		return 0, 0, fmt.Errorf("invalid script result: %v", result)
+	}
+
+	code, ok1 := values[0].(int64)
+	value, ok2 := values[1].(int64)
+	if !ok1 || !ok2 {
+		return 0, 0, fmt.Errorf("failed to parse script result: %v", values)
+	}
+
+	return code, value, nil
+}
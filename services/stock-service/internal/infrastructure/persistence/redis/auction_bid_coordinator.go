@@ -0,0 +1,195 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// auctionStateRetention keeps bid state around after the end time for closing and reads
+	auctionStateRetention = 24 * time.Hour
+)
+
+// AuctionBidState is the live bid state of an auction held in Redis
+type AuctionBidState struct {
+	HighestBid    int64
+	HighestBidder string
+	BidCount      int
+}
+
+// AuctionBidCoordinator decides the highest bid atomically using Lua scripts.
+// Like StockReservationCoordinator, it exists to satisfy Redis atomicity constraints:
+// concurrent bids must be compared and applied in a single step.
+type AuctionBidCoordinator struct {
+	client *redis.Client
+}
+
+// NewAuctionBidCoordinator creates a new coordinator
+func NewAuctionBidCoordinator(client *redis.Client) *AuctionBidCoordinator {
+	return &AuctionBidCoordinator{
+		client: client,
+	}
+}
+
+// Create initializes bid state for a new auction
+func (c *AuctionBidCoordinator) Create(ctx context.Context, a *auction.Auction) error {
+	ttl := int(time.Until(a.EndsAt().Add(auctionStateRetention)).Seconds())
+
+	result, err := c.client.Eval(ctx, CreateAuctionScript,
+		[]string{auctionKey(a.ID()), auctionProductKey(a.ProductID())},
+		a.ID().String(),
+		a.SellerID().String(),
+		a.StartPrice(),
+		a.MinIncrement(),
+		a.EndsAt().UnixMilli(),
+		ttl,
+	).Int64()
+	if err != nil {
+		logger.ErrorContext(ctx, "lua script execution failed",
+			zap.String("auction_id", a.ID().String()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to execute create auction script: %w", err)
+	}
+
+	if result == 0 {
+		return auction.ErrAuctionAlreadyExists
+	}
+
+	return nil
+}
+
+// PlaceBid accepts the bid if it beats the current highest bid by the minimum increment.
+// Returns the bid count on success, or the minimum acceptable bid with ErrBidTooLow.
+func (c *AuctionBidCoordinator) PlaceBid(
+	ctx context.Context,
+	auctionID auction.AuctionID,
+	bidderID auction.UserID,
+	amount int64,
+) (int64, error) {
+	result, err := c.client.Eval(ctx, PlaceBidScript,
+		[]string{auctionKey(auctionID)},
+		bidderID.String(),
+		amount,
+		time.Now().UnixMilli(),
+	).Result()
+	if err != nil {
+		logger.ErrorContext(ctx, "lua script execution failed",
+			zap.String("auction_id", auctionID.String()),
+			zap.Error(err),
+		)
+		return 0, fmt.Errorf("failed to execute place bid script: %w", err)
+	}
+
+	// Parse result: {code, value}
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		logger.ErrorContext(ctx, "invalid lua script result",
+			zap.String("auction_id", auctionID.String()),
+			zap.Any("result", result),
+		)
+		return 0, fmt.Errorf("invalid script result")
+	}
+
+	code, ok1 := values[0].(int64)
+	value, ok2 := values[1].(int64)
+	if !ok1 || !ok2 {
+		logger.ErrorContext(ctx, "failed to parse lua script result",
+			zap.Any("values", values),
+		)
+		return 0, fmt.Errorf("failed to parse result")
+	}
+
+	switch code {
+	case 1:
+		return value, nil
+	case 0:
+		return value, auction.ErrBidTooLow
+	case -1:
+		return 0, auction.ErrAuctionNotFound
+	case -2:
+		return 0, auction.ErrAuctionNotOpen
+	case -3:
+		return 0, auction.ErrSellerCannotBid
+	default:
+		return 0, fmt.Errorf("unexpected script result code: %d", code)
+	}
+}
+
+// GetState reads the live bid state of an auction
+func (c *AuctionBidCoordinator) GetState(ctx context.Context, auctionID auction.AuctionID) (*AuctionBidState, error) {
+	values, err := c.client.HMGet(ctx, auctionKey(auctionID), "highest_bid", "highest_bidder", "bid_count").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auction state: %w", err)
+	}
+
+	if values[0] == nil {
+		return nil, auction.ErrAuctionNotFound
+	}
+
+	state := &AuctionBidState{}
+	if s, ok := values[0].(string); ok {
+		state.HighestBid, _ = strconv.ParseInt(s, 10, 64)
+	}
+	if s, ok := values[1].(string); ok {
+		state.HighestBidder = s
+	}
+	if s, ok := values[2].(string); ok {
+		state.BidCount, _ = strconv.Atoi(s)
+	}
+
+	return state, nil
+}
+
+// Close stops bidding and returns the final bid state
+func (c *AuctionBidCoordinator) Close(ctx context.Context, a *auction.Auction) (*AuctionBidState, error) {
+	result, err := c.client.Eval(ctx, CloseAuctionScript,
+		[]string{auctionKey(a.ID()), auctionProductKey(a.ProductID())},
+		a.ID().String(),
+	).Result()
+	if err != nil {
+		logger.ErrorContext(ctx, "lua script execution failed",
+			zap.String("auction_id", a.ID().String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to execute close auction script: %w", err)
+	}
+
+	// Parse result: {found, highest_bid, highest_bidder, bid_count}
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 4 {
+		logger.ErrorContext(ctx, "invalid lua script result",
+			zap.String("auction_id", a.ID().String()),
+			zap.Any("result", result),
+		)
+		return nil, fmt.Errorf("invalid script result")
+	}
+
+	found, ok1 := values[0].(int64)
+	highestBid, ok2 := values[1].(int64)
+	highestBidder, ok3 := values[2].(string)
+	bidCount, ok4 := values[3].(int64)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		logger.ErrorContext(ctx, "failed to parse lua script result",
+			zap.Any("values", values),
+		)
+		return nil, fmt.Errorf("failed to parse result")
+	}
+
+	if found == 0 {
+		return nil, auction.ErrAuctionNotFound
+	}
+
+	return &AuctionBidState{
+		HighestBid:    highestBid,
+		HighestBidder: highestBidder,
+		BidCount:      int(bidCount),
+	}, nil
+}
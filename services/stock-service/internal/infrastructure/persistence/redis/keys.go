@@ -3,20 +3,74 @@ package redis
 import (
 	"fmt"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
 )
 
-// stockKey generates Redis key for stock
-func stockKey(productID stock.ProductID) string {
-	return fmt.Sprintf("stock:product:%s", productID.String())
+// stockKey generates Redis key for the stock of a product, or of one of its variants
+func stockKey(productID stock.ProductID, variantID stock.VariantID) string {
+	if variantID.IsEmpty() {
+		return fmt.Sprintf("stock:product:%s", productID.String())
+	}
+	return fmt.Sprintf("stock:product:%s:variant:%s", productID.String(), variantID.String())
 }
 
 func reservationKey(id reservation.ReservationID) string {
 	return fmt.Sprintf("reservation:%s", id.String())
 }
 
+// reservationReturnedKey marks a reservation whose stock and purchase quota were given back
+func reservationReturnedKey(id reservation.ReservationID) string {
+	return reservationKey(id) + ":returned"
+}
+
 // stockMetadataKey generates Redis key for stock metadata
-func stockMetadataKey(productID stock.ProductID) string {
-	return fmt.Sprintf("stock:product:%s:meta", productID.String())
+func stockMetadataKey(productID stock.ProductID, variantID stock.VariantID) string {
+	return stockKey(productID, variantID) + ":meta"
+}
+
+// auctionKey generates Redis key for auction bid state
+func auctionKey(id auction.AuctionID) string {
+	return fmt.Sprintf("auction:%s", id.String())
+}
+
+// auctionProductKey generates Redis key holding the open auction of a product
+func auctionProductKey(productID auction.ProductID) string {
+	return fmt.Sprintf("auction:product:%s", productID.String())
+}
+
+// productVariantsKey generates Redis key for the variants of a product (variant_id -> price override)
+func productVariantsKey(productID string) string {
+	return fmt.Sprintf("stock_service:product:%s:variants", productID)
+}
+
+// purchaseQuotaKey generates Redis key counting the units a user holds or bought of a product
+func purchaseQuotaKey(productID stock.ProductID, userID reservation.UserID) string {
+	return fmt.Sprintf("purchase:product:%s:user:%s", productID.String(), userID.String())
+}
+
+// reserveIdempotencyKey generates Redis key recording the reservation made under a user's idempotency key
+func reserveIdempotencyKey(key *reservation.IdempotencyKey) string {
+	return fmt.Sprintf("idempotency:reserve:user:%s:%s", key.UserID().String(), key.Key())
+}
+
+// queueWaitingKey generates Redis key for the waiting room of a product (sorted by join order)
+func queueWaitingKey(productID string) string {
+	return fmt.Sprintf("queue:product:%s:waiting", productID)
+}
+
+// queueAdmittedKey generates Redis key for the admitted users of a product (scored by admission expiry)
+func queueAdmittedKey(productID string) string {
+	return fmt.Sprintf("queue:product:%s:admitted", productID)
+}
+
+// queueSequenceKey generates Redis key for the join sequence of a product's waiting room
+func queueSequenceKey(productID string) string {
+	return fmt.Sprintf("queue:product:%s:seq", productID)
+}
+
+// queueLastAdmitKey generates Redis key for the last admission time of a product's waiting room
+func queueLastAdmitKey(productID string) string {
+	return fmt.Sprintf("queue:product:%s:last_admit", productID)
 }
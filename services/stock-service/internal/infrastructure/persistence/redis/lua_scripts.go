@@ -1,10 +1,39 @@
 package redis
 
 const (
-	// ReserveStockScript is the Lua script for atomic stock reservation
+	// ReserveStockScript is the Lua script for atomic stock reservation from the stock counter
+	// KEYS[1] of a product or variant.
+	// KEYS[3] counts the units the user holds or bought of the product, across all of its
+	// variants, and is checked against the product's entry in the purchase limit hash KEYS[4]
+	// (field ARGV[4]).
+	// The optional KEYS[5] is the request's idempotency key: a replay with the same fingerprint
+	// (ARGV[5]) returns the original reservation ID, and a new reservation records its ID (ARGV[6])
+	// under the key for ARGV[7] seconds.
+	// Returns {0, current} insufficient stock, {-2, remaining_quota} purchase limit exceeded,
+	// {-3, 0} idempotency key reused with another fingerprint, {2, current, reservation_id} replay,
+	// {1, new_quantity} reserved
 	ReserveStockScript = `
 		local current = tonumber(redis.call('GET', KEYS[1]) or '0')
 		local quantity = tonumber(ARGV[1])
+
+		if KEYS[5] then
+			local recorded = redis.call('GET', KEYS[5])
+			if recorded then
+				local record = cjson.decode(recorded)
+				if record['fingerprint'] ~= ARGV[5] then
+					return {-3, 0}
+				end
+				return {2, current, record['reservation_id']}
+			end
+		end
+
+		local limit = tonumber(redis.call('HGET', KEYS[4], ARGV[4]) or '0')
+		if limit > 0 then
+			local used = tonumber(redis.call('GET', KEYS[3]) or '0')
+			if used + quantity > limit then
+				return {-2, math.max(limit - used, 0)}
+			end
+		end
 		
 		if current < quantity then
 			return {0, current}
@@ -12,20 +41,250 @@ const (
 		
 		local new_stock = redis.call('DECRBY', KEYS[1], quantity)
 		redis.call('SETEX', KEYS[2], tonumber(ARGV[3]), ARGV[2])
+		redis.call('INCRBY', KEYS[3], quantity)
+
+		if KEYS[5] then
+			redis.call('SET', KEYS[5], cjson.encode({reservation_id = ARGV[6], fingerprint = ARGV[5]}), 'EX', tonumber(ARGV[7]))
+		end
 		
 		return {1, new_stock}
 	`
 
-	// ReleaseStockScript is the Lua script for releasing reserved stock
+	// ReleaseStockScript is the Lua script for releasing reserved stock and giving back the user's
+	// purchase quota (KEYS[3]). The returned marker KEYS[4] is kept for ARGV[2] seconds so
+	// ReturnStockScript never returns the same reservation's stock again.
+	// Returns {0, 0} when the reservation is missing and {-1, 0} when it is no longer RESERVED,
+	// so that stock already sold through a consumed reservation is never returned
 	ReleaseStockScript = `
-		local reservation_exists = redis.call('EXISTS', KEYS[2])
-		if reservation_exists == 0 then
-			return {0, "reservation not found"}
+		local raw = redis.call('GET', KEYS[2])
+		if not raw then
+			return {0, 0}
+		end
+
+		if cjson.decode(raw)['status'] ~= 'RESERVED' then
+			return {-1, 0}
 		end
 		
 		redis.call('DEL', KEYS[2])
+		redis.call('SET', KEYS[4], 1, 'EX', tonumber(ARGV[2]))
 		local new_stock = redis.call('INCRBY', KEYS[1], tonumber(ARGV[1]))
+		if redis.call('DECRBY', KEYS[3], tonumber(ARGV[1])) <= 0 then
+			redis.call('DEL', KEYS[3])
+		end
 		
 		return {1, new_stock}
 	`
+
+	// ReturnStockScript returns the stock and purchase quota of a reservation whose hold key
+	// already expired, unless the reservation's returned marker KEYS[3] shows ReleaseStockScript
+	// or an earlier run already returned them. The marker is kept for ARGV[2] seconds.
+	// Returns {0, current} already returned, {1, new_quantity} returned
+	ReturnStockScript = `
+		if not redis.call('SET', KEYS[3], 1, 'NX', 'EX', tonumber(ARGV[2])) then
+			return {0, tonumber(redis.call('GET', KEYS[1]) or '0')}
+		end
+
+		local new_stock = redis.call('INCRBY', KEYS[1], tonumber(ARGV[1]))
+		if redis.call('DECRBY', KEYS[2], tonumber(ARGV[1])) <= 0 then
+			redis.call('DEL', KEYS[2])
+		end
+
+		return {1, new_stock}
+	`
+
+	// AdjustStockScript applies a signed adjustment to a stock counter without letting it go negative.
+	// Additions also raise the low-stock baseline in the metadata.
+	// Returns {-1, 0} stock not found, {0, current} insufficient stock, {1, new_quantity} applied
+	AdjustStockScript = `
+		local current = redis.call('GET', KEYS[1])
+		if not current then
+			return {-1, 0}
+		end
+
+		current = tonumber(current)
+		local delta = tonumber(ARGV[1])
+		if current + delta < 0 then
+			return {0, current}
+		end
+
+		local new_stock = redis.call('INCRBY', KEYS[1], delta)
+
+		local raw = redis.call('GET', KEYS[2])
+		if raw then
+			local meta = cjson.decode(raw)
+			if delta > 0 then
+				meta['initial_quantity'] = (tonumber(meta['initial_quantity']) or 0) + delta
+			end
+			meta['updated_at'] = tonumber(ARGV[2])
+			redis.call('SET', KEYS[2], cjson.encode(meta))
+		end
+
+		return {1, new_stock}
+	`
+
+	// ConsumeReservationScript marks a reservation CONSUMED and replaces its hold TTL with a retention TTL
+	// Returns 0 not found, -1 not RESERVED, 1 consumed, 2 already consumed
+	ConsumeReservationScript = `
+		local raw = redis.call('GET', KEYS[1])
+		if not raw then
+			return 0
+		end
+
+		local data = cjson.decode(raw)
+		if data['status'] == 'CONSUMED' then
+			return 2
+		end
+		if data['status'] ~= 'RESERVED' then
+			return -1
+		end
+
+		data['status'] = 'CONSUMED'
+		data['order_id'] = ARGV[1]
+		data['consumed_at'] = ARGV[2]
+		redis.call('SET', KEYS[1], cjson.encode(data), 'EX', tonumber(ARGV[3]))
+
+		return 1
+	`
+
+	// CreateAuctionScript initializes auction bid state, allowing one open auction per product
+	CreateAuctionScript = `
+		if not redis.call('SET', KEYS[2], ARGV[1], 'NX') then
+			return 0
+		end
+
+		redis.call('HSET', KEYS[1],
+			'status', 'OPEN',
+			'seller_id', ARGV[2],
+			'start_price', ARGV[3],
+			'min_increment', ARGV[4],
+			'ends_at', ARGV[5],
+			'highest_bid', 0,
+			'highest_bidder', '',
+			'bid_count', 0)
+		redis.call('EXPIRE', KEYS[1], tonumber(ARGV[6]))
+		redis.call('EXPIRE', KEYS[2], tonumber(ARGV[6]))
+
+		return 1
+	`
+
+	// PlaceBidScript is the Lua script for atomically accepting the highest bid
+	// Returns {code, value}:
+	//   -1 auction not found, -2 auction closed or ended, -3 seller bidding,
+	//    0 bid too low (value = minimum acceptable bid), 1 accepted (value = bid count)
+	PlaceBidScript = `
+		if redis.call('EXISTS', KEYS[1]) == 0 then
+			return {-1, 0}
+		end
+
+		local state = redis.call('HMGET', KEYS[1],
+			'status', 'ends_at', 'seller_id', 'start_price', 'min_increment', 'highest_bid', 'bid_count')
+
+		if state[1] ~= 'OPEN' or tonumber(ARGV[3]) >= tonumber(state[2]) then
+			return {-2, 0}
+		end
+		if state[3] == ARGV[1] then
+			return {-3, 0}
+		end
+
+		local amount = tonumber(ARGV[2])
+		local bid_count = tonumber(state[7] or '0')
+		local min_bid = tonumber(state[4])
+		if bid_count > 0 then
+			min_bid = tonumber(state[6]) + tonumber(state[5])
+		end
+
+		if amount < min_bid then
+			return {0, min_bid}
+		end
+
+		bid_count = bid_count + 1
+		redis.call('HSET', KEYS[1], 'highest_bid', amount, 'highest_bidder', ARGV[1], 'bid_count', bid_count)
+
+		return {1, bid_count}
+	`
+
+	// CloseAuctionScript stops bidding and returns the final bid state (idempotent)
+	// Returns {found, highest_bid, highest_bidder, bid_count}
+	CloseAuctionScript = `
+		if redis.call('EXISTS', KEYS[1]) == 0 then
+			return {0, 0, '', 0}
+		end
+
+		redis.call('HSET', KEYS[1], 'status', 'CLOSED')
+		if redis.call('GET', KEYS[2]) == ARGV[1] then
+			redis.call('DEL', KEYS[2])
+		end
+
+		local state = redis.call('HMGET', KEYS[1], 'highest_bid', 'highest_bidder', 'bid_count')
+		return {1, tonumber(state[1] or '0'), state[2] or '', tonumber(state[3] or '0')}
+	`
+
+	// JoinQueueScript puts a user at the back of a product's waiting room (KEYS[1], a sorted set
+	// scored by the join sequence KEYS[3]) unless the user is already waiting or is still admitted
+	// in KEYS[2] (scored by admission expiry).
+	// Returns {1, admitted_until} when admitted, {0, position} when waiting (1-based)
+	JoinQueueScript = `
+		local admitted_until = redis.call('ZSCORE', KEYS[2], ARGV[1])
+		if admitted_until and tonumber(admitted_until) > tonumber(ARGV[2]) then
+			return {1, tonumber(admitted_until)}
+		end
+
+		if not redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+			local seq = redis.call('INCR', KEYS[3])
+			redis.call('ZADD', KEYS[1], seq, ARGV[1])
+		end
+
+		return {0, redis.call('ZRANK', KEYS[1], ARGV[1]) + 1}
+	`
+
+	// QueuePositionScript reads a user's state in a product's waiting room without joining.
+	// Returns {1, admitted_until} when admitted, {0, position} when waiting, {-1, 0} when absent
+	QueuePositionScript = `
+		local admitted_until = redis.call('ZSCORE', KEYS[2], ARGV[1])
+		if admitted_until and tonumber(admitted_until) > tonumber(ARGV[2]) then
+			return {1, tonumber(admitted_until)}
+		end
+
+		local rank = redis.call('ZRANK', KEYS[1], ARGV[1])
+		if not rank then
+			return {-1, 0}
+		end
+
+		return {0, rank + 1}
+	`
+
+	// AdmitQueueScript moves users from the front of the waiting room (KEYS[1]) to the admitted set
+	// (KEYS[2]) at ARGV[2] users per second. KEYS[3] holds the time of the last admission in ms, so
+	// the rate holds no matter how many service instances run the admission worker; at most one
+	// second worth of users is admitted after an idle period. Expired admissions are dropped first.
+	// Returns the number of users admitted
+	AdmitQueueScript = `
+		redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', ARGV[4])
+
+		local now = tonumber(ARGV[1])
+		local rate = tonumber(ARGV[2])
+		local last = tonumber(redis.call('GET', KEYS[3]) or '0')
+
+		local count
+		if last == 0 or now - last >= 1000 then
+			count = rate
+			last = now
+		else
+			count = math.floor((now - last) * rate / 1000)
+			if count == 0 then
+				return 0
+			end
+			last = last + math.floor(count * 1000 / rate)
+		end
+		redis.call('SET', KEYS[3], last)
+
+		local popped = redis.call('ZPOPMIN', KEYS[1], count)
+		local admitted = 0
+		for i = 1, #popped, 2 do
+			redis.call('ZADD', KEYS[2], ARGV[3], popped[i])
+			admitted = admitted + 1
+		end
+
+		return admitted
+	`
 )
@@ -2,14 +2,37 @@ package redis
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
 	"github.com/redis/go-redis/v9"
 )
 
 const (
-	activeProductsKey = "stock_service:active_products"
+	activeProductsKey  = "stock_service:active_products"
+	auctionProductsKey = "stock_service:auction_products"
+	// purchaseLimitsKey is a hash of product_id -> per-user lifetime purchase limit.
+	// It is read by the reserve Lua script, so products without a limit have no field.
+	purchaseLimitsKey = "stock_service:purchase_limits"
+	// saleWindowsKey is a hash of product_id -> "<starts_unix>:<ends_unix>", 0 for an open bound.
+	// Reserve checks it so a delayed product.deactivated event cannot extend a sale.
+	saleWindowsKey = "stock_service:sale_windows"
+	// pricesKey is a hash of product_id -> "<amount>:<currency>", the current unit price
+	// that reservations are quoted at. Auction products have no field.
+	pricesKey = "stock_service:product_prices"
 )
 
+// ProductVariant is a variant of a product as known to stock-service.
+// A zero Amount means the variant has no price override and sells at the product price.
+type ProductVariant struct {
+	ID       string
+	Amount   int64
+	Currency string
+}
+
 // ProductStateRepository manages product state in Redis
 type ProductStateRepository struct {
 	client *redis.Client
@@ -37,11 +60,199 @@ func (r *ProductStateRepository) MarkInactive(ctx context.Context, productID str
 	return r.client.SRem(ctx, activeProductsKey, productID).Err()
 }
 
-// Remove removes a product from the active set
+// Remove removes a product from the active and auction sets and drops its purchase limit, sale window, price and variants
 func (r *ProductStateRepository) Remove(ctx context.Context, productID string) error {
+	if err := r.UnmarkAuction(ctx, productID); err != nil {
+		return err
+	}
+	if err := r.SetPrice(ctx, productID, 0, ""); err != nil {
+		return err
+	}
+	if err := r.SetVariants(ctx, productID, nil); err != nil {
+		return err
+	}
+	if err := r.SetPurchaseLimit(ctx, productID, 0); err != nil {
+		return err
+	}
+	if err := r.SetSaleWindow(ctx, productID, nil, nil); err != nil {
+		return err
+	}
 	return r.MarkInactive(ctx, productID)
 }
 
+// IsAuction checks if a product is sold by auction
+func (r *ProductStateRepository) IsAuction(ctx context.Context, productID string) (bool, error) {
+	return r.client.SIsMember(ctx, auctionProductsKey, productID).Result()
+}
+
+// MarkAuction marks a product as sold by auction
+func (r *ProductStateRepository) MarkAuction(ctx context.Context, productID string) error {
+	return r.client.SAdd(ctx, auctionProductsKey, productID).Err()
+}
+
+// UnmarkAuction marks a product as sold at a fixed price
+func (r *ProductStateRepository) UnmarkAuction(ctx context.Context, productID string) error {
+	return r.client.SRem(ctx, auctionProductsKey, productID).Err()
+}
+
+// SetPurchaseLimit sets the per-user lifetime purchase limit of a product (0 removes it)
+func (r *ProductStateRepository) SetPurchaseLimit(ctx context.Context, productID string, limit int) error {
+	if limit <= 0 {
+		return r.client.HDel(ctx, purchaseLimitsKey, productID).Err()
+	}
+	return r.client.HSet(ctx, purchaseLimitsKey, productID, limit).Err()
+}
+
+// GetPurchaseLimit returns the per-user purchase limit of a product (0 = unlimited)
+func (r *ProductStateRepository) GetPurchaseLimit(ctx context.Context, productID string) (int, error) {
+	raw, err := r.client.HGet(ctx, purchaseLimitsKey, productID).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(raw)
+}
+
+// SetSaleWindow sets the sale window of a product (both bounds nil removes it)
+func (r *ProductStateRepository) SetSaleWindow(ctx context.Context, productID string, startsAt, endsAt *time.Time) error {
+	if startsAt == nil && endsAt == nil {
+		return r.client.HDel(ctx, saleWindowsKey, productID).Err()
+	}
+
+	var startUnix, endUnix int64
+	if startsAt != nil {
+		startUnix = startsAt.Unix()
+	}
+	if endsAt != nil {
+		endUnix = endsAt.Unix()
+	}
+
+	return r.client.HSet(ctx, saleWindowsKey, productID, fmt.Sprintf("%d:%d", startUnix, endUnix)).Err()
+}
+
+// CheckSaleWindow returns ErrSaleNotStarted or ErrSaleEnded when now is outside the product's sale window
+func (r *ProductStateRepository) CheckSaleWindow(ctx context.Context, productID string, now time.Time) error {
+	raw, err := r.client.HGet(ctx, saleWindowsKey, productID).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	startRaw, endRaw, ok := strings.Cut(raw, ":")
+	if !ok {
+		return fmt.Errorf("malformed sale window %q", raw)
+	}
+	startUnix, err := strconv.ParseInt(startRaw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("malformed sale window start: %w", err)
+	}
+	endUnix, err := strconv.ParseInt(endRaw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("malformed sale window end: %w", err)
+	}
+
+	nowUnix := now.Unix()
+	if startUnix > 0 && nowUnix < startUnix {
+		return reservation.ErrSaleNotStarted
+	}
+	if endUnix > 0 && nowUnix >= endUnix {
+		return reservation.ErrSaleEnded
+	}
+
+	return nil
+}
+
+// SetPrice sets the unit price reservations of a product are quoted at (a zero amount removes it)
+func (r *ProductStateRepository) SetPrice(ctx context.Context, productID string, amount int64, currency string) error {
+	if amount <= 0 || currency == "" {
+		return r.client.HDel(ctx, pricesKey, productID).Err()
+	}
+	return r.client.HSet(ctx, pricesKey, productID, fmt.Sprintf("%d:%s", amount, currency)).Err()
+}
+
+// GetPrice returns the unit price of a product, or ErrPriceUnavailable when none is known
+func (r *ProductStateRepository) GetPrice(ctx context.Context, productID string) (int64, string, error) {
+	raw, err := r.client.HGet(ctx, pricesKey, productID).Result()
+	if err == redis.Nil {
+		return 0, "", reservation.ErrPriceUnavailable
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	amountRaw, currency, ok := strings.Cut(raw, ":")
+	if !ok {
+		return 0, "", fmt.Errorf("malformed product price %q", raw)
+	}
+	amount, err := strconv.ParseInt(amountRaw, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("malformed product price amount: %w", err)
+	}
+
+	return amount, currency, nil
+}
+
+// SetVariants replaces the variants of a product (nil removes them)
+func (r *ProductStateRepository) SetVariants(ctx context.Context, productID string, variants []ProductVariant) error {
+	key := productVariantsKey(productID)
+
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(variants) > 0 {
+		fields := make([]interface{}, 0, len(variants)*2)
+		for _, v := range variants {
+			override := ""
+			if v.Amount > 0 && v.Currency != "" {
+				override = fmt.Sprintf("%d:%s", v.Amount, v.Currency)
+			}
+			fields = append(fields, v.ID, override)
+		}
+		pipe.HSet(ctx, key, fields...)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// HasVariants checks if a product is sold by variant
+func (r *ProductStateRepository) HasVariants(ctx context.Context, productID string) (bool, error) {
+	n, err := r.client.Exists(ctx, productVariantsKey(productID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// GetVariantPrice returns the price override of a variant (zero amount when it sells at the
+// product price), or ErrVariantNotFound when the product has no such variant
+func (r *ProductStateRepository) GetVariantPrice(ctx context.Context, productID, variantID string) (int64, string, error) {
+	raw, err := r.client.HGet(ctx, productVariantsKey(productID), variantID).Result()
+	if err == redis.Nil {
+		return 0, "", reservation.ErrVariantNotFound
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	if raw == "" {
+		return 0, "", nil
+	}
+
+	amountRaw, currency, ok := strings.Cut(raw, ":")
+	if !ok {
+		return 0, "", fmt.Errorf("malformed variant price %q", raw)
+	}
+	amount, err := strconv.ParseInt(amountRaw, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("malformed variant price amount: %w", err)
+	}
+
+	return amount, currency, nil
+}
+
 // GetAllActive returns all active product IDs
 func (r *ProductStateRepository) GetAllActive(ctx context.Context) ([]string, error) {
 	return r.client.SMembers(ctx, activeProductsKey).Result()
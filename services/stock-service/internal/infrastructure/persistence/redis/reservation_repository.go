@@ -8,6 +8,7 @@ import (
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
@@ -38,6 +39,7 @@ func (r *ReservationRepository) Save(ctx context.Context, res *reservation.Reser
 	data := map[string]interface{}{
 		"id":          res.ID().String(),
 		"product_id":  res.ProductID().String(),
+		"variant_id":  res.VariantID().String(),
 		"user_id":     res.UserID().String(),
 		"quantity":    res.Quantity(),
 		"status":      string(res.Status()),
@@ -118,6 +120,8 @@ func (r *ReservationRepository) FindByID(ctx context.Context, id reservation.Res
 
 	// Parse fields
 	productID, _ := reservation.ParseProductID(resData["product_id"].(string))
+	variantRaw, _ := resData["variant_id"].(string) // absent on reservations made before variants
+	variantID, _ := reservation.ParseVariantID(variantRaw)
 	userID, _ := reservation.ParseUserID(resData["user_id"].(string))
 	quantity := int(resData["quantity"].(float64))
 	status := reservation.ReservationStatus(resData["status"].(string))
@@ -130,15 +134,23 @@ func (r *ReservationRepository) FindByID(ctx context.Context, id reservation.Res
 		orderID = &oid
 	}
 
+	var consumedAt *time.Time
+	if raw, ok := resData["consumed_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			consumedAt = &t
+		}
+	}
+
 	res := reservation.ReconstructReservation(
 		id,
 		productID,
+		variantID,
 		userID,
 		quantity,
 		status,
 		reservedAt,
 		expiredAt,
-		nil, nil,
+		consumedAt, nil,
 		orderID,
 	)
 
@@ -164,6 +176,17 @@ func (r *ReservationRepository) Delete(ctx context.Context, id reservation.Reser
 	return nil
 }
 
+// SetPurchaseQuota overwrites the used purchase quota of a user for a product
+func (r *ReservationRepository) SetPurchaseQuota(ctx context.Context, total reservation.PurchaseTotal) error {
+	key := purchaseQuotaKey(stock.ProductID(total.ProductID), total.UserID)
+
+	if err := r.client.Set(ctx, key, total.Quantity, 0).Err(); err != nil {
+		return fmt.Errorf("failed to set purchase quota: %w", err)
+	}
+
+	return nil
+}
+
 // FindActiveByProductID finds all active reservations for a product
 func (r *ReservationRepository) FindActiveByProductID(
 	ctx context.Context,
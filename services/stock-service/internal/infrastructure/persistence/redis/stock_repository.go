@@ -25,11 +25,12 @@ func NewStockRepository(client *redis.Client) *StockRepository {
 
 // Save saves stock to Redis
 func (r *StockRepository) Save(ctx context.Context, s *stock.Stock) error {
-	key := stockKey(s.ProductID())
-	metaKey := stockMetadataKey(s.ProductID())
+	key := stockKey(s.ProductID(), s.VariantID())
+	metaKey := stockMetadataKey(s.ProductID(), s.VariantID())
 
 	logger.DebugContext(ctx, "saving stock to redis",
 		zap.String("product_id", s.ProductID().String()),
+		zap.String("variant_id", s.VariantID().String()),
 		zap.Int("quantity", s.Quantity()),
 	)
 
@@ -64,13 +65,14 @@ func (r *StockRepository) Save(ctx context.Context, s *stock.Stock) error {
 	return nil
 }
 
-// FindByProductID finds stock by product ID
-func (r *StockRepository) FindByProductID(ctx context.Context, productID stock.ProductID) (*stock.Stock, error) {
-	key := stockKey(productID)
-	metaKey := stockMetadataKey(productID)
+// FindByProductID finds the stock of a product or one of its variants
+func (r *StockRepository) FindByProductID(ctx context.Context, productID stock.ProductID, variantID stock.VariantID) (*stock.Stock, error) {
+	key := stockKey(productID, variantID)
+	metaKey := stockMetadataKey(productID, variantID)
 
 	logger.DebugContext(ctx, "finding stock in redis",
 		zap.String("product_id", productID.String()),
+		zap.String("variant_id", variantID.String()),
 	)
 
 	// Get quantity
@@ -111,14 +113,14 @@ func (r *StockRepository) FindByProductID(ctx context.Context, productID stock.P
 		}
 	}
 
-	s := stock.ReconstructStock(productID, quantity, initialQuantity, time.Now())
+	s := stock.ReconstructStock(productID, variantID, quantity, initialQuantity, time.Now())
 
 	return s, nil
 }
 
 // Exists checks if stock exists
-func (r *StockRepository) Exists(ctx context.Context, productID stock.ProductID) (bool, error) {
-	key := stockKey(productID)
+func (r *StockRepository) Exists(ctx context.Context, productID stock.ProductID, variantID stock.VariantID) (bool, error) {
+	key := stockKey(productID, variantID)
 
 	exists, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
@@ -136,6 +138,7 @@ func (r *StockRepository) Exists(ctx context.Context, productID stock.ProductID)
 func (r *StockRepository) Reserve(
 	ctx context.Context,
 	productID stock.ProductID,
+	variantID stock.VariantID,
 	quantity int,
 ) (int, error) {
 	// This is simplified version, full version with reservation is in ReserveWithReservation
@@ -146,7 +149,7 @@ func (r *StockRepository) Reserve(
 		return 0, stock.ErrExceedsMaxQuantity
 	}
 
-	stockKey := stockKey(productID)
+	stockKey := stockKey(productID, variantID)
 
 	logger.InfoContext(ctx, "reserving stock",
 		zap.String("product_id", productID.String()),
@@ -185,9 +188,10 @@ func (r *StockRepository) Reserve(
 func (r *StockRepository) Release(
 	ctx context.Context,
 	productID stock.ProductID,
+	variantID stock.VariantID,
 	quantity int,
 ) (int, error) {
-	stockKey := stockKey(productID)
+	stockKey := stockKey(productID, variantID)
 
 	logger.InfoContext(ctx, "releasing stock",
 		zap.String("product_id", productID.String()),
@@ -211,3 +215,62 @@ func (r *StockRepository) Release(
 
 	return int(result), nil
 }
+
+// Adjust applies a signed seller adjustment to the stock counter atomically
+func (r *StockRepository) Adjust(
+	ctx context.Context,
+	productID stock.ProductID,
+	variantID stock.VariantID,
+	delta int,
+) (int, error) {
+	logger.InfoContext(ctx, "adjusting stock",
+		zap.String("product_id", productID.String()),
+		zap.String("variant_id", variantID.String()),
+		zap.Int("delta", delta),
+	)
+
+	result, err := r.client.Eval(ctx, AdjustStockScript,
+		[]string{stockKey(productID, variantID), stockMetadataKey(productID, variantID)},
+		delta,
+		time.Now().Unix(),
+	).Result()
+	if err != nil {
+		logger.ErrorContext(ctx, "lua script execution failed",
+			zap.String("product_id", productID.String()),
+			zap.Error(err),
+		)
+		return 0, fmt.Errorf("failed to execute adjust script: %w", err)
+	}
+
+	// Parse result: {code, quantity}
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		return 0, fmt.Errorf("invalid script result")
+	}
+
+	code, ok1 := values[0].(int64)
+	quantity, ok2 := values[1].(int64)
+	if !ok1 || !ok2 {
+		return 0, fmt.Errorf("failed to parse result")
+	}
+
+	switch code {
+	case -1:
+		return 0, stock.ErrStockNotFound
+	case 0:
+		logger.WarnContext(ctx, "adjustment would make stock negative",
+			zap.String("product_id", productID.String()),
+			zap.Int("delta", delta),
+			zap.Int64("available", quantity),
+		)
+		return int(quantity), stock.ErrInsufficientStock
+	}
+
+	logger.InfoContext(ctx, "stock adjusted successfully",
+		zap.String("product_id", productID.String()),
+		zap.Int("delta", delta),
+		zap.Int64("new_quantity", quantity),
+	)
+
+	return int(quantity), nil
+}
@@ -7,12 +7,23 @@ import (
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/metrics"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
+// consumedReservationRetention keeps consumed reservations readable after their hold TTL is dropped
+const consumedReservationRetention = 24 * time.Hour
+
+// idempotencyKeyRetention is how long a retried reserve request returns the original reservation
+const idempotencyKeyRetention = 24 * time.Hour
+
+// returnedMarkerRetention keeps a released reservation's returned marker until the expiry scan
+// has long since recorded the release
+const returnedMarkerRetention = 24 * time.Hour
+
 // StockReservationCoordinator handles atomic operations across Stock and Reservation aggregates.
 // This is an infrastructure-level component that exists purely to satisfy Redis technical
 // constraints (Lua script atomicity), not a domain service.
@@ -28,17 +39,31 @@ func NewStockReservationCoordinator(client *redis.Client) *StockReservationCoord
 	}
 }
 
-// Reserve reserves stock and creates reservation atomically using Lua script
+// eval runs a Lua script and records its latency under the given script name
+func (c *StockReservationCoordinator) eval(ctx context.Context, name string, script string, keys []string, args ...interface{}) *redis.Cmd {
+	start := time.Now()
+	cmd := c.client.Eval(ctx, script, keys, args...)
+	metrics.ObserveRedisScript(name, time.Since(start))
+	return cmd
+}
+
+// Reserve reserves stock and creates reservation atomically using Lua script.
+// With an idempotency key, a replayed request reserves nothing and returns the ID of the
+// original reservation instead; the returned ID is empty for a new reservation.
 func (c *StockReservationCoordinator) Reserve(
 	ctx context.Context,
 	productID stock.ProductID,
+	variantID stock.VariantID,
 	res *reservation.Reservation,
-) (int, error) {
-	sKey := stockKey(productID)
+	idempotencyKey *reservation.IdempotencyKey,
+) (int, reservation.ReservationID, error) {
+	sKey := stockKey(productID, variantID)
 	rKey := reservationKey(res.ID())
+	qKey := purchaseQuotaKey(productID, res.UserID())
 
 	logger.InfoContext(ctx, "reserving stock with lua script",
 		zap.String("product_id", productID.String()),
+		zap.String("variant_id", variantID.String()),
 		zap.String("reservation_id", res.ID().String()),
 		zap.Int("quantity", res.Quantity()),
 	)
@@ -47,6 +72,7 @@ func (c *StockReservationCoordinator) Reserve(
 	resData := map[string]interface{}{
 		"id":          res.ID().String(),
 		"product_id":  res.ProductID().String(),
+		"variant_id":  res.VariantID().String(),
 		"user_id":     res.UserID().String(),
 		"quantity":    res.Quantity(),
 		"status":      string(res.Status()),
@@ -60,22 +86,24 @@ func (c *StockReservationCoordinator) Reserve(
 			zap.String("reservation_id", res.ID().String()),
 			zap.Error(err),
 		)
-		return 0, fmt.Errorf("failed to marshal reservation: %w", err)
+		return 0, "", fmt.Errorf("failed to marshal reservation: %w", err)
 	}
 
 	// Calculate TTL in seconds
 	ttl := int(time.Until(res.ExpiredAt()).Seconds())
 	if ttl <= 0 {
-		return 0, reservation.ErrReservationExpired
+		return 0, "", reservation.ErrReservationExpired
+	}
+
+	keys := []string{sKey, rKey, qKey, purchaseLimitsKey}
+	args := []interface{}{res.Quantity(), string(resJSON), ttl, productID.String()}
+	if idempotencyKey != nil {
+		keys = append(keys, reserveIdempotencyKey(idempotencyKey))
+		args = append(args, idempotencyKey.Fingerprint(), res.ID().String(), int(idempotencyKeyRetention.Seconds()))
 	}
 
 	// Execute Lua script
-	result, err := c.client.Eval(ctx, ReserveStockScript,
-		[]string{sKey, rKey},
-		res.Quantity(),
-		string(resJSON),
-		ttl,
-	).Result()
+	result, err := c.eval(ctx, "reserve", ReserveStockScript, keys, args...).Result()
 
 	if err != nil {
 		logger.ErrorContext(ctx, "lua script execution failed",
@@ -83,17 +111,17 @@ func (c *StockReservationCoordinator) Reserve(
 			zap.String("reservation_id", res.ID().String()),
 			zap.Error(err),
 		)
-		return 0, fmt.Errorf("failed to execute reserve script: %w", err)
+		return 0, "", fmt.Errorf("failed to execute reserve script: %w", err)
 	}
 
-	// Parse result: {success, new_quantity}
+	// Parse result: {success, new_quantity} or {2, current, reservation_id} on replay
 	values, ok := result.([]interface{})
-	if !ok || len(values) != 2 {
+	if !ok || len(values) < 2 {
 		logger.ErrorContext(ctx, "invalid lua script result",
 			zap.String("product_id", productID.String()),
 			zap.Any("result", result),
 		)
-		return 0, fmt.Errorf("invalid script result")
+		return 0, "", fmt.Errorf("invalid script result")
 	}
 
 	success, ok1 := values[0].(int64)
@@ -102,17 +130,47 @@ func (c *StockReservationCoordinator) Reserve(
 		logger.ErrorContext(ctx, "failed to parse lua script result",
 			zap.Any("values", values),
 		)
-		return 0, fmt.Errorf("failed to parse result")
+		return 0, "", fmt.Errorf("failed to parse result")
 	}
 
 	// Check success
+	if success == 2 && len(values) == 3 {
+		originalID, ok := values[2].(string)
+		if !ok {
+			return 0, "", fmt.Errorf("failed to parse replayed reservation id")
+		}
+		logger.InfoContext(ctx, "reserve request replayed",
+			zap.String("product_id", productID.String()),
+			zap.String("reservation_id", originalID),
+		)
+		return int(newQty), reservation.ReservationID(originalID), nil
+	}
+
+	if success == -3 {
+		logger.WarnContext(ctx, "idempotency key reused with a different request",
+			zap.String("product_id", productID.String()),
+			zap.String("user_id", res.UserID().String()),
+		)
+		return 0, "", reservation.ErrIdempotencyKeyReused
+	}
+
+	if success == -2 {
+		logger.WarnContext(ctx, "purchase limit exceeded",
+			zap.String("product_id", productID.String()),
+			zap.String("user_id", res.UserID().String()),
+			zap.Int("requested", res.Quantity()),
+			zap.Int64("remaining_quota", newQty),
+		)
+		return 0, "", reservation.ErrPurchaseLimitExceeded
+	}
+
 	if success == 0 {
 		logger.WarnContext(ctx, "insufficient stock",
 			zap.String("product_id", productID.String()),
 			zap.Int("requested", res.Quantity()),
 			zap.Int64("available", newQty),
 		)
-		return int(newQty), stock.ErrInsufficientStock
+		return int(newQty), "", stock.ErrInsufficientStock
 	}
 
 	logger.InfoContext(ctx, "stock reserved successfully with lua script",
@@ -122,18 +180,70 @@ func (c *StockReservationCoordinator) Reserve(
 		zap.Int64("remaining", newQty),
 	)
 
-	return int(newQty), nil
+	return int(newQty), "", nil
+}
+
+// FindIdempotentReservation returns the current stock and the ID of the reservation recorded
+// under an idempotency key; the ID is empty while the key is unused. The reserve script
+// checks the key again, so a request racing the original one is still replayed.
+func (c *StockReservationCoordinator) FindIdempotentReservation(
+	ctx context.Context,
+	productID stock.ProductID,
+	variantID stock.VariantID,
+	idempotencyKey *reservation.IdempotencyKey,
+) (int, reservation.ReservationID, error) {
+	recorded, err := c.client.Get(ctx, reserveIdempotencyKey(idempotencyKey)).Bytes()
+	if err == redis.Nil {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	var record struct {
+		ReservationID string `json:"reservation_id"`
+		Fingerprint   string `json:"fingerprint"`
+	}
+	if err := json.Unmarshal(recorded, &record); err != nil {
+		return 0, "", fmt.Errorf("failed to unmarshal idempotency key: %w", err)
+	}
+	if record.Fingerprint != idempotencyKey.Fingerprint() {
+		logger.WarnContext(ctx, "idempotency key reused with a different request",
+			zap.String("product_id", productID.String()),
+			zap.String("user_id", idempotencyKey.UserID().String()),
+		)
+		return 0, "", reservation.ErrIdempotencyKeyReused
+	}
+
+	current, err := c.client.Get(ctx, stockKey(productID, variantID)).Int()
+	if err != nil && err != redis.Nil {
+		return 0, "", fmt.Errorf("failed to get stock: %w", err)
+	}
+
+	return current, reservation.ReservationID(record.ReservationID), nil
+}
+
+// ForgetIdempotencyKey removes the record of an idempotency key, so a reservation that was
+// rolled back can be retried under the same key
+func (c *StockReservationCoordinator) ForgetIdempotencyKey(ctx context.Context, idempotencyKey *reservation.IdempotencyKey) error {
+	if err := c.client.Del(ctx, reserveIdempotencyKey(idempotencyKey)).Err(); err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
 }
 
 // Release releases stock and deletes reservation atomically using Lua script
 func (c *StockReservationCoordinator) Release(
 	ctx context.Context,
 	productID stock.ProductID,
+	variantID stock.VariantID,
 	reservationID reservation.ReservationID,
+	userID reservation.UserID,
 	quantity int,
 ) (int, error) {
-	sKey := stockKey(productID)
+	sKey := stockKey(productID, variantID)
 	rKey := reservationKey(reservationID)
+	qKey := purchaseQuotaKey(productID, userID)
 
 	logger.InfoContext(ctx, "releasing stock with lua script",
 		zap.String("product_id", productID.String()),
@@ -142,9 +252,9 @@ func (c *StockReservationCoordinator) Release(
 	)
 
 	// Execute Lua script
-	result, err := c.client.Eval(ctx, ReleaseStockScript,
-		[]string{sKey, rKey},
-		quantity,
+	result, err := c.eval(ctx, "release", ReleaseStockScript,
+		[]string{sKey, rKey, qKey, reservationReturnedKey(reservationID)},
+		quantity, int(returnedMarkerRetention.Seconds()),
 	).Result()
 
 	if err != nil {
@@ -181,6 +291,13 @@ func (c *StockReservationCoordinator) Release(
 		return 0, reservation.ErrReservationNotFound
 	}
 
+	if success == -1 {
+		logger.WarnContext(ctx, "reservation is no longer reserved in redis",
+			zap.String("reservation_id", reservationID.String()),
+		)
+		return 0, reservation.ErrCanOnlyReleaseReserved
+	}
+
 	logger.InfoContext(ctx, "stock released successfully with lua script",
 		zap.String("product_id", productID.String()),
 		zap.String("reservation_id", reservationID.String()),
@@ -190,3 +307,85 @@ func (c *StockReservationCoordinator) Release(
 
 	return int(newQty), nil
 }
+
+// ReturnStock gives back the stock and purchase quota of a reservation whose Redis hold has
+// already expired. It is a no-op for a reservation whose stock Release or an earlier call
+// already returned, and then reports the current stock.
+func (c *StockReservationCoordinator) ReturnStock(
+	ctx context.Context,
+	productID stock.ProductID,
+	variantID stock.VariantID,
+	reservationID reservation.ReservationID,
+	userID reservation.UserID,
+	quantity int,
+) (int, error) {
+	values, err := c.eval(ctx, "return", ReturnStockScript,
+		[]string{stockKey(productID, variantID), purchaseQuotaKey(productID, userID), reservationReturnedKey(reservationID)},
+		quantity, int(returnedMarkerRetention.Seconds()),
+	).Int64Slice()
+	if err != nil {
+		logger.ErrorContext(ctx, "lua script execution failed",
+			zap.String("product_id", productID.String()),
+			zap.String("reservation_id", reservationID.String()),
+			zap.Error(err),
+		)
+		return 0, fmt.Errorf("failed to execute return stock script: %w", err)
+	}
+	if len(values) != 2 {
+		return 0, fmt.Errorf("invalid script result")
+	}
+
+	if values[0] == 0 {
+		logger.InfoContext(ctx, "reservation stock already returned",
+			zap.String("reservation_id", reservationID.String()),
+		)
+	}
+
+	return int(values[1]), nil
+}
+
+// Consume marks the reservation CONSUMED in Redis so it can no longer be released.
+// The stock counter is untouched: consumed stock stays deducted.
+func (c *StockReservationCoordinator) Consume(
+	ctx context.Context,
+	res *reservation.Reservation,
+) error {
+	rKey := reservationKey(res.ID())
+
+	orderID := ""
+	if res.OrderID() != nil {
+		orderID = *res.OrderID()
+	}
+
+	consumedAt := time.Now()
+	if res.ConsumedAt() != nil {
+		consumedAt = *res.ConsumedAt()
+	}
+
+	result, err := c.eval(ctx, "consume", ConsumeReservationScript,
+		[]string{rKey},
+		orderID,
+		consumedAt.Format(time.RFC3339),
+		int(consumedReservationRetention.Seconds()),
+	).Int64()
+	if err != nil {
+		logger.ErrorContext(ctx, "lua script execution failed",
+			zap.String("reservation_id", res.ID().String()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to execute consume script: %w", err)
+	}
+
+	switch result {
+	case 0:
+		return reservation.ErrReservationNotFound
+	case -1:
+		return reservation.ErrCanOnlyConsumeReserved
+	case 2:
+		logger.InfoContext(ctx, "reservation already consumed in redis",
+			zap.String("reservation_id", res.ID().String()),
+		)
+	}
+
+	return nil
+}
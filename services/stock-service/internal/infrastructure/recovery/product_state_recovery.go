@@ -21,10 +21,34 @@ type ProductStateRecovery struct {
 }
 
 type SnapshotData struct {
-	ActiveProducts   []string         `json:"active_products"`
-	PartitionOffsets map[string]int64 `json:"partition_offsets"` // "0" -> offset
-	Total            int              `json:"total"`
-	OccurredAt       string           `json:"occurred_at"`
+	ActiveProducts   []string                  `json:"active_products"`
+	AuctionProducts  []string                  `json:"auction_products"`
+	PurchaseLimits   map[string]int            `json:"purchase_limits"`
+	SaleWindows      map[string]SaleWindowData `json:"sale_windows"`
+	Prices           map[string]PriceData      `json:"prices"`
+	Variants         map[string][]VariantData  `json:"variants"`
+	PartitionOffsets map[string]int64          `json:"partition_offsets"` // "0" -> offset
+	Total            int                       `json:"total"`
+	OccurredAt       string                    `json:"occurred_at"`
+}
+
+// SaleWindowData is a product sale window in a snapshot, absent bounds are open
+type SaleWindowData struct {
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+// PriceData is the unit price of a fixed-price product in a snapshot
+type PriceData struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// VariantData is a product variant in a snapshot, the price is only set for a price override
+type VariantData struct {
+	VariantID string `json:"variant_id"`
+	Price     int64  `json:"price,omitempty"`
+	Currency  string `json:"currency,omitempty"`
 }
 
 type SnapshotInfo struct {
@@ -298,6 +322,55 @@ func (r *ProductStateRecovery) loadSnapshot(ctx context.Context, snapshot *Snaps
 		successCount++
 	}
 
+	for _, productID := range snapshot.AuctionProducts {
+		if err := r.productStateRepo.MarkAuction(ctx, productID); err != nil {
+			zap.L().Error("failed to mark product as auction",
+				zap.String("product_id", productID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	for productID, limit := range snapshot.PurchaseLimits {
+		if err := r.productStateRepo.SetPurchaseLimit(ctx, productID, limit); err != nil {
+			zap.L().Error("failed to set product purchase limit",
+				zap.String("product_id", productID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	for productID, window := range snapshot.SaleWindows {
+		if err := r.productStateRepo.SetSaleWindow(ctx, productID, window.StartsAt, window.EndsAt); err != nil {
+			zap.L().Error("failed to set product sale window",
+				zap.String("product_id", productID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	for productID, price := range snapshot.Prices {
+		if err := r.productStateRepo.SetPrice(ctx, productID, price.Amount, price.Currency); err != nil {
+			zap.L().Error("failed to set product price",
+				zap.String("product_id", productID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	for productID, variants := range snapshot.Variants {
+		state := make([]redis.ProductVariant, 0, len(variants))
+		for _, v := range variants {
+			state = append(state, redis.ProductVariant{ID: v.VariantID, Amount: v.Price, Currency: v.Currency})
+		}
+		if err := r.productStateRepo.SetVariants(ctx, productID, state); err != nil {
+			zap.L().Error("failed to set product variants",
+				zap.String("product_id", productID),
+				zap.Error(err),
+			)
+		}
+	}
+
 	zap.L().Info("snapshot loaded",
 		zap.Int("success", successCount),
 		zap.Int("total", len(snapshot.ActiveProducts)),
@@ -444,8 +517,28 @@ func (r *ProductStateRecovery) applyIncrementalEvent(ctx context.Context, event
 	switch event.EventType {
 	case "product.published":
 		r.productStateRepo.MarkActive(ctx, productID)
+		if priceType, _ := event.Data["price_type"].(string); priceType == "AUCTION" {
+			r.productStateRepo.MarkAuction(ctx, productID)
+			r.productStateRepo.SetPrice(ctx, productID, 0, "")
+		} else {
+			r.productStateRepo.UnmarkAuction(ctx, productID)
+			amount, currency := kafka.EventPrice(event.Data)
+			r.productStateRepo.SetPrice(ctx, productID, amount, currency)
+		}
+		r.productStateRepo.SetVariants(ctx, productID, kafka.EventVariants(event.Data))
+		purchaseLimit, _ := event.Data["purchase_limit"].(float64)
+		r.productStateRepo.SetPurchaseLimit(ctx, productID, int(purchaseLimit))
+		startsAt, _ := kafka.ParseEventTime(event.Data, "sale_starts_at")
+		endsAt, _ := kafka.ParseEventTime(event.Data, "sale_ends_at")
+		r.productStateRepo.SetSaleWindow(ctx, productID, startsAt, endsAt)
+	case "product.price_updated":
+		if priceType, _ := event.Data["price_type"].(string); priceType != "AUCTION" {
+			amount, currency := kafka.EventPrice(event.Data)
+			r.productStateRepo.SetPrice(ctx, productID, amount, currency)
+		}
 	case "product.deactivated":
 		r.productStateRepo.MarkInactive(ctx, productID)
+		r.productStateRepo.SetSaleWindow(ctx, productID, nil, nil)
 	case "product.deleted":
 		r.productStateRepo.Remove(ctx, productID)
 	case "product.snapshot":
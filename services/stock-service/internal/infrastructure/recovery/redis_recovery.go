@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
 	"github.com/segmentio/kafka-go"
 
 	"github.com/redis/go-redis/v9"
@@ -18,6 +19,8 @@ type RedisRecovery struct {
 	redisClient               *redis.Client
 	persistentReservationRepo reservation.PersistentRepository
 	cacheReservationRepo      reservation.CacheRepository
+	stockRepo                 stock.Repository
+	ledgerRepo                stock.LedgerRepository
 }
 
 // NewRedisRecovery creates a new RedisRecovery
@@ -25,11 +28,15 @@ func NewRedisRecovery(
 	redisClient *redis.Client,
 	persistentReservationRepo reservation.PersistentRepository,
 	cacheReservationRepo reservation.CacheRepository,
+	stockRepo stock.Repository,
+	ledgerRepo stock.LedgerRepository,
 ) *RedisRecovery {
 	return &RedisRecovery{
 		redisClient:               redisClient,
 		persistentReservationRepo: persistentReservationRepo,
 		cacheReservationRepo:      cacheReservationRepo,
+		stockRepo:                 stockRepo,
+		ledgerRepo:                ledgerRepo,
 	}
 }
 
@@ -72,6 +79,83 @@ func (r *RedisRecovery) RecoverActiveReservations(ctx context.Context) error {
 	return nil
 }
 
+// ReconcileStock rebuilds the Redis stock counters from the PostgreSQL stock ledger.
+// Every change to a counter is ledgered in the same transaction as its outbox event,
+// so the replayed balance equals the counter Redis held, including stock held by
+// active reservations. Run it while reservations are not being taken (startup or
+// maintenance), since it overwrites live counters.
+func (r *RedisRecovery) ReconcileStock(ctx context.Context) error {
+	zap.L().Info("reconciling redis stock counters from stock ledger")
+
+	stocks, err := r.ledgerRepo.FindAllBalances(ctx)
+	if err != nil {
+		zap.L().Error("failed to replay stock ledger",
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to replay stock ledger: %w", err)
+	}
+
+	successCount := 0
+	for _, stk := range stocks {
+		if err := r.stockRepo.Save(ctx, stk); err != nil {
+			zap.L().Error("failed to restore stock to redis",
+				zap.String("product_id", stk.ProductID().String()),
+				zap.String("variant_id", stk.VariantID().String()),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		successCount++
+	}
+
+	zap.L().Info("redis stock counters reconciled",
+		zap.Int("success", successCount),
+		zap.Int("total", len(stocks)),
+	)
+
+	if successCount != len(stocks) {
+		return fmt.Errorf("failed to restore %d of %d stock counters", len(stocks)-successCount, len(stocks))
+	}
+
+	return nil
+}
+
+// RecoverPurchaseQuotas rebuilds the per-user purchase quota counters checked by the
+// reserve script from the reservations held or consumed in PostgreSQL
+func (r *RedisRecovery) RecoverPurchaseQuotas(ctx context.Context) error {
+	zap.L().Info("recovering purchase quotas from postgresql")
+
+	totals, err := r.persistentReservationRepo.FindPurchaseTotals(ctx)
+	if err != nil {
+		zap.L().Error("failed to query purchase totals",
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to query purchase totals: %w", err)
+	}
+
+	successCount := 0
+	for _, total := range totals {
+		if err := r.cacheReservationRepo.SetPurchaseQuota(ctx, total); err != nil {
+			zap.L().Error("failed to restore purchase quota to redis",
+				zap.String("product_id", total.ProductID.String()),
+				zap.String("user_id", total.UserID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		successCount++
+	}
+
+	zap.L().Info("purchase quotas recovered",
+		zap.Int("success", successCount),
+		zap.Int("total", len(totals)),
+	)
+
+	return nil
+}
+
 // RecoverStockFromKafka recovers stock by replaying Kafka events
 func (r *RedisRecovery) RecoverStockFromKafka(
 	ctx context.Context,
@@ -94,8 +178,8 @@ func (r *RedisRecovery) RecoverStockFromKafka(
 	})
 	defer reader.Close()
 
-	// Track stock changes per product
-	stockChanges := make(map[string]int) // product_id -> net change
+	// Track stock changes per stock counter
+	stockChanges := make(map[string]int) // stock key -> net change
 
 	startTime := time.Now().Add(-lookbackDuration)
 	eventsProcessed := 0
@@ -126,20 +210,24 @@ func (r *RedisRecovery) RecoverStockFromKafka(
 
 		eventType := event["event_type"].(string)
 		productID := event["data"].(map[string]interface{})["product_id"].(string)
+		key := fmt.Sprintf("stock:product:%s", productID)
+		if variantID, _ := event["data"].(map[string]interface{})["variant_id"].(string); variantID != "" {
+			key = fmt.Sprintf("stock:product:%s:variant:%s", productID, variantID)
+		}
 
 		// Apply event
 		switch eventType {
 		case "stock.set":
 			quantity := int(event["data"].(map[string]interface{})["quantity"].(float64))
-			stockChanges[productID] = quantity
+			stockChanges[key] = quantity
 
 		case "stock.reserved":
 			quantity := int(event["data"].(map[string]interface{})["quantity"].(float64))
-			stockChanges[productID] -= quantity
+			stockChanges[key] -= quantity
 
 		case "stock.released":
 			quantity := int(event["data"].(map[string]interface{})["quantity"].(float64))
-			stockChanges[productID] += quantity
+			stockChanges[key] += quantity
 		}
 
 		eventsProcessed++
@@ -151,11 +239,10 @@ func (r *RedisRecovery) RecoverStockFromKafka(
 		zap.Int("events_processed", eventsProcessed),
 	)
 
-	for productID, quantity := range stockChanges {
-		key := fmt.Sprintf("stock:product:%s", productID)
+	for key, quantity := range stockChanges {
 		if err := r.redisClient.Set(ctx, key, quantity, 0).Err(); err != nil {
 			zap.L().Error("failed to set stock during recovery",
-				zap.String("product_id", productID),
+				zap.String("key", key),
 				zap.Error(err),
 			)
 			continue
@@ -181,10 +268,21 @@ func (r *RedisRecovery) FullRecovery(ctx context.Context) error {
 		return err
 	}
 
-	// Step 2: Stock recovery requires manual intervention or Kafka replay
-	// zap.L().Warn("stock quantities need manual recovery",
-	// 	zap.String("action", "use admin API to set stock or replay kafka events"),
-	// )
+	// Step 2: Rebuild stock counters from the durable ledger
+	if err := r.ReconcileStock(ctx); err != nil {
+		zap.L().Error("failed to reconcile stock",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// Step 3: Rebuild per-user purchase quotas
+	if err := r.RecoverPurchaseQuotas(ctx); err != nil {
+		zap.L().Error("failed to recover purchase quotas",
+			zap.Error(err),
+		)
+		return err
+	}
 
 	zap.L().Info("redis recovery completed")
 
@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+
+	stockv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/stock/v1"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnableQueue enables the waiting room of a product
+func (h *StockHandler) EnableQueue(
+	ctx context.Context,
+	req *stockv1.EnableQueueRequest,
+) (*stockv1.EnableQueueResponse, error) {
+	logger.InfoContext(ctx, "handling EnableQueue request",
+		zap.String("product_id", req.ProductId),
+		zap.Int32("admission_rate", req.AdmissionRate),
+	)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.AdmissionRate <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "admission_rate must be positive")
+	}
+
+	queueStatus, err := h.admissionService.EnableQueue(ctx, req.ProductId, int(req.AdmissionRate))
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, "enable queue failed",
+			zap.String("product_id", req.ProductId),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	return &stockv1.EnableQueueResponse{
+		Status: domainQueueStatusToProto(queueStatus),
+	}, nil
+}
+
+// DisableQueue disables the waiting room of a product
+func (h *StockHandler) DisableQueue(
+	ctx context.Context,
+	req *stockv1.DisableQueueRequest,
+) (*stockv1.DisableQueueResponse, error) {
+	logger.InfoContext(ctx, "handling DisableQueue request",
+		zap.String("product_id", req.ProductId),
+	)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	if err := h.admissionService.DisableQueue(ctx, req.ProductId); err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, "disable queue failed",
+			zap.String("product_id", req.ProductId),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	return &stockv1.DisableQueueResponse{
+		Success: true,
+	}, nil
+}
+
+// GetQueueStatus returns whether the waiting room of a product is enabled
+func (h *StockHandler) GetQueueStatus(
+	ctx context.Context,
+	req *stockv1.GetQueueStatusRequest,
+) (*stockv1.GetQueueStatusResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	queueStatus, err := h.admissionService.GetQueueStatus(ctx, req.ProductId)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, "get queue status failed",
+			zap.String("product_id", req.ProductId),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	return &stockv1.GetQueueStatusResponse{
+		Status: domainQueueStatusToProto(queueStatus),
+	}, nil
+}
+
+// JoinQueue puts a user in the waiting room of a product
+func (h *StockHandler) JoinQueue(
+	ctx context.Context,
+	req *stockv1.JoinQueueRequest,
+) (*stockv1.JoinQueueResponse, error) {
+	logger.DebugContext(ctx, "handling JoinQueue request",
+		zap.String("product_id", req.ProductId),
+		zap.String("user_id", req.UserId),
+	)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	ticket, err := h.admissionService.JoinQueue(ctx, req.ProductId, req.UserId)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, "join queue failed",
+			zap.String("product_id", req.ProductId),
+			zap.String("user_id", req.UserId),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	return &stockv1.JoinQueueResponse{
+		Ticket: domainTicketToProto(ticket),
+	}, nil
+}
+
+// GetQueuePosition returns a user's position in the waiting room of a product
+func (h *StockHandler) GetQueuePosition(
+	ctx context.Context,
+	req *stockv1.GetQueuePositionRequest,
+) (*stockv1.GetQueuePositionResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	ticket, err := h.admissionService.GetQueuePosition(ctx, req.ProductId, req.UserId)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, "get queue position failed",
+			zap.String("product_id", req.ProductId),
+			zap.String("user_id", req.UserId),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	return &stockv1.GetQueuePositionResponse{
+		Ticket: domainTicketToProto(ticket),
+	}, nil
+}
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+
+	stockv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/stock/v1"
+
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateAuction opens an auction for a product
+func (h *StockHandler) CreateAuction(
+	ctx context.Context,
+	req *stockv1.CreateAuctionRequest,
+) (*stockv1.CreateAuctionResponse, error) {
+	logger.InfoContext(ctx, "handling CreateAuction request",
+		zap.String("product_id", req.ProductId),
+		zap.String("seller_id", req.SellerId),
+	)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.SellerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "seller_id is required")
+	}
+	if req.StartPrice <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "start_price must be positive")
+	}
+	if req.MinIncrement <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "min_increment must be positive")
+	}
+	if req.ReservePrice < 0 {
+		return nil, status.Error(codes.InvalidArgument, "reserve_price cannot be negative")
+	}
+	if req.Currency == "" {
+		return nil, status.Error(codes.InvalidArgument, "currency is required")
+	}
+	if req.EndsAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "ends_at is required")
+	}
+
+	quantity := int(req.Quantity)
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	a, err := h.auctionService.CreateAuction(
+		ctx,
+		req.ProductId,
+		req.SellerId,
+		req.StartPrice,
+		req.MinIncrement,
+		req.ReservePrice,
+		req.Currency,
+		quantity,
+		req.EndsAt.AsTime(),
+	)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, "create auction failed",
+			zap.String("product_id", req.ProductId),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	return &stockv1.CreateAuctionResponse{
+		Auction: domainAuctionToProto(a),
+	}, nil
+}
+
+// PlaceBid places a bid on an open auction
+func (h *StockHandler) PlaceBid(
+	ctx context.Context,
+	req *stockv1.PlaceBidRequest,
+) (*stockv1.PlaceBidResponse, error) {
+	logger.DebugContext(ctx, "handling PlaceBid request",
+		zap.String("auction_id", req.AuctionId),
+		zap.String("bidder_id", req.BidderId),
+		zap.Int64("amount", req.Amount),
+	)
+
+	if req.AuctionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "auction_id is required")
+	}
+	if req.BidderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "bidder_id is required")
+	}
+	if req.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+
+	bidCount, err := h.auctionService.PlaceBid(ctx, req.AuctionId, req.BidderId, req.Amount)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, "place bid failed",
+			zap.String("auction_id", req.AuctionId),
+			zap.String("bidder_id", req.BidderId),
+			zap.Int64("amount", req.Amount),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	return &stockv1.PlaceBidResponse{
+		Accepted: true,
+		Amount:   req.Amount,
+		BidCount: bidCount,
+	}, nil
+}
+
+// GetAuction gets auction details
+func (h *StockHandler) GetAuction(
+	ctx context.Context,
+	req *stockv1.GetAuctionRequest,
+) (*stockv1.GetAuctionResponse, error) {
+	logger.DebugContext(ctx, "handling GetAuction request",
+		zap.String("auction_id", req.AuctionId),
+	)
+
+	if req.AuctionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "auction_id is required")
+	}
+
+	a, err := h.auctionService.GetAuction(ctx, req.AuctionId)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, "get auction failed",
+			zap.String("auction_id", req.AuctionId),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	return &stockv1.GetAuctionResponse{
+		Auction: domainAuctionToProto(a),
+	}, nil
+}
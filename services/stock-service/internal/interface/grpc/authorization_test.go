@@ -0,0 +1,223 @@
+package grpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testIdentitySecret = "test-secret"
+
+// Signatures the gateway issues with testIdentitySecret for "user-1", expiring at 1790000000
+const (
+	adminSellerSignature = "1790000000.03f8ee87c140c0f88a7ac4157d84539669ff7d979257127aec67d3ec8b176028"
+	buyerSignature       = "1790000000.c7dc6c2ca8528b5e08af3b98acb161ab9c3d0ea0a738f55d6e6f361edcb6d0a1"
+)
+
+func identityContext(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+// signedIdentityContext signs the identity as the gateway does, valid for a minute
+func signedIdentityContext(userID string, roles ...string) context.Context {
+	exp := time.Now().Add(time.Minute).Unix()
+	mac := hmac.New(sha256.New, []byte(testIdentitySecret))
+	fmt.Fprintf(mac, "%s|%s|%d", userID, strings.Join(roles, ","), exp)
+
+	pairs := []string{userIDMetadataKey, userID}
+	for _, role := range roles {
+		pairs = append(pairs, userRolesMetadataKey, role)
+	}
+	pairs = append(pairs, identitySignatureMetadataKey, fmt.Sprintf("%d.%s", exp, hex.EncodeToString(mac.Sum(nil))))
+	return identityContext(pairs...)
+}
+
+func TestVerifiedCallerRoles(t *testing.T) {
+	beforeExpiry := time.Unix(1790000000, 0).Add(-time.Second)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		now       time.Time
+		wantRoles []string
+		wantErr   error
+	}{
+		{
+			name: "signed admin",
+			ctx: identityContext(
+				userIDMetadataKey, "user-1",
+				userRolesMetadataKey, "admin",
+				userRolesMetadataKey, "seller",
+				identitySignatureMetadataKey, adminSellerSignature,
+			),
+			now:       beforeExpiry,
+			wantRoles: []string{"admin", "seller"},
+		},
+		{
+			name: "signed buyer",
+			ctx: identityContext(
+				userIDMetadataKey, "user-1",
+				userRolesMetadataKey, "buyer",
+				identitySignatureMetadataKey, buyerSignature,
+			),
+			now:       beforeExpiry,
+			wantRoles: []string{"buyer"},
+		},
+		{
+			name:    "no metadata",
+			ctx:     context.Background(),
+			now:     beforeExpiry,
+			wantErr: errIdentityNotSigned,
+		},
+		{
+			name: "unsigned roles",
+			ctx: identityContext(
+				userIDMetadataKey, "user-1",
+				userRolesMetadataKey, "admin",
+			),
+			now:     beforeExpiry,
+			wantErr: errIdentityNotSigned,
+		},
+		{
+			name: "role added to a buyer signature",
+			ctx: identityContext(
+				userIDMetadataKey, "user-1",
+				userRolesMetadataKey, "buyer",
+				userRolesMetadataKey, "admin",
+				identitySignatureMetadataKey, buyerSignature,
+			),
+			now:     beforeExpiry,
+			wantErr: errInvalidIdentitySignature,
+		},
+		{
+			name: "signature of another user",
+			ctx: identityContext(
+				userIDMetadataKey, "user-2",
+				userRolesMetadataKey, "admin",
+				userRolesMetadataKey, "seller",
+				identitySignatureMetadataKey, adminSellerSignature,
+			),
+			now:     beforeExpiry,
+			wantErr: errInvalidIdentitySignature,
+		},
+		{
+			name: "extended expiry",
+			ctx: identityContext(
+				userIDMetadataKey, "user-1",
+				userRolesMetadataKey, "buyer",
+				identitySignatureMetadataKey, "1790003600.c7dc6c2ca8528b5e08af3b98acb161ab9c3d0ea0a738f55d6e6f361edcb6d0a1",
+			),
+			now:     beforeExpiry,
+			wantErr: errInvalidIdentitySignature,
+		},
+		{
+			name: "signature without expiry",
+			ctx: identityContext(
+				userIDMetadataKey, "user-1",
+				userRolesMetadataKey, "buyer",
+				identitySignatureMetadataKey, "c7dc6c2ca8528b5e08af3b98acb161ab9c3d0ea0a738f55d6e6f361edcb6d0a1",
+			),
+			now:     beforeExpiry,
+			wantErr: errInvalidIdentitySignature,
+		},
+		{
+			name: "two user IDs",
+			ctx: identityContext(
+				userIDMetadataKey, "user-1",
+				userIDMetadataKey, "user-2",
+				userRolesMetadataKey, "buyer",
+				identitySignatureMetadataKey, buyerSignature,
+			),
+			now:     beforeExpiry,
+			wantErr: errIdentityNotSigned,
+		},
+		{
+			name: "expired",
+			ctx: identityContext(
+				userIDMetadataKey, "user-1",
+				userRolesMetadataKey, "buyer",
+				identitySignatureMetadataKey, buyerSignature,
+			),
+			now:     time.Unix(1790000000, 0),
+			wantErr: errIdentitySignatureExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles, err := verifiedCallerRoles(tt.ctx, []byte(testIdentitySecret), tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("verifiedCallerRoles() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(roles, tt.wantRoles) {
+				t.Fatalf("verifiedCallerRoles() roles = %v, want %v", roles, tt.wantRoles)
+			}
+		})
+	}
+}
+
+func TestAuthorizationInterceptor(t *testing.T) {
+	interceptor := AuthorizationInterceptor(testIdentitySecret)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{
+			name:     "public method without identity",
+			method:   "/stock.v1.StockService/GetStock",
+			ctx:      context.Background(),
+			wantCode: codes.OK,
+		},
+		{
+			name:     "admin method without identity",
+			method:   "/stock.v1.StockService/TriggerRecovery",
+			ctx:      context.Background(),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "admin method with unsigned admin role",
+			method:   "/deadletter.v1.DeadLetterService/ReplayDeadLetter",
+			ctx:      identityContext(userIDMetadataKey, "user-1", userRolesMetadataKey, "admin"),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "admin method with signed buyer",
+			method:   "/stock.v1.StockService/TriggerRecovery",
+			ctx:      signedIdentityContext("user-1", "buyer"),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "admin method with signed admin",
+			method:   "/stock.v1.StockService/TriggerRecovery",
+			ctx:      signedIdentityContext("user-1", "seller", "admin"),
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("interceptor code = %s, want %s", code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/admission"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
 	"google.golang.org/grpc/codes"
@@ -22,12 +24,21 @@ func mapDomainErrorToGRPC(err error) error {
 	if errors.Is(err, stock.ErrInvalidProductID) {
 		return status.Error(codes.InvalidArgument, "invalid product id")
 	}
+	if errors.Is(err, stock.ErrInvalidVariantID) {
+		return status.Error(codes.InvalidArgument, "invalid variant id")
+	}
 	if errors.Is(err, stock.ErrExceedsMaxQuantity) {
 		return status.Error(codes.InvalidArgument, "quantity exceeds maximum limit of 10")
 	}
 	if errors.Is(err, stock.ErrInvalidQuantity) {
 		return status.Error(codes.InvalidArgument, "invalid quantity")
 	}
+	if errors.Is(err, stock.ErrInvalidAdjustmentReason) {
+		return status.Error(codes.InvalidArgument, "invalid adjustment reason for this operation")
+	}
+	if errors.Is(err, stock.ErrAdjustmentNoteTooLong) {
+		return status.Error(codes.InvalidArgument, "adjustment note is too long")
+	}
 
 	// Reservation errors
 	if errors.Is(err, reservation.ErrReservationNotFound) {
@@ -48,6 +59,79 @@ func mapDomainErrorToGRPC(err error) error {
 	if errors.Is(err, reservation.ErrCanOnlyReleaseReserved) {
 		return status.Error(codes.FailedPrecondition, "only reserved reservations can be released")
 	}
+	if errors.Is(err, reservation.ErrPurchaseLimitExceeded) {
+		return status.Error(codes.FailedPrecondition, "purchase limit exceeded for this product")
+	}
+	if errors.Is(err, reservation.ErrSaleNotStarted) {
+		return status.Error(codes.FailedPrecondition, "sale has not started yet")
+	}
+	if errors.Is(err, reservation.ErrSaleEnded) {
+		return status.Error(codes.FailedPrecondition, "sale has ended")
+	}
+	if errors.Is(err, reservation.ErrInvalidIdempotencyKey) {
+		return status.Error(codes.InvalidArgument, "idempotency key must be 1 to 255 characters")
+	}
+	if errors.Is(err, reservation.ErrPriceUnavailable) {
+		return status.Error(codes.Unavailable, "product price is not available yet, please retry")
+	}
+	if errors.Is(err, reservation.ErrInvalidVariantID) {
+		return status.Error(codes.InvalidArgument, "invalid variant id")
+	}
+	if errors.Is(err, reservation.ErrVariantRequired) {
+		return status.Error(codes.InvalidArgument, "product has variants, variant_id is required")
+	}
+	if errors.Is(err, reservation.ErrVariantNotFound) {
+		return status.Error(codes.NotFound, "variant not found for this product")
+	}
+	if errors.Is(err, reservation.ErrIdempotencyKeyReused) {
+		return status.Error(codes.AlreadyExists, "idempotency key was already used with a different request")
+	}
+
+	// Waiting room errors
+	if errors.Is(err, admission.ErrAdmissionRequired) {
+		return status.Error(codes.PermissionDenied, "admission token is required for this product")
+	}
+	if errors.Is(err, admission.ErrInvalidAdmissionToken) {
+		return status.Error(codes.PermissionDenied, "invalid admission token")
+	}
+	if errors.Is(err, admission.ErrAdmissionExpired) {
+		return status.Error(codes.PermissionDenied, "admission token has expired")
+	}
+	if errors.Is(err, admission.ErrQueueNotEnabled) {
+		return status.Error(codes.FailedPrecondition, "waiting room is not enabled for this product")
+	}
+	if errors.Is(err, admission.ErrNotInQueue) {
+		return status.Error(codes.NotFound, "user is not in the waiting room")
+	}
+	if errors.Is(err, admission.ErrInvalidAdmissionRate) {
+		return status.Error(codes.InvalidArgument, "admission rate must be positive")
+	}
+
+	// Auction errors
+	if errors.Is(err, auction.ErrAuctionNotFound) {
+		return status.Error(codes.NotFound, "auction not found")
+	}
+	if errors.Is(err, auction.ErrBidTooLow) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, auction.ErrAuctionNotOpen) {
+		return status.Error(codes.FailedPrecondition, "auction is not open for bidding")
+	}
+	if errors.Is(err, auction.ErrSellerCannotBid) {
+		return status.Error(codes.PermissionDenied, "seller cannot bid on own auction")
+	}
+	if errors.Is(err, auction.ErrAuctionAlreadyExists) {
+		return status.Error(codes.AlreadyExists, "product already has an open auction")
+	}
+	if errors.Is(err, auction.ErrProductSoldByAuction) {
+		return status.Error(codes.FailedPrecondition, "product can only be purchased through auction")
+	}
+	if errors.Is(err, auction.ErrNotAuctionProduct) {
+		return status.Error(codes.FailedPrecondition, "product is not sold by auction")
+	}
+	if errors.Is(err, auction.ErrProductNotActive) {
+		return status.Error(codes.FailedPrecondition, "product is not active")
+	}
 
 	// Validation errors
 	if isValidationError(err) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/application/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/recovery"
 
 	"go.uber.org/zap"
@@ -15,21 +16,32 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const (
+	defaultAdjustmentPageSize = 20
+	maxAdjustmentPageSize     = 100
+)
+
 // StockHandler implements StockService gRPC server
 type StockHandler struct {
 	stockv1.UnimplementedStockServiceServer
-	stockService *service.StockService
-	recovery     *recovery.RedisRecovery
+	stockService     *service.StockService
+	auctionService   *service.AuctionService
+	admissionService *service.AdmissionService
+	recovery         *recovery.RedisRecovery
 }
 
 // NewStockHandler creates a new StockHandler
 func NewStockHandler(
 	stockService *service.StockService,
+	auctionService *service.AuctionService,
+	admissionService *service.AdmissionService,
 	recovery *recovery.RedisRecovery,
 ) *StockHandler {
 	return &StockHandler{
-		stockService: stockService,
-		recovery:     recovery,
+		stockService:     stockService,
+		auctionService:   auctionService,
+		admissionService: admissionService,
+		recovery:         recovery,
 	}
 }
 
@@ -40,6 +52,7 @@ func (h *StockHandler) SetStock(
 ) (*stockv1.SetStockResponse, error) {
 	logger.InfoContext(ctx, "handling SetStock request",
 		zap.String("product_id", req.ProductId),
+		zap.String("variant_id", req.VariantId),
 		zap.Int32("quantity", req.Quantity),
 	)
 
@@ -50,7 +63,7 @@ func (h *StockHandler) SetStock(
 		return nil, status.Error(codes.InvalidArgument, "quantity cannot be negative")
 	}
 
-	if err := h.stockService.SetStock(ctx, req.ProductId, int(req.Quantity)); err != nil {
+	if err := h.stockService.SetStock(ctx, req.ProductId, req.VariantId, int(req.Quantity)); err != nil {
 		grpcErr := mapDomainErrorToGRPC(err)
 		logError(ctx, grpcErr, "set stock failed",
 			zap.String("product_id", req.ProductId),
@@ -60,7 +73,7 @@ func (h *StockHandler) SetStock(
 	}
 
 	// Get updated stock
-	stk, err := h.stockService.GetStock(ctx, req.ProductId)
+	stk, err := h.stockService.GetStock(ctx, req.ProductId, req.VariantId)
 	if err != nil {
 		grpcErr := mapDomainErrorToGRPC(err)
 		logger.ErrorContext(ctx, "failed to get stock after set",
@@ -93,7 +106,7 @@ func (h *StockHandler) GetStock(
 		return nil, status.Error(codes.InvalidArgument, "product_id is required")
 	}
 
-	stk, err := h.stockService.GetStock(ctx, req.ProductId)
+	stk, err := h.stockService.GetStock(ctx, req.ProductId, req.VariantId)
 	if err != nil {
 		grpcErr := mapDomainErrorToGRPC(err)
 		code := status.Code(grpcErr)
@@ -117,6 +130,119 @@ func (h *StockHandler) GetStock(
 	}, nil
 }
 
+// AddStock adds stock to a product with a reason code
+func (h *StockHandler) AddStock(
+	ctx context.Context,
+	req *stockv1.AdjustStockRequest,
+) (*stockv1.AdjustStockResponse, error) {
+	return h.adjustStock(ctx, req, h.stockService.AddStock, "add stock")
+}
+
+// RemoveStock removes stock from a product with a reason code
+func (h *StockHandler) RemoveStock(
+	ctx context.Context,
+	req *stockv1.AdjustStockRequest,
+) (*stockv1.AdjustStockResponse, error) {
+	return h.adjustStock(ctx, req, h.stockService.RemoveStock, "remove stock")
+}
+
+type adjustStockFunc func(ctx context.Context, productID, variantID, actorID string, quantity int, reason, note string) (*stock.Adjustment, error)
+
+func (h *StockHandler) adjustStock(
+	ctx context.Context,
+	req *stockv1.AdjustStockRequest,
+	adjust adjustStockFunc,
+	operation string,
+) (*stockv1.AdjustStockResponse, error) {
+	logger.InfoContext(ctx, fmt.Sprintf("handling %s request", operation),
+		zap.String("product_id", req.ProductId),
+		zap.String("variant_id", req.VariantId),
+		zap.String("actor_id", req.ActorId),
+		zap.Int32("quantity", req.Quantity),
+		zap.String("reason", req.Reason),
+	)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.ActorId == "" {
+		return nil, status.Error(codes.InvalidArgument, "actor_id is required")
+	}
+	if req.Quantity <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be positive")
+	}
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	adj, err := adjust(ctx, req.ProductId, req.VariantId, req.ActorId, int(req.Quantity), req.Reason, req.Note)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, operation+" failed",
+			zap.String("product_id", req.ProductId),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	stk, err := h.stockService.GetStock(ctx, req.ProductId, req.VariantId)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logger.ErrorContext(ctx, "failed to get stock after adjustment",
+			zap.String("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, grpcErr
+	}
+
+	return &stockv1.AdjustStockResponse{
+		Adjustment: domainAdjustmentToProto(adj),
+		Stock:      domainStockToProto(stk),
+	}, nil
+}
+
+// ListStockAdjustments lists the adjustment history of a product
+func (h *StockHandler) ListStockAdjustments(
+	ctx context.Context,
+	req *stockv1.ListStockAdjustmentsRequest,
+) (*stockv1.ListStockAdjustmentsResponse, error) {
+	logger.DebugContext(ctx, "handling ListStockAdjustments request",
+		zap.String("product_id", req.ProductId),
+	)
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 || limit > maxAdjustmentPageSize {
+		limit = defaultAdjustmentPageSize
+	}
+	offset := int(req.Offset)
+	if offset < 0 {
+		offset = 0
+	}
+
+	adjustments, err := h.stockService.ListAdjustments(ctx, req.ProductId, limit, offset)
+	if err != nil {
+		grpcErr := mapDomainErrorToGRPC(err)
+		logError(ctx, grpcErr, "list stock adjustments failed",
+			zap.String("product_id", req.ProductId),
+			zap.String("error", err.Error()),
+		)
+		return nil, grpcErr
+	}
+
+	resp := &stockv1.ListStockAdjustmentsResponse{
+		Adjustments: make([]*stockv1.StockAdjustment, 0, len(adjustments)),
+	}
+	for _, adj := range adjustments {
+		resp.Adjustments = append(resp.Adjustments, domainAdjustmentToProto(adj))
+	}
+
+	return resp, nil
+}
+
 // Reserve reserves stock for a user
 func (h *StockHandler) Reserve(
 	ctx context.Context,
@@ -124,6 +250,7 @@ func (h *StockHandler) Reserve(
 ) (*stockv1.ReserveResponse, error) {
 	logger.InfoContext(ctx, "handling Reserve request",
 		zap.String("product_id", req.ProductId),
+		zap.String("variant_id", req.VariantId),
 		zap.String("user_id", req.UserId),
 		zap.Int32("quantity", req.Quantity),
 	)
@@ -141,7 +268,7 @@ func (h *StockHandler) Reserve(
 		return nil, status.Error(codes.InvalidArgument, "quantity cannot exceed 10")
 	}
 
-	res, remainingStock, err := h.stockService.Reserve(ctx, req.ProductId, req.UserId, int(req.Quantity))
+	res, remainingStock, err := h.stockService.Reserve(ctx, req.ProductId, req.VariantId, req.UserId, int(req.Quantity), req.AdmissionToken, req.IdempotencyKey)
 	if err != nil {
 		grpcErr := mapDomainErrorToGRPC(err)
 		logError(ctx, grpcErr, "reserve stock failed",
@@ -237,27 +364,28 @@ func (h *StockHandler) GetReservation(
 	}, nil
 }
 
-// TriggerRecovery triggers Redis recovery (admin operation)
+// TriggerRecovery triggers Redis recovery (admin operation, enforced by AuthorizationInterceptor)
 func (h *StockHandler) TriggerRecovery(
 	ctx context.Context,
 	req *stockv1.TriggerRecoveryRequest,
 ) (*stockv1.TriggerRecoveryResponse, error) {
 	logger.InfoContext(ctx, "admin: triggering recovery",
 		zap.String("recovery_type", req.RecoveryType),
+		zap.String("caller_id", callerID(ctx)),
 	)
 
-	// TODO: Add authorization check (only admin can trigger)
-
 	var err error
 	var count int
 
 	switch req.RecoveryType {
 	case "reservations":
 		err = h.recovery.RecoverActiveReservations(ctx)
+	case "stock":
+		err = h.recovery.ReconcileStock(ctx)
 	case "full":
 		err = h.recovery.FullRecovery(ctx)
 	default:
-		return nil, status.Error(codes.InvalidArgument, "invalid recovery type, must be 'reservations' or 'full'")
+		return nil, status.Error(codes.InvalidArgument, "invalid recovery type, must be 'reservations', 'stock' or 'full'")
 	}
 
 	if err != nil {
@@ -5,13 +5,16 @@ import (
 	"time"
 
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/logger"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/metrics"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/common/tracing"
 	"github.com/samborkent/uuidv7"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
-// UnaryServerInterceptor returns a gRPC unary interceptor for tracing and logging
+// UnaryServerInterceptor returns a gRPC unary interceptor for tracing, logging and metrics
 func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -31,6 +34,7 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 		resp, err := handler(ctx, req)
 
 		latency := time.Since(start)
+		metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err), latency)
 
 		if err != nil {
 			logger.DebugContext(ctx, "grpc request failed",
@@ -50,6 +54,11 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 }
 
 func extractOrGenerateTraceID(ctx context.Context) string {
+	// Span started by the OpenTelemetry stats handler from the incoming traceparent
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		return traceID
+	}
+
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return uuidv7.New().String()
@@ -2,6 +2,8 @@ package grpc
 
 import (
 	stockv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/stock/v1"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/admission"
+	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/auction"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/reservation"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/domain/stock"
 
@@ -12,17 +14,34 @@ import (
 func domainStockToProto(s *stock.Stock) *stockv1.Stock {
 	return &stockv1.Stock{
 		ProductId:       s.ProductID().String(),
+		VariantId:       s.VariantID().String(),
 		Quantity:        int32(s.Quantity()),
 		InitialQuantity: int32(s.InitialQuantity()),
 		UpdatedAt:       timestamppb.New(s.UpdatedAt()),
 	}
 }
 
+// domainAdjustmentToProto converts domain Adjustment to proto StockAdjustment
+func domainAdjustmentToProto(a *stock.Adjustment) *stockv1.StockAdjustment {
+	return &stockv1.StockAdjustment{
+		Id:            a.ID(),
+		ProductId:     a.ProductID().String(),
+		VariantId:     a.VariantID().String(),
+		ActorId:       a.ActorID(),
+		Delta:         int32(a.Delta()),
+		Reason:        string(a.Reason()),
+		Note:          a.Note(),
+		QuantityAfter: int32(a.QuantityAfter()),
+		CreatedAt:     timestamppb.New(a.CreatedAt()),
+	}
+}
+
 // domainReservationToProto converts domain Reservation to proto Reservation
 func domainReservationToProto(r *reservation.Reservation) *stockv1.Reservation {
 	proto := &stockv1.Reservation{
 		Id:         r.ID().String(),
 		ProductId:  r.ProductID().String(),
+		VariantId:  r.VariantID().String(),
 		UserId:     r.UserID().String(),
 		Quantity:   int32(r.Quantity()),
 		Status:     string(r.Status()),
@@ -36,3 +55,61 @@ func domainReservationToProto(r *reservation.Reservation) *stockv1.Reservation {
 
 	return proto
 }
+
+// domainAuctionToProto converts domain Auction to proto Auction
+func domainAuctionToProto(a *auction.Auction) *stockv1.Auction {
+	proto := &stockv1.Auction{
+		Id:             a.ID().String(),
+		ProductId:      a.ProductID().String(),
+		SellerId:       a.SellerID().String(),
+		StartPrice:     a.StartPrice(),
+		MinIncrement:   a.MinIncrement(),
+		ReserveMet:     a.IsReserveMet(),
+		Currency:       a.Currency(),
+		Quantity:       int32(a.Quantity()),
+		Status:         string(a.Status()),
+		EndsAt:         timestamppb.New(a.EndsAt()),
+		HighestBid:     a.HighestBid(),
+		BidCount:       int32(a.BidCount()),
+		MinimumNextBid: a.MinimumNextBid(),
+		CreatedAt:      timestamppb.New(a.CreatedAt()),
+	}
+
+	if a.Status() == auction.AuctionStatusWon {
+		winnerID := a.HighestBidder().String()
+		proto.WinnerId = &winnerID
+	}
+	if reservationID := a.ReservationID(); reservationID != nil {
+		proto.ReservationId = reservationID
+	}
+
+	return proto
+}
+
+// domainQueueStatusToProto converts a waiting room status to proto QueueStatus
+func domainQueueStatusToProto(s *admission.QueueStatus) *stockv1.QueueStatus {
+	return &stockv1.QueueStatus{
+		ProductId:     s.ProductID,
+		Enabled:       s.Enabled,
+		AdmissionRate: int32(s.AdmissionRate),
+		Waiting:       s.Waiting,
+	}
+}
+
+// domainTicketToProto converts a waiting room ticket to proto QueueTicket
+func domainTicketToProto(t *admission.Ticket) *stockv1.QueueTicket {
+	ticket := &stockv1.QueueTicket{
+		ProductId:            t.ProductID,
+		UserId:               t.UserID,
+		Position:             t.Position,
+		Admitted:             t.Admitted,
+		AdmissionToken:       t.Token,
+		EstimatedWaitSeconds: int64(t.EstimatedWait.Seconds()),
+	}
+
+	if t.Admitted {
+		ticket.AdmissionExpiresAt = timestamppb.New(t.ExpiresAt)
+	}
+
+	return ticket
+}
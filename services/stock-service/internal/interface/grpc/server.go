@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"net"
 
+	deadletterv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/deadletter/v1"
 	stockv1 "github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/shared/proto/stock/v1"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/application/service"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/config"
 	"github.com/eric-cw-hsu/high-concurrency-distributed-auction-system/stock-service/internal/infrastructure/recovery"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -24,19 +26,25 @@ type Server struct {
 func NewServer(
 	cfg *config.ServerConfig,
 	stockService *service.StockService,
+	auctionService *service.AuctionService,
+	admissionService *service.AdmissionService,
 	recovery *recovery.RedisRecovery,
+	deadLetterHandler *DeadLetterHandler,
 ) *Server {
 	grpcServer := grpc.NewServer(
 		grpc.MaxRecvMsgSize(10*1024*1024),
 		grpc.MaxSendMsgSize(10*1024*1024),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
 			UnaryServerInterceptor(),
+			AuthorizationInterceptor(cfg.IdentitySecret),
 		),
 	)
 
-	handler := NewStockHandler(stockService, recovery)
+	handler := NewStockHandler(stockService, auctionService, admissionService, recovery)
 
 	stockv1.RegisterStockServiceServer(grpcServer, handler)
+	deadletterv1.RegisterDeadLetterServiceServer(grpcServer, deadLetterHandler)
 
 	reflection.Register(grpcServer)
 